@@ -107,6 +107,10 @@ func NewTypeMeta(kind ResourceKind) TypeMeta {
 	}
 }
 
+// FieldManager is the name of the field manager recorded for changes made through the dashboard, e.g. by
+// server-side apply.
+const FieldManager = "dashboard"
+
 // ResourceKind is an unique name for each resource. It can used for API discovery and generic
 // code that does things based on the kind. For example, there may be a generic "deleter"
 // that based on resource kind, name and namespace deletes it.
@@ -124,16 +128,20 @@ const (
 	ResourceKindCronJob                  = "cronjob"
 	ResourceKindLimitRange               = "limitrange"
 	ResourceKindNamespace                = "namespace"
+	ResourceKindNetworkPolicy            = "networkpolicy"
 	ResourceKindNode                     = "node"
 	ResourceKindPersistentVolumeClaim    = "persistentvolumeclaim"
 	ResourceKindPersistentVolume         = "persistentvolume"
 	ResourceKindCustomResourceDefinition = "customresourcedefinition"
 	ResourceKindPod                      = "pod"
+	ResourceKindPodDisruptionBudget      = "poddisruptionbudget"
+	ResourceKindPriorityClass            = "priorityclass"
 	ResourceKindReplicaSet               = "replicaset"
 	ResourceKindReplicationController    = "replicationcontroller"
 	ResourceKindResourceQuota            = "resourcequota"
 	ResourceKindSecret                   = "secret"
 	ResourceKindService                  = "service"
+	ResourceKindServiceAccount           = "serviceaccount"
 	ResourceKindStatefulSet              = "statefulset"
 	ResourceKindStorageClass             = "storageclass"
 	ResourceKindClusterRole              = "clusterrole"
@@ -174,6 +182,9 @@ const (
 	ClientTypeAutoscalingClient   = "autoscalingclient"
 	ClientTypeStorageClient       = "storageclient"
 	ClientTypeRbacClient          = "rbacclient"
+	ClientTypeNetworkingClient    = "networkingclient"
+	ClientTypePolicyClient        = "policyclient"
+	ClientTypeSchedulingClient    = "schedulingclient"
 	ClientTypeAPIExtensionsClient = "apiextensionsclient"
 	ClientTypePluginsClient       = "plugin"
 )
@@ -203,16 +214,20 @@ var KindToAPIMapping = map[string]APIMapping{
 	ResourceKindCronJob:                  {"cronjobs", ClientTypeBetaBatchClient, true},
 	ResourceKindLimitRange:               {"limitrange", ClientTypeDefault, true},
 	ResourceKindNamespace:                {"namespaces", ClientTypeDefault, false},
+	ResourceKindNetworkPolicy:            {"networkpolicies", ClientTypeNetworkingClient, true},
 	ResourceKindNode:                     {"nodes", ClientTypeDefault, false},
 	ResourceKindPersistentVolumeClaim:    {"persistentvolumeclaims", ClientTypeDefault, true},
 	ResourceKindPersistentVolume:         {"persistentvolumes", ClientTypeDefault, false},
 	ResourceKindCustomResourceDefinition: {"customresourcedefinitions", ClientTypeAPIExtensionsClient, false},
 	ResourceKindPod:                      {"pods", ClientTypeDefault, true},
+	ResourceKindPodDisruptionBudget:      {"poddisruptionbudgets", ClientTypePolicyClient, true},
+	ResourceKindPriorityClass:            {"priorityclasses", ClientTypeSchedulingClient, false},
 	ResourceKindReplicaSet:               {"replicasets", ClientTypeAppsClient, true},
 	ResourceKindReplicationController:    {"replicationcontrollers", ClientTypeDefault, true},
 	ResourceKindResourceQuota:            {"resourcequotas", ClientTypeDefault, true},
 	ResourceKindSecret:                   {"secrets", ClientTypeDefault, true},
 	ResourceKindService:                  {"services", ClientTypeDefault, true},
+	ResourceKindServiceAccount:           {"serviceaccounts", ClientTypeDefault, true},
 	ResourceKindStatefulSet:              {"statefulsets", ClientTypeAppsClient, true},
 	ResourceKindStorageClass:             {"storageclasses", ClientTypeStorageClient, false},
 	ResourceKindEndpoint:                 {"endpoints", ClientTypeDefault, true},
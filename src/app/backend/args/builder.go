@@ -48,6 +48,30 @@ func (self *holderBuilder) SetMetricClientCheckPeriod(period int) *holderBuilder
 	return self
 }
 
+// SetLoginMaxFailedAttempts 'login-max-failed-attempts' argument of Dashboard binary.
+func (self *holderBuilder) SetLoginMaxFailedAttempts(attempts int) *holderBuilder {
+	self.holder.loginMaxFailedAttempts = attempts
+	return self
+}
+
+// SetLoginLockoutDuration 'login-lockout-duration' argument of Dashboard binary.
+func (self *holderBuilder) SetLoginLockoutDuration(duration int) *holderBuilder {
+	self.holder.loginLockoutDuration = duration
+	return self
+}
+
+// SetLoginMaxLockoutDuration 'login-max-lockout-duration' argument of Dashboard binary.
+func (self *holderBuilder) SetLoginMaxLockoutDuration(duration int) *holderBuilder {
+	self.holder.loginMaxLockoutDuration = duration
+	return self
+}
+
+// SetLoginClientIPHeader 'login-client-ip-header' argument of Dashboard binary.
+func (self *holderBuilder) SetLoginClientIPHeader(header string) *holderBuilder {
+	self.holder.loginClientIPHeader = header
+	return self
+}
+
 // SetInsecureBindAddress 'insecure-bind-address' argument of Dashboard binary.
 func (self *holderBuilder) SetInsecureBindAddress(ip net.IP) *holderBuilder {
 	self.holder.insecureBindAddress = ip
@@ -108,6 +132,42 @@ func (self *holderBuilder) SetKubeConfigFile(kubeConfigFile string) *holderBuild
 	return self
 }
 
+// SetClusterRegistryKubeConfig 'cluster-registry-kubeconfig' argument of Dashboard binary.
+func (self *holderBuilder) SetClusterRegistryKubeConfig(kubeConfig string) *holderBuilder {
+	self.holder.clusterKubeConfig = kubeConfig
+	return self
+}
+
+// SetClusterRegistryConfigMap 'cluster-registry-configmap' argument of Dashboard binary.
+func (self *holderBuilder) SetClusterRegistryConfigMap(configMap string) *holderBuilder {
+	self.holder.clusterConfigMap = configMap
+	return self
+}
+
+// SetClusterRegistrySharedIdentity 'cluster-registry-shared-identity' argument of Dashboard binary.
+func (self *holderBuilder) SetClusterRegistrySharedIdentity(clusters []string) *holderBuilder {
+	self.holder.clusterSharedIdentity = clusters
+	return self
+}
+
+// SetSnapshotDir 'snapshot-dir' argument of Dashboard binary.
+func (self *holderBuilder) SetSnapshotDir(snapshotDir string) *holderBuilder {
+	self.holder.snapshotDir = snapshotDir
+	return self
+}
+
+// SetGitDir 'git-dir' argument of Dashboard binary.
+func (self *holderBuilder) SetGitDir(gitDir string) *holderBuilder {
+	self.holder.gitDir = gitDir
+	return self
+}
+
+// SetGitPath 'git-path' argument of Dashboard binary.
+func (self *holderBuilder) SetGitPath(gitPath string) *holderBuilder {
+	self.holder.gitPath = gitPath
+	return self
+}
+
 // SetSystemBanner 'system-banner' argument of Dashboard binary.
 func (self *holderBuilder) SetSystemBanner(systemBanner string) *holderBuilder {
 	self.holder.systemBanner = systemBanner
@@ -29,6 +29,9 @@ type holder struct {
 	port                    int
 	tokenTTL                int
 	metricClientCheckPeriod int
+	loginMaxFailedAttempts  int
+	loginLockoutDuration    int
+	loginMaxLockoutDuration int
 
 	insecureBindAddress net.IP
 	bindAddress         net.IP
@@ -41,12 +44,19 @@ type holder struct {
 	heapsterHost         string
 	sidecarHost          string
 	kubeConfigFile       string
+	clusterKubeConfig    string
+	clusterConfigMap     string
+	snapshotDir          string
+	gitDir               string
+	gitPath              string
 	systemBanner         string
 	systemBannerSeverity string
 	apiLogLevel          string
 	namespace            string
+	loginClientIPHeader  string
 
-	authenticationMode []string
+	authenticationMode    []string
+	clusterSharedIdentity []string
 
 	autoGenerateCertificates  bool
 	enableInsecureLogin       bool
@@ -77,6 +87,26 @@ func (self *holder) GetMetricClientCheckPeriod() int {
 	return self.metricClientCheckPeriod
 }
 
+// GetLoginMaxFailedAttempts 'login-max-failed-attempts' argument of Dashboard binary.
+func (self *holder) GetLoginMaxFailedAttempts() int {
+	return self.loginMaxFailedAttempts
+}
+
+// GetLoginLockoutDuration 'login-lockout-duration' argument of Dashboard binary.
+func (self *holder) GetLoginLockoutDuration() int {
+	return self.loginLockoutDuration
+}
+
+// GetLoginMaxLockoutDuration 'login-max-lockout-duration' argument of Dashboard binary.
+func (self *holder) GetLoginMaxLockoutDuration() int {
+	return self.loginMaxLockoutDuration
+}
+
+// GetLoginClientIPHeader 'login-client-ip-header' argument of Dashboard binary.
+func (self *holder) GetLoginClientIPHeader() string {
+	return self.loginClientIPHeader
+}
+
 // GetInsecureBindAddress 'insecure-bind-address' argument of Dashboard binary.
 func (self *holder) GetInsecureBindAddress() net.IP {
 	return self.insecureBindAddress
@@ -135,6 +165,36 @@ func (self *holder) GetKubeConfigFile() string {
 	return self.kubeConfigFile
 }
 
+// GetClusterRegistryKubeConfig 'cluster-registry-kubeconfig' argument of Dashboard binary.
+func (self *holder) GetClusterRegistryKubeConfig() string {
+	return self.clusterKubeConfig
+}
+
+// GetClusterRegistryConfigMap 'cluster-registry-configmap' argument of Dashboard binary.
+func (self *holder) GetClusterRegistryConfigMap() string {
+	return self.clusterConfigMap
+}
+
+// GetClusterRegistrySharedIdentity 'cluster-registry-shared-identity' argument of Dashboard binary.
+func (self *holder) GetClusterRegistrySharedIdentity() []string {
+	return self.clusterSharedIdentity
+}
+
+// GetSnapshotDir 'snapshot-dir' argument of Dashboard binary.
+func (self *holder) GetSnapshotDir() string {
+	return self.snapshotDir
+}
+
+// GetGitDir 'git-dir' argument of Dashboard binary.
+func (self *holder) GetGitDir() string {
+	return self.gitDir
+}
+
+// GetGitPath 'git-path' argument of Dashboard binary.
+func (self *holder) GetGitPath() string {
+	return self.gitPath
+}
+
 // GetSystemBanner 'system-banner' argument of Dashboard binary.
 func (self *holder) GetSystemBanner() string {
 	return self.systemBanner
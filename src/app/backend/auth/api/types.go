@@ -108,8 +108,8 @@ type TokenManager interface {
 // Authenticator represents authentication methods supported by Dashboard. Currently supported types are:
 //    - Token based - Any bearer token accepted by apiserver
 //	  - Basic - Username and password based authentication. Requires that apiserver has basic auth enabled also
-//    - Kubeconfig based - Authenticates user based on kubeconfig file. Only token/basic modes are supported within
-// 		the kubeconfig file.
+//    - Kubeconfig based - Authenticates user based on kubeconfig file. Only token/basic modes and embedded client
+// 		certificates are supported within the kubeconfig file.
 type Authenticator interface {
 	// GetAuthInfo returns filled AuthInfo structure that can be used for K8S api client creation.
 	GetAuthInfo() (api.AuthInfo, error)
@@ -127,6 +127,9 @@ type LoginSpec struct {
 	// KubeConfig is the content of users' kubeconfig file. It will be parsed and auth data will be extracted.
 	// Kubeconfig can not contain any paths. All data has to be provided within the file.
 	KubeConfig string `json:"kubeconfig,omitempty"`
+	// SourceIP is the address of the client that sent login request. It is set by the backend and used to throttle
+	// failed login attempts. It can not be provided by the client.
+	SourceIP string `json:"-"`
 }
 
 // AuthResponse is returned from our backend as a response for login/refresh requests. It contains generated JWEToken
@@ -15,10 +15,15 @@
 package auth
 
 import (
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/emicklei/go-restful"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 
+	"github.com/kubernetes/dashboard/src/app/backend/args"
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"github.com/kubernetes/dashboard/src/app/backend/validation"
@@ -64,8 +69,12 @@ func (self AuthHandler) handleLogin(request *restful.Request, response *restful.
 		return
 	}
 
+	loginSpec.SourceIP = getSourceIP(request)
 	loginResponse, err := self.manager.Login(loginSpec)
 	if err != nil {
+		if retryAfter, ok := k8sErrors.SuggestsClientDelay(err); ok {
+			response.AddHeader("Retry-After", strconv.Itoa(retryAfter))
+		}
 		response.AddHeader("Content-Type", "text/plain")
 		response.WriteErrorString(errors.HandleHTTPError(err), err.Error()+"\n")
 		return
@@ -107,6 +116,24 @@ func (self *AuthHandler) handleLoginSkippable(request *restful.Request, response
 	response.WriteHeaderAndEntity(http.StatusOK, authApi.LoginSkippableResponse{Skippable: self.manager.AuthenticationSkippable()})
 }
 
+// Returns IP address of the client that sent the request without the port. If the client IP header is configured,
+// the last address of the header is used, as it is the one added by the trusted proxy.
+func getSourceIP(request *restful.Request) string {
+	if header := args.Holder.GetLoginClientIPHeader(); len(header) > 0 {
+		addresses := strings.Split(request.Request.Header.Get(header), ",")
+		if address := strings.TrimSpace(addresses[len(addresses)-1]); len(address) > 0 {
+			return address
+		}
+	}
+
+	host, _, err := net.SplitHostPort(request.Request.RemoteAddr)
+	if err != nil {
+		return request.Request.RemoteAddr
+	}
+
+	return host
+}
+
 // NewAuthHandler created AuthHandler instance.
 func NewAuthHandler(manager authApi.AuthManager) AuthHandler {
 	return AuthHandler{manager: manager}
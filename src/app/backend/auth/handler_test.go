@@ -15,9 +15,12 @@
 package auth
 
 import (
+	"net/http"
 	"testing"
 
 	restful "github.com/emicklei/go-restful"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
 )
 
 func TestIntegrationHandler_Install(t *testing.T) {
@@ -29,3 +32,31 @@ func TestIntegrationHandler_Install(t *testing.T) {
 		t.Error("Failed to install routes.")
 	}
 }
+
+func TestGetSourceIP(t *testing.T) {
+	defer args.GetHolderBuilder().SetLoginClientIPHeader("")
+
+	cases := []struct {
+		info     string
+		header   string
+		value    string
+		expected string
+	}{
+		{"remote address", "", "10.0.0.1", "192.168.0.1"},
+		{"missing header", "X-Forwarded-For", "", "192.168.0.1"},
+		{"last forwarded address", "X-Forwarded-For", "1.2.3.4, 10.0.0.1", "10.0.0.1"},
+		{"single address", "X-Real-IP", "10.0.0.2", "10.0.0.2"},
+	}
+
+	for _, c := range cases {
+		args.GetHolderBuilder().SetLoginClientIPHeader(c.header)
+		request := restful.NewRequest(&http.Request{RemoteAddr: "192.168.0.1:54321", Header: http.Header{}})
+		if len(c.value) > 0 {
+			request.Request.Header.Set(c.header, c.value)
+		}
+
+		if actual := getSourceIP(request); actual != c.expected {
+			t.Errorf("getSourceIP() for %s == %s, expected %s", c.info, actual, c.expected)
+		}
+	}
+}
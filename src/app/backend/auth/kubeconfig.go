@@ -15,6 +15,11 @@
 package auth
 
 import (
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 
@@ -24,6 +29,8 @@ import (
 
 // Below structures represent structure of kubeconfig file. They only contain fields required to gather data needed
 // to log in user. It should support same auth options as defined in auth/api/types.go file. Currently: basic, token.
+// Additionally embedded client certificates are supported. Fields that reference local files or require external
+// binaries (exec, auth-provider plugins) are parsed only to report them as unsupported.
 
 type contextInfo struct {
 	User string `yaml:"user"`
@@ -44,14 +51,25 @@ type authProviderConfig struct {
 }
 
 type authProviderInfo struct {
+	Name   string             `yaml:"name"`
 	Config authProviderConfig `yaml:"config"`
 }
 
+type execInfo struct {
+	Command string `yaml:"command"`
+}
+
 type userInfo struct {
-	AuthProvider authProviderInfo `yaml:"auth-provider"`
-	Token        string           `yaml:"token"`
-	Username     string           `yaml:"username"`
-	Password     string           `yaml:"password"`
+	AuthProvider          authProviderInfo `yaml:"auth-provider"`
+	Exec                  *execInfo        `yaml:"exec"`
+	ClientCertificate     string           `yaml:"client-certificate"`
+	ClientCertificateData string           `yaml:"client-certificate-data"`
+	ClientKey             string           `yaml:"client-key"`
+	ClientKeyData         string           `yaml:"client-key-data"`
+	Token                 string           `yaml:"token"`
+	TokenFile             string           `yaml:"tokenFile"`
+	Username              string           `yaml:"username"`
+	Password              string           `yaml:"password"`
 }
 
 type kubeConfig struct {
@@ -120,11 +138,26 @@ func (self *kubeConfigAuthenticator) getAuthInfo(info userInfo) (api.AuthInfo, e
 		info.Token = info.AuthProvider.Config.AccessToken
 	}
 
-	if len(info.Token) == 0 && (len(info.Password) == 0 || len(info.Username) == 0) {
+	certData, keyData, err := self.getClientCertificate(info)
+	if err != nil {
+		return api.AuthInfo{}, err
+	}
+
+	if len(info.Token) == 0 && (len(info.Password) == 0 || len(info.Username) == 0) && len(certData) == 0 {
+		if unsupported := self.getUnsupportedFields(info); len(unsupported) > 0 {
+			return api.AuthInfo{}, errors.NewInvalid(fmt.Sprintf("Unsupported authentication options found in "+
+				"kubeconfig file: %s. Only token, username/password and embedded client-certificate-data/"+
+				"client-key-data can be used.", strings.Join(unsupported, ", ")))
+		}
+
 		return api.AuthInfo{}, errors.NewInvalid("Not enough data to create auth info structure.")
 	}
 
-	result := api.AuthInfo{}
+	result := api.AuthInfo{
+		ClientCertificateData: certData,
+		ClientKeyData:         keyData,
+	}
+
 	if self.authModes.IsEnabled(authApi.Token) {
 		result.Token = info.Token
 	}
@@ -137,6 +170,61 @@ func (self *kubeConfigAuthenticator) getAuthInfo(info userInfo) (api.AuthInfo, e
 	return result, nil
 }
 
+// Returns decoded and validated PEM encoded client certificate and key embedded in user info. Both are nil if no
+// client certificate has been provided.
+func (self *kubeConfigAuthenticator) getClientCertificate(info userInfo) ([]byte, []byte, error) {
+	if len(info.ClientCertificateData) == 0 && len(info.ClientKeyData) == 0 {
+		return nil, nil, nil
+	}
+
+	if len(info.ClientCertificateData) == 0 || len(info.ClientKeyData) == 0 {
+		return nil, nil, errors.NewInvalid("Both client-certificate-data and client-key-data have to be provided.")
+	}
+
+	certData, err := base64.StdEncoding.DecodeString(info.ClientCertificateData)
+	if err != nil {
+		return nil, nil, errors.NewInvalid(fmt.Sprintf("Could not decode client-certificate-data: %s", err.Error()))
+	}
+
+	keyData, err := base64.StdEncoding.DecodeString(info.ClientKeyData)
+	if err != nil {
+		return nil, nil, errors.NewInvalid(fmt.Sprintf("Could not decode client-key-data: %s", err.Error()))
+	}
+
+	if _, err := tls.X509KeyPair(certData, keyData); err != nil {
+		return nil, nil, errors.NewInvalid(fmt.Sprintf("Invalid client certificate: %s", err.Error()))
+	}
+
+	return certData, keyData, nil
+}
+
+// Returns names of user info fields that were provided but can not be used by dashboard to log in user, i.e.
+// exec plugins or references to local files.
+func (self *kubeConfigAuthenticator) getUnsupportedFields(info userInfo) []string {
+	result := make([]string, 0)
+	if info.Exec != nil {
+		result = append(result, fmt.Sprintf("exec (command: %s)", info.Exec.Command))
+	}
+
+	if len(info.AuthProvider.Name) > 0 {
+		result = append(result, fmt.Sprintf("auth-provider (name: %s) without access-token", info.AuthProvider.Name))
+	}
+
+	if len(info.ClientCertificate) > 0 {
+		result = append(result, "client-certificate")
+	}
+
+	if len(info.ClientKey) > 0 {
+		result = append(result, "client-key")
+	}
+
+	if len(info.TokenFile) > 0 {
+		result = append(result, "tokenFile")
+	}
+
+	return result
+}
+
 // NewBasicAuthenticator returns Authenticator based on LoginSpec.
 func NewKubeConfigAuthenticator(spec *authApi.LoginSpec, authModes authApi.AuthenticationModes) authApi.Authenticator {
 	return &kubeConfigAuthenticator{
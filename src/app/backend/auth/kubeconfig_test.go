@@ -16,9 +16,18 @@ package auth
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
 	"reflect"
 	"testing"
 	"text/template"
+	"time"
 
 	"k8s.io/client-go/tools/clientcmd/api"
 
@@ -52,13 +61,57 @@ users:
 {{if .token}}
     token: {{.token}}
 {{end}}
-{{if .accessToken}}
+{{if .clientCertificateData}}
+    client-certificate-data: {{.clientCertificateData}}
+{{end}}
+{{if .clientKeyData}}
+    client-key-data: {{.clientKeyData}}
+{{end}}
+{{if .exec}}
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: {{.exec}}
+{{end}}
+{{if or .accessToken .authProvider}}
     auth-provider:
+{{if .authProvider}}
+      name: {{.authProvider}}
+{{end}}
       config:
+{{if .accessToken}}
         access-token: {{.accessToken}}
 {{end}}
+{{end}}
 `
 
+func generateClientCertificate(t *testing.T) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "foo"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
 func TestKubeConfigAuthenticator(t *testing.T) {
 	authModeBasic := map[authApi.AuthenticationMode]bool{
 		authApi.Basic: true,
@@ -70,6 +123,9 @@ func TestKubeConfigAuthenticator(t *testing.T) {
 	authModeToken := map[authApi.AuthenticationMode]bool{
 		authApi.Token: true,
 	}
+	cert, key := generateClientCertificate(t)
+	encodedCert := base64.StdEncoding.EncodeToString(cert)
+	encodedKey := base64.StdEncoding.EncodeToString(key)
 
 	cases := []struct {
 		info        string
@@ -106,6 +162,45 @@ func TestKubeConfigAuthenticator(t *testing.T) {
 			api.AuthInfo{},
 			errors.NewInvalid("Not enough data to create auth info structure."),
 		},
+		{
+			`If "client-certificate-data" and "client-key-data" are provided, they are decoded and picked up.`,
+			authModeToken,
+			map[string]string{"clientCertificateData": encodedCert, "clientKeyData": encodedKey},
+			api.AuthInfo{ClientCertificateData: cert, ClientKeyData: key},
+			nil,
+		},
+		{
+			`If client certificate is provided along with token, both are picked up.`,
+			authModeToken,
+			map[string]string{"clientCertificateData": encodedCert, "clientKeyData": encodedKey, "token": "bar"},
+			api.AuthInfo{Token: "bar", ClientCertificateData: cert, ClientKeyData: key},
+			nil,
+		},
+		{
+			`If "client-key-data" is missing, an error is returned.`,
+			authModeToken,
+			map[string]string{"clientCertificateData": encodedCert},
+			api.AuthInfo{},
+			errors.NewInvalid("Both client-certificate-data and client-key-data have to be provided."),
+		},
+		{
+			`If "exec" is the only auth option provided, an error listing it is returned.`,
+			authModeBoth,
+			map[string]string{"exec": "aws-iam-authenticator"},
+			api.AuthInfo{},
+			errors.NewInvalid("Unsupported authentication options found in kubeconfig file: exec (command: " +
+				"aws-iam-authenticator). Only token, username/password and embedded client-certificate-data/" +
+				"client-key-data can be used."),
+		},
+		{
+			`If "auth-provider" does not contain an access token, an error listing it is returned.`,
+			authModeBoth,
+			map[string]string{"authProvider": "oidc"},
+			api.AuthInfo{},
+			errors.NewInvalid("Unsupported authentication options found in kubeconfig file: auth-provider " +
+				"(name: oidc) without access-token. Only token, username/password and embedded " +
+				"client-certificate-data/client-key-data can be used."),
+		},
 	}
 	for _, c := range cases {
 		kubeconfig := template.Must(template.New("kubeconfig").Parse(kubeconfigTemplate))
@@ -0,0 +1,154 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// loginAttempts holds failed login statistics of a single source IP or username.
+type loginAttempts struct {
+	// Number of failed attempts since last lockout or successful login.
+	failures int
+	// Number of consecutive lockouts. Used to exponentially increase lockout duration.
+	lockouts int
+	// Time of the last failed attempt.
+	lastFailure time.Time
+	// Time until which login attempts are rejected.
+	lockedUntil time.Time
+}
+
+// loginLimiter tracks failed login attempts per key (source IP or username) and locks out keys that have reached
+// the maximum number of failed attempts. Lockout duration is doubled for every consecutive lockout up to the
+// configured maximum. Limiter with maxFailures set to 0 allows all attempts.
+type loginLimiter struct {
+	mux         sync.Mutex
+	maxFailures int
+	lockout     time.Duration
+	maxLockout  time.Duration
+	attempts    map[string]*loginAttempts
+	now         func() time.Time
+}
+
+// Allow returns zero if login attempt for all given keys is allowed, otherwise the time after which next attempt
+// can be made is returned.
+func (self *loginLimiter) Allow(keys ...string) time.Duration {
+	if !self.enabled() {
+		return 0
+	}
+
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	now := self.now()
+	self.cleanup(now)
+
+	var retryAfter time.Duration
+	for _, key := range keys {
+		if attempts, ok := self.attempts[key]; ok && attempts.lockedUntil.After(now) {
+			if wait := attempts.lockedUntil.Sub(now); wait > retryAfter {
+				retryAfter = wait
+			}
+		}
+	}
+
+	return retryAfter
+}
+
+// Failure records failed login attempt for all given keys and locks out keys that have reached the maximum number
+// of failed attempts.
+func (self *loginLimiter) Failure(keys ...string) {
+	if !self.enabled() {
+		return
+	}
+
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	now := self.now()
+	for _, key := range keys {
+		attempts, ok := self.attempts[key]
+		if !ok {
+			attempts = &loginAttempts{}
+			self.attempts[key] = attempts
+		}
+
+		attempts.failures++
+		attempts.lastFailure = now
+		if attempts.failures >= self.maxFailures {
+			attempts.lockedUntil = now.Add(self.lockoutDuration(attempts.lockouts))
+			attempts.lockouts++
+			attempts.failures = 0
+		}
+	}
+}
+
+// Success resets failed login statistics of all given keys.
+func (self *loginLimiter) Success(keys ...string) {
+	if !self.enabled() {
+		return
+	}
+
+	self.mux.Lock()
+	defer self.mux.Unlock()
+
+	for _, key := range keys {
+		delete(self.attempts, key)
+	}
+}
+
+func (self *loginLimiter) enabled() bool {
+	return self != nil && self.maxFailures > 0
+}
+
+// Returns lockout duration for given number of previous consecutive lockouts.
+func (self *loginLimiter) lockoutDuration(lockouts int) time.Duration {
+	duration := self.lockout
+	for i := 0; i < lockouts && duration < self.maxLockout; i++ {
+		duration *= 2
+	}
+
+	if duration > self.maxLockout {
+		return self.maxLockout
+	}
+
+	return duration
+}
+
+// Removes entries that are not locked and had no failed attempts for longer than maximum lockout duration. This
+// keeps memory usage bounded and makes lockout duration decay back to the initial value.
+func (self *loginLimiter) cleanup(now time.Time) {
+	for key, attempts := range self.attempts {
+		if !attempts.lockedUntil.After(now) && now.Sub(attempts.lastFailure) > self.maxLockout {
+			delete(self.attempts, key)
+		}
+	}
+}
+
+// newLoginLimiter creates login limiter. Durations are provided in seconds.
+func newLoginLimiter(maxFailures, lockout, maxLockout int) *loginLimiter {
+	if maxLockout < lockout {
+		maxLockout = lockout
+	}
+
+	return &loginLimiter{
+		maxFailures: maxFailures,
+		lockout:     time.Duration(lockout) * time.Second,
+		maxLockout:  time.Duration(maxLockout) * time.Second,
+		attempts:    make(map[string]*loginAttempts),
+		now:         time.Now,
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoginLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	limiter := newLoginLimiter(2, 10, 30)
+	limiter.now = func() time.Time { return now }
+
+	cases := []struct {
+		info     string
+		advance  time.Duration
+		failures int
+		expected time.Duration
+	}{
+		{"Single failure should not lock out", 0, 1, 0},
+		{"Reaching max failures should lock out for initial duration", 0, 1, 10 * time.Second},
+		{"Lockout should expire", 10 * time.Second, 0, 0},
+		{"Consecutive lockout should double duration", 0, 2, 20 * time.Second},
+		{"Lockout duration should be capped", 20 * time.Second, 2, 30 * time.Second},
+		{"Single failure after expired lockout should not lock out", time.Minute, 1, 0},
+	}
+
+	for _, c := range cases {
+		now = now.Add(c.advance)
+		for i := 0; i < c.failures; i++ {
+			limiter.Failure("ip/127.0.0.1")
+		}
+
+		if got := limiter.Allow("ip/127.0.0.1", "user/foo"); got != c.expected {
+			t.Errorf("Test Case: %s. Expected retry after to be: %v, but got %v.", c.info, c.expected, got)
+		}
+	}
+}
+
+func TestLoginLimiter_Success(t *testing.T) {
+	limiter := newLoginLimiter(1, 10, 10)
+	limiter.Failure("user/foo")
+	limiter.Success("user/foo")
+
+	if got := limiter.Allow("user/foo"); got != 0 {
+		t.Errorf("Expected successful login to reset lockout, but got retry after %v.", got)
+	}
+}
+
+func TestLoginLimiter_Disabled(t *testing.T) {
+	limiter := newLoginLimiter(0, 10, 10)
+	for i := 0; i < 10; i++ {
+		limiter.Failure("user/foo")
+	}
+
+	if got := limiter.Allow("user/foo"); got != 0 {
+		t.Errorf("Expected disabled limiter to allow all attempts, but got retry after %v.", got)
+	}
+}
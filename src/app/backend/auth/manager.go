@@ -15,8 +15,11 @@
 package auth
 
 import (
+	"math"
+
 	"k8s.io/client-go/tools/clientcmd/api"
 
+	"github.com/kubernetes/dashboard/src/app/backend/args"
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
@@ -28,31 +31,59 @@ type authManager struct {
 	clientManager           clientapi.ClientManager
 	authenticationModes     authApi.AuthenticationModes
 	authenticationSkippable bool
+	limiter                 *loginLimiter
 }
 
 // Login implements auth manager. See AuthManager interface for more information.
 func (self authManager) Login(spec *authApi.LoginSpec) (*authApi.AuthResponse, error) {
+	keys := self.getLimiterKeys(spec)
+	if retryAfter := self.limiter.Allow(keys...); retryAfter > 0 {
+		monitorLoginFailure(self.getLoginMode(spec, nil), loginFailureThrottled)
+		return nil, errors.NewTooManyRequests("Too many failed login attempts. Try again later.",
+			int(math.Ceil(retryAfter.Seconds())))
+	}
+
 	authenticator, err := self.getAuthenticator(spec)
 	if err != nil {
+		monitorLoginFailure(loginModeUnknown, loginFailureInvalid)
 		return nil, err
 	}
 
+	mode := self.getLoginMode(spec, authenticator)
 	authInfo, err := authenticator.GetAuthInfo()
 	if err != nil {
+		monitorLoginFailure(mode, loginFailureInvalid)
 		return nil, err
 	}
 
 	err = self.healthCheck(authInfo)
 	nonCriticalErrors, criticalError := errors.HandleError(err)
 	if criticalError != nil || len(nonCriticalErrors) > 0 {
+		if criticalError != nil {
+			monitorLoginFailure(mode, loginFailureError)
+		} else {
+			// Only rejected credentials count as failed attempts. Apiserver being unavailable should not lock
+			// users out.
+			self.limiter.Failure(keys...)
+			monitorLoginFailure(mode, loginFailureUnauthorized)
+		}
+
 		return &authApi.AuthResponse{Errors: nonCriticalErrors}, criticalError
 	}
 
 	token, err := self.tokenManager.Generate(authInfo)
 	if err != nil {
+		monitorLoginFailure(mode, loginFailureError)
 		return nil, err
 	}
 
+	// Source IP is not reset on success. Otherwise single valid credential could be used to reset the counter
+	// between attempts to guess other ones.
+	if len(spec.Username) > 0 {
+		self.limiter.Success(usernameLimiterKey(spec.Username))
+	}
+
+	monitorLoginSuccess(mode)
 	return &authApi.AuthResponse{JWEToken: token, Errors: nonCriticalErrors}, nil
 }
 
@@ -87,6 +118,47 @@ func (self authManager) getAuthenticator(spec *authApi.LoginSpec) (authApi.Authe
 	return nil, errors.NewInvalid("Not enough data to create authenticator.")
 }
 
+// Returns login mode used as a metric label based on authenticator created for given LoginSpec.
+func (self authManager) getLoginMode(spec *authApi.LoginSpec, authenticator authApi.Authenticator) string {
+	switch authenticator.(type) {
+	case *tokenAuthenticator:
+		return loginModeToken
+	case *basicAuthenticator:
+		return loginModeBasic
+	case *kubeConfigAuthenticator:
+		return loginModeKubeConfig
+	}
+
+	switch {
+	case len(spec.Token) > 0:
+		return loginModeToken
+	case len(spec.Username) > 0:
+		return loginModeBasic
+	case len(spec.KubeConfig) > 0:
+		return loginModeKubeConfig
+	}
+
+	return loginModeUnknown
+}
+
+// Returns keys used to throttle failed login attempts for given LoginSpec.
+func (self authManager) getLimiterKeys(spec *authApi.LoginSpec) []string {
+	keys := make([]string, 0)
+	if len(spec.SourceIP) > 0 {
+		keys = append(keys, "ip/"+spec.SourceIP)
+	}
+
+	if len(spec.Username) > 0 {
+		keys = append(keys, usernameLimiterKey(spec.Username))
+	}
+
+	return keys
+}
+
+func usernameLimiterKey(username string) string {
+	return "user/" + username
+}
+
 // Checks if user data extracted from provided AuthInfo structure is valid and user is correctly authenticated
 // by K8S apiserver.
 func (self authManager) healthCheck(authInfo api.AuthInfo) error {
@@ -101,5 +173,7 @@ func NewAuthManager(clientManager clientapi.ClientManager, tokenManager authApi.
 		clientManager:           clientManager,
 		authenticationModes:     authenticationModes,
 		authenticationSkippable: authenticationSkippable,
+		limiter: newLoginLimiter(args.Holder.GetLoginMaxFailedAttempts(), args.Holder.GetLoginLockoutDuration(),
+			args.Holder.GetLoginMaxLockoutDuration()),
 	}
 }
@@ -87,7 +87,7 @@ func (self *fakeClientManager) HasAccess(authInfo api.AuthInfo) error {
 }
 
 func (self *fakeClientManager) VerberClient(req *restful.Request, config *rest.Config) (clientapi.ResourceVerber, error) {
-	return client.NewResourceVerber(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), nil
+	return client.NewResourceVerber(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil), nil
 }
 
 func (self *fakeClientManager) CanI(req *restful.Request, ssar *v1.SelfSubjectAccessReview) bool {
@@ -171,6 +171,41 @@ func TestAuthManager_Login(t *testing.T) {
 	}
 }
 
+func TestAuthManager_LoginThrottling(t *testing.T) {
+	unauthorizedErr := errors.NewUnauthorized("Unauthorized")
+	cManager := &fakeClientManager{HasAccessError: unauthorizedErr}
+	tManager := &fakeTokenManager{GeneratedToken: "generated-token"}
+	authManager := &authManager{
+		tokenManager:        tManager,
+		clientManager:       cManager,
+		authenticationModes: authApi.AuthenticationModes{authApi.Basic: true},
+		limiter:             newLoginLimiter(2, 10, 10),
+	}
+	spec := &authApi.LoginSpec{Username: "foo", Password: "bar", SourceIP: "127.0.0.1"}
+
+	for i := 0; i < 2; i++ {
+		if _, err := authManager.Login(spec); err != nil {
+			t.Fatalf("Expected failed attempt %d to not return critical error, but got %v.", i, err)
+		}
+	}
+
+	cManager.HasAccessError = nil
+	_, err := authManager.Login(spec)
+	if !errors.IsTooManyRequests(err) {
+		t.Errorf("Expected login to be throttled after too many failed attempts, but got %v.", err)
+	}
+
+	_, err = authManager.Login(&authApi.LoginSpec{Username: "foo", Password: "bar", SourceIP: "127.0.0.2"})
+	if !errors.IsTooManyRequests(err) {
+		t.Errorf("Expected login for the same username from other IP to be throttled, but got %v.", err)
+	}
+
+	response, err := authManager.Login(&authApi.LoginSpec{Username: "baz", Password: "bar", SourceIP: "127.0.0.2"})
+	if err != nil || response.JWEToken != "generated-token" {
+		t.Errorf("Expected login for other username from other IP to succeed, but got %v, %v.", response, err)
+	}
+}
+
 func TestAuthManager_AuthenticationModes(t *testing.T) {
 	cManager := &fakeClientManager{}
 	tManager := &fakeTokenManager{}
@@ -0,0 +1,68 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Login modes used as metric labels. Kubeconfig is not an authentication mode on its own, but is tracked separately.
+const (
+	loginModeToken      = "token"
+	loginModeBasic      = "basic"
+	loginModeKubeConfig = "kubeconfig"
+	loginModeUnknown    = "unknown"
+)
+
+// Reasons of failed login used as metric labels.
+const (
+	loginFailureInvalid      = "invalid"
+	loginFailureUnauthorized = "unauthorized"
+	loginFailureThrottled    = "throttled"
+	loginFailureError        = "error"
+)
+
+var (
+	loginSuccessCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dashboard_login_success_count",
+			Help: "Counter of successful logins broken out for each login mode.",
+		},
+		[]string{"mode"},
+	)
+	loginFailureCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dashboard_login_failure_count",
+			Help: "Counter of failed logins broken out for each login mode and failure reason.",
+		},
+		[]string{"mode", "reason"},
+	)
+)
+
+// Initialize all metrics in prometheus
+func init() {
+	prometheus.MustRegister(loginSuccessCounter)
+	prometheus.MustRegister(loginFailureCounter)
+}
+
+// Track successful login in prometheus
+func monitorLoginSuccess(mode string) {
+	loginSuccessCounter.WithLabelValues(mode).Inc()
+}
+
+// Track failed login in prometheus
+func monitorLoginFailure(mode, reason string) {
+	loginFailureCounter.WithLabelValues(mode, reason).Inc()
+}
@@ -20,7 +20,9 @@ import (
 	pluginclientset "github.com/kubernetes/dashboard/src/app/backend/plugin/client/clientset/versioned"
 	v1 "k8s.io/api/authorization/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -57,8 +59,10 @@ type ClientManager interface {
 type ResourceVerber interface {
 	Put(kind string, namespaceSet bool, namespace string, name string,
 		object *runtime.Unknown) error
+	Patch(kind string, namespaceSet bool, namespace string, name string, patchType types.PatchType, data []byte,
+		patchOptions *metaV1.PatchOptions) (runtime.Object, error)
 	Get(kind string, namespaceSet bool, namespace string, name string) (runtime.Object, error)
-	Delete(kind string, namespaceSet bool, namespace string, name string) error
+	Delete(kind string, namespaceSet bool, namespace string, name string, deleteOptions *metaV1.DeleteOptions) error
 }
 
 // CanIResponse is used to as response to check whether or not user is allowed to access given endpoint.
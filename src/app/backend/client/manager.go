@@ -66,6 +66,13 @@ type clientManager struct {
 	kubeConfigPath string
 	// Address of apiserver host in format 'protocol://address:port'
 	apiserverHost string
+	// Client config used instead of kubeConfigPath and apiserverHost if set. Used by clusters
+	// registered in cluster registry.
+	clientConfig clientcmd.ClientConfig
+	// True if the cluster of clientConfig shares the identity provider with the default cluster,
+	// so that auth info from the request can be forwarded to it. Otherwise credentials from
+	// clientConfig are used for logged in users.
+	sharedIdentity bool
 	// Initialized on clientManager creation and used if kubeconfigPath and apiserverHost are
 	// empty
 	inClusterConfig *rest.Config
@@ -204,6 +211,12 @@ func (self *clientManager) ClientCmdConfig(req *restful.Request) (clientcmd.Clie
 		return nil, err
 	}
 
+	// Tokens issued by the identity provider of the default cluster are not forwarded to clusters
+	// with their own identity provider.
+	if self.clientConfig != nil && !self.sharedIdentity {
+		return self.clientConfig, nil
+	}
+
 	cfg, err := self.buildConfigFromFlags(self.apiserverHost, self.kubeConfigPath)
 	if err != nil {
 		return nil, err
@@ -260,7 +273,8 @@ func (self *clientManager) VerberClient(req *restful.Request, config *rest.Confi
 	return NewResourceVerber(k8sClient.CoreV1().RESTClient(),
 		k8sClient.ExtensionsV1beta1().RESTClient(), k8sClient.AppsV1().RESTClient(),
 		k8sClient.BatchV1().RESTClient(), k8sClient.BatchV1beta1().RESTClient(), k8sClient.AutoscalingV1().RESTClient(),
-		k8sClient.StorageV1().RESTClient(), k8sClient.RbacV1().RESTClient(),
+		k8sClient.StorageV1().RESTClient(), k8sClient.RbacV1().RESTClient(), k8sClient.NetworkingV1().RESTClient(),
+		k8sClient.PolicyV1beta1().RESTClient(), k8sClient.SchedulingV1().RESTClient(),
 		apiextensionsclient.ApiextensionsV1().RESTClient(),
 		pluginsclient.DashboardV1alpha1().RESTClient(),
 		config), nil
@@ -283,6 +297,10 @@ func (self *clientManager) initConfig(cfg *rest.Config) {
 // empty then in-cluster config will be used and if it is nil the error is returned.
 func (self *clientManager) buildConfigFromFlags(apiserverHost, kubeConfigPath string) (
 	*rest.Config, error) {
+	if self.clientConfig != nil {
+		return self.clientConfig.ClientConfig()
+	}
+
 	if len(kubeConfigPath) > 0 || len(apiserverHost) > 0 {
 		return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
 			&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeConfigPath},
@@ -457,7 +475,7 @@ func (self *clientManager) init() {
 
 // Initializes in-cluster config if apiserverHost and kubeConfigPath were not provided.
 func (self *clientManager) initInClusterConfig() {
-	if len(self.apiserverHost) > 0 || len(self.kubeConfigPath) > 0 {
+	if len(self.apiserverHost) > 0 || len(self.kubeConfigPath) > 0 || self.clientConfig != nil {
 		log.Print("Skipping in-cluster config")
 		return
 	}
@@ -536,3 +554,17 @@ func NewClientManager(kubeConfigPath, apiserverHost string) clientapi.ClientMana
 	result.init()
 	return result
 }
+
+// NewClientManagerForConfig creates client manager based on provided client config. It is used
+// to create clients for additional clusters registered in cluster registry. Auth info of logged in
+// users is forwarded to the cluster only if it shares the identity provider with the default
+// cluster, otherwise credentials from the client config are used.
+func NewClientManagerForConfig(clientConfig clientcmd.ClientConfig, sharedIdentity bool) clientapi.ClientManager {
+	result := &clientManager{
+		clientConfig:   clientConfig,
+		sharedIdentity: sharedIdentity,
+	}
+
+	result.init()
+	return result
+}
@@ -23,6 +23,8 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/args"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
 func TestNewClientManager(t *testing.T) {
@@ -236,6 +238,50 @@ func TestConfig(t *testing.T) {
 	}
 }
 
+func TestConfigForRegisteredCluster(t *testing.T) {
+	args.GetHolderBuilder().SetEnableSkipLogin(false)
+	cmdConfig := clientcmdapi.NewConfig()
+	cmdConfig.Clusters["foo"] = &clientcmdapi.Cluster{Server: "https://foo.example.com"}
+	cmdConfig.AuthInfos["admin"] = &clientcmdapi.AuthInfo{Token: "cluster-token"}
+	cmdConfig.Contexts["foo"] = &clientcmdapi.Context{Cluster: "foo", AuthInfo: "admin"}
+	cmdConfig.CurrentContext = "foo"
+	clientConfig := clientcmd.NewDefaultClientConfig(*cmdConfig, &clientcmd.ConfigOverrides{})
+
+	cases := []struct {
+		sharedIdentity bool
+		expected       string
+	}{
+		{true, "test-token"},
+		{false, "cluster-token"},
+	}
+
+	for _, c := range cases {
+		request := &restful.Request{
+			Request: &http.Request{
+				Header: http.Header(map[string][]string{"Authorization": {"Bearer test-token"}}),
+				TLS:    &tls.ConnectionState{},
+			},
+		}
+
+		manager := NewClientManagerForConfig(clientConfig, c.sharedIdentity)
+		cfg, err := manager.Config(request)
+		if err != nil {
+			t.Fatalf("Config() with shared identity %t: unexpected error: %s", c.sharedIdentity, err.Error())
+		}
+
+		if cfg.Host != "https://foo.example.com" || cfg.BearerToken != c.expected {
+			t.Errorf("Config() with shared identity %t: expected token %s for https://foo.example.com, but got %s "+
+				"for %s", c.sharedIdentity, c.expected, cfg.BearerToken, cfg.Host)
+		}
+
+		request.Request.Header.Del("Authorization")
+		if _, err := manager.Config(request); err == nil {
+			t.Errorf("Config() with shared identity %t: expected error for request without token",
+				c.sharedIdentity)
+		}
+	}
+}
+
 func TestClientCmdConfig(t *testing.T) {
 	args.GetHolderBuilder().SetEnableSkipLogin(true)
 	cases := []struct {
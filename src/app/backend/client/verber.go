@@ -24,6 +24,7 @@ import (
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	restclient "k8s.io/client-go/rest"
 )
 
@@ -38,6 +39,9 @@ type resourceVerber struct {
 	autoscalingClient   RESTClient
 	storageClient       RESTClient
 	rbacClient          RESTClient
+	networkingClient    RESTClient
+	policyClient        RESTClient
+	schedulingClient    RESTClient
 	apiExtensionsClient RESTClient
 	pluginsClient       RESTClient
 	config              *restclient.Config
@@ -59,6 +63,12 @@ func (verber *resourceVerber) getRESTClientByType(clientType api.ClientType) RES
 		return verber.storageClient
 	case api.ClientTypeRbacClient:
 		return verber.rbacClient
+	case api.ClientTypeNetworkingClient:
+		return verber.networkingClient
+	case api.ClientTypePolicyClient:
+		return verber.policyClient
+	case api.ClientTypeSchedulingClient:
+		return verber.schedulingClient
 	case api.ClientTypeAPIExtensionsClient:
 		return verber.apiExtensionsClient
 	case api.ClientTypePluginsClient:
@@ -111,29 +121,35 @@ func (verber *resourceVerber) getResourceSpecFromKind(kind string, namespaceSet
 type RESTClient interface {
 	Delete() *restclient.Request
 	Put() *restclient.Request
+	Patch(pt types.PatchType) *restclient.Request
 	Get() *restclient.Request
 }
 
 // NewResourceVerber creates a new resource verber that uses the given client for performing operations.
-func NewResourceVerber(client, extensionsClient, appsClient, batchClient, betaBatchClient, autoscalingClient, storageClient, rbacClient, apiExtensionsClient, pluginsClient RESTClient, config *restclient.Config) clientapi.ResourceVerber {
+func NewResourceVerber(client, extensionsClient, appsClient, batchClient, betaBatchClient, autoscalingClient, storageClient, rbacClient, networkingClient, policyClient, schedulingClient, apiExtensionsClient, pluginsClient RESTClient, config *restclient.Config) clientapi.ResourceVerber {
 	return &resourceVerber{client, extensionsClient, appsClient,
-		batchClient, betaBatchClient, autoscalingClient, storageClient, rbacClient, apiExtensionsClient, pluginsClient, config}
+		batchClient, betaBatchClient, autoscalingClient, storageClient, rbacClient, networkingClient, policyClient,
+		schedulingClient, apiExtensionsClient, pluginsClient, config}
 }
 
-// Delete deletes the resource of the given kind in the given namespace with the given name.
-func (verber *resourceVerber) Delete(kind string, namespaceSet bool, namespace string, name string) error {
+// Delete deletes the resource of the given kind in the given namespace with the given name. If delete options are
+// nil, the resource is deleted with foreground propagation policy.
+func (verber *resourceVerber) Delete(kind string, namespaceSet bool, namespace string, name string,
+	deleteOptions *v1.DeleteOptions) error {
 	client, resourceSpec, err := verber.getResourceSpecFromKind(kind, namespaceSet)
 	if err != nil {
 		return err
 	}
 
-	// Do cascade delete by default, as this is what users typically expect.
-	defaultPropagationPolicy := v1.DeletePropagationForeground
-	defaultDeleteOptions := &v1.DeleteOptions{
-		PropagationPolicy: &defaultPropagationPolicy,
+	if deleteOptions == nil {
+		// Do cascade delete by default, as this is what users typically expect.
+		defaultPropagationPolicy := v1.DeletePropagationForeground
+		deleteOptions = &v1.DeleteOptions{
+			PropagationPolicy: &defaultPropagationPolicy,
+		}
 	}
 
-	req := client.Delete().Resource(resourceSpec.Resource).Name(name).Body(defaultDeleteOptions)
+	req := client.Delete().Resource(resourceSpec.Resource).Name(name).Body(deleteOptions)
 
 	if resourceSpec.Namespaced {
 		req.Namespace(namespace)
@@ -164,6 +180,35 @@ func (verber *resourceVerber) Put(kind string, namespaceSet bool, namespace stri
 	return req.Do().Error()
 }
 
+// Patch applies the patch of the given type to the resource of the given kind in the given namespace with the given
+// name. The patched resource is returned. If patch options request dry run, patched resource is not persisted.
+func (verber *resourceVerber) Patch(kind string, namespaceSet bool, namespace string, name string,
+	patchType types.PatchType, data []byte, patchOptions *v1.PatchOptions) (runtime.Object, error) {
+	client, resourceSpec, err := verber.getResourceSpecFromKind(kind, namespaceSet)
+	if err != nil {
+		return nil, err
+	}
+
+	if patchOptions == nil {
+		patchOptions = &v1.PatchOptions{}
+	}
+
+	result := &runtime.Unknown{}
+	req := client.Patch(patchType).
+		Resource(resourceSpec.Resource).
+		Name(name).
+		SpecificallyVersionedParams(patchOptions, v1.ParameterCodec, v1.SchemeGroupVersion).
+		SetHeader("Accept", "application/json").
+		Body(data)
+
+	if resourceSpec.Namespaced {
+		req.Namespace(namespace)
+	}
+
+	err = req.Do().Into(result)
+	return result, err
+}
+
 // Get gets the resource of the given kind in the given namespace with the given name.
 func (verber *resourceVerber) Get(kind string, namespaceSet bool, namespace string, name string) (runtime.Object, error) {
 	client, resourceSpec, err := verber.getResourceSpecFromKind(kind, namespaceSet)
@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
 	restclient "k8s.io/client-go/rest"
 
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
@@ -38,6 +39,7 @@ func (f clientFunc) Do(req *http.Request) (*http.Response, error) {
 type FakeRESTClient struct {
 	response *http.Response
 	err      error
+	request  *http.Request
 }
 
 func (c *FakeRESTClient) Delete() *restclient.Request {
@@ -62,6 +64,14 @@ func (c *FakeRESTClient) Put() *restclient.Request {
 	}), "PUT", nil, "/api/v1", restclient.ContentConfig{}, restclient.Serializers{}, nil, nil, 0)
 }
 
+func (c *FakeRESTClient) Patch(pt types.PatchType) *restclient.Request {
+	return restclient.NewRequest(clientFunc(func(req *http.Request) (*http.Response, error) {
+		c.request = req
+		return c.response, c.err
+	}), "PATCH", nil, "/api/v1", restclient.ContentConfig{}, restclient.Serializers{}, nil, nil, 0).
+		SetHeader("Content-Type", string(pt))
+}
+
 func (c *FakeRESTClient) Get() *restclient.Request {
 	return restclient.NewRequest(clientFunc(func(req *http.Request) (*http.Response, error) {
 		return c.response, c.err
@@ -75,19 +85,19 @@ func TestDeleteShouldPropagateErrorsAndChoseClient(t *testing.T) {
 		appsClient:       &FakeRESTClient{err: errors.NewInvalid("err from apps")},
 	}
 
-	err := verber.Delete("replicaset", true, "bar", "baz")
+	err := verber.Delete("replicaset", true, "bar", "baz", nil)
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("err from apps")) {
 		t.Fatalf("Expected error on verber delete but got %#v", err.Error())
 	}
 
-	err = verber.Delete("service", true, "bar", "baz")
+	err = verber.Delete("service", true, "bar", "baz", nil)
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("err")) {
 		t.Fatalf("Expected error on verber delete but got %#v", err)
 	}
 
-	err = verber.Delete("statefulset", true, "bar", "baz")
+	err = verber.Delete("statefulset", true, "bar", "baz", nil)
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("err from apps")) {
 		t.Fatalf("Expected error on verber delete but got %#v", err)
@@ -99,6 +109,7 @@ func TestGetShouldPropagateErrorsAndChoseClient(t *testing.T) {
 		client:           &FakeRESTClient{err: errors.NewInvalid("err")},
 		extensionsClient: &FakeRESTClient{err: errors.NewInvalid("err from extensions")},
 		appsClient:       &FakeRESTClient{err: errors.NewInvalid("err from apps")},
+		networkingClient: &FakeRESTClient{err: errors.NewInvalid("err from networking")},
 	}
 
 	_, err := verber.Get("replicaset", true, "bar", "baz")
@@ -118,6 +129,12 @@ func TestGetShouldPropagateErrorsAndChoseClient(t *testing.T) {
 	if !reflect.DeepEqual(err, errors.NewInvalid("err from apps")) {
 		t.Fatalf("Expected error on verber delete but got %#v", err)
 	}
+
+	_, err = verber.Get("networkpolicy", true, "bar", "baz")
+
+	if !reflect.DeepEqual(err, errors.NewInvalid("err from networking")) {
+		t.Fatalf("Expected error on verber delete but got %#v", err)
+	}
 }
 
 func TestDeleteShouldThrowErrorOnUnknownResourceKind(t *testing.T) {
@@ -126,7 +143,7 @@ func TestDeleteShouldThrowErrorOnUnknownResourceKind(t *testing.T) {
 		apiExtensionsClient: &FakeRESTClient{err: errors.NewNotFound("err")},
 	}
 
-	err := verber.Delete("foo", true, "bar", "baz")
+	err := verber.Delete("foo", true, "bar", "baz", nil)
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("Unknown resource kind: foo")) {
 		t.Fatalf("Expected error on verber delete but got %#v", err)
@@ -182,7 +199,7 @@ func TestPutShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 func TestDeleteShouldRespectNamespacednessOfResourceKind(t *testing.T) {
 	verber := resourceVerber{client: &FakeRESTClient{}}
 
-	err := verber.Delete("service", false, "", "baz")
+	err := verber.Delete("service", false, "", "baz", nil)
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("Set no namespace for namespaced resource kind: service")) {
 		t.Fatalf("Expected error on verber delete but got %#v", err)
@@ -212,9 +229,45 @@ func TestPutShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
 func TestDeleteShouldRespectNotNamespacednessOfResourceKind(t *testing.T) {
 	verber := resourceVerber{client: &FakeRESTClient{}}
 
-	err := verber.Delete("namespace", true, "bar", "baz")
+	err := verber.Delete("namespace", true, "bar", "baz", nil)
 
 	if !reflect.DeepEqual(err, errors.NewInvalid("Set namespace for not-namespaced resource kind: namespace")) {
 		t.Fatalf("Expected error on verber delete but got %#v", err)
 	}
 }
+
+func TestPatchShouldSendPatchWithOptions(t *testing.T) {
+	client := &FakeRESTClient{err: errors.NewInvalid("err")}
+	verber := resourceVerber{client: client}
+
+	force := true
+	_, err := verber.Patch("pod", true, "bar", "baz", types.ApplyPatchType, []byte("{}"),
+		&metaV1.PatchOptions{DryRun: []string{metaV1.DryRunAll}, FieldManager: "dashboard", Force: &force})
+
+	if !reflect.DeepEqual(err, errors.NewInvalid("err")) {
+		t.Fatalf("Expected error on verber patch but got %#v", err)
+	}
+
+	if client.request.Method != "PATCH" || client.request.URL.Path != "/api/v1/namespaces/bar/pods/baz" {
+		t.Errorf("Expected patch of pod bar/baz but got %s %s", client.request.Method, client.request.URL.Path)
+	}
+
+	if contentType := client.request.Header.Get("Content-Type"); contentType != string(types.ApplyPatchType) {
+		t.Errorf("Expected content type %s but got %s", types.ApplyPatchType, contentType)
+	}
+
+	expected := "dryRun=All&fieldManager=dashboard&force=true"
+	if query := client.request.URL.RawQuery; query != expected {
+		t.Errorf("Expected query %s but got %s", expected, query)
+	}
+}
+
+func TestPatchShouldRespectNamespacednessOfResourceKind(t *testing.T) {
+	verber := resourceVerber{client: &FakeRESTClient{}}
+
+	_, err := verber.Patch("service", false, "", "baz", types.MergePatchType, []byte("{}"), nil)
+
+	if !reflect.DeepEqual(err, errors.NewInvalid("Set no namespace for namespaced resource kind: service")) {
+		t.Fatalf("Expected error on verber patch but got %#v", err)
+	}
+}
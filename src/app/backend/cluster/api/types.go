@@ -0,0 +1,74 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration"
+)
+
+// ClusterPathPrefix is a prefix of API paths that are served for a cluster selected from the registry, i.e.
+// /api/v1/cluster/{cluster}/pod.
+const ClusterPathPrefix = "/api/v1/cluster/"
+
+// DefaultClusterName is the name of the cluster Dashboard runs in or is configured with, which is served without the
+// cluster path prefix.
+const DefaultClusterName = "default"
+
+// Cluster represents a single cluster registered in dashboard. Every cluster has its own clients and
+// integrations, i.e. metric clients.
+type Cluster struct {
+	// Name is a unique name of the cluster used in API paths.
+	Name string
+	// ClientManager is used to create clients for the cluster.
+	ClientManager clientapi.ClientManager
+	// IntegrationManager manages integrations, i.e. metric clients, of the cluster.
+	IntegrationManager integration.IntegrationManager
+	// SharedIdentity is true if the cluster shares the identity provider with the default cluster, so that the token
+	// of the logged in user is forwarded to it.
+	SharedIdentity bool
+}
+
+// ClusterRegistry holds all clusters that can be accessed through a single dashboard instance.
+type ClusterRegistry interface {
+	// List returns all registered clusters sorted by name.
+	List() []Cluster
+	// Get returns cluster with given name and true if it is registered, false otherwise.
+	Get(name string) (Cluster, bool)
+}
+
+// ClusterList contains list of registered clusters with their health.
+type ClusterList struct {
+	Clusters []ClusterStatus `json:"clusters"`
+}
+
+// ClusterStatus contains basic information about a registered cluster and its health as seen by the user.
+type ClusterStatus struct {
+	// Name is a unique name of the cluster used in API paths.
+	Name string `json:"name"`
+	// Default is true for the default cluster, which is served without the cluster path prefix.
+	Default bool `json:"default"`
+	// SharedIdentity is true if the token of the logged in user is used for the cluster, otherwise credentials from
+	// the cluster config are used.
+	SharedIdentity bool `json:"sharedIdentity"`
+	// Host is the address of cluster apiserver.
+	Host string `json:"host"`
+	// Healthy is true if apiserver responded to version request made with credentials used for the user.
+	Healthy bool `json:"healthy"`
+	// Version is the version of cluster apiserver.
+	Version string `json:"version,omitempty"`
+	// Error describes why cluster is not healthy.
+	Error string `json:"error,omitempty"`
+}
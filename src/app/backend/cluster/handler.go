@@ -0,0 +1,101 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"net/http"
+	"sync"
+
+	restful "github.com/emicklei/go-restful"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/cluster/api"
+)
+
+// ClusterHandler manages all endpoints related to cluster registry.
+type ClusterHandler struct {
+	clientManager clientapi.ClientManager
+	registry      api.ClusterRegistry
+}
+
+// Install creates new endpoints for cluster registry.
+func (self ClusterHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.GET("/clusters").
+			To(self.handleGetClusters).
+			Writes(api.ClusterList{}))
+}
+
+func (self ClusterHandler) handleGetClusters(request *restful.Request, response *restful.Response) {
+	response.WriteHeaderAndEntity(http.StatusOK, GetClusterList(self.clientManager, self.registry, request))
+}
+
+// GetClusterList returns the default cluster of given client manager followed by all registered clusters. Health of
+// every cluster is checked in parallel using credentials used for the user, so the result reflects what the user can
+// access.
+func GetClusterList(clientManager clientapi.ClientManager, registry api.ClusterRegistry,
+	request *restful.Request) api.ClusterList {
+	clusters := append([]api.Cluster{{
+		Name:           api.DefaultClusterName,
+		ClientManager:  clientManager,
+		SharedIdentity: true,
+	}}, registry.List()...)
+	result := api.ClusterList{Clusters: make([]api.ClusterStatus, len(clusters))}
+
+	var wg sync.WaitGroup
+	for i, cluster := range clusters {
+		wg.Add(1)
+		go func(i int, cluster api.Cluster) {
+			defer wg.Done()
+			result.Clusters[i] = getClusterStatus(cluster, request)
+		}(i, cluster)
+	}
+
+	wg.Wait()
+	result.Clusters[0].Default = true
+	return result
+}
+
+func getClusterStatus(cluster api.Cluster, request *restful.Request) api.ClusterStatus {
+	result := api.ClusterStatus{Name: cluster.Name, SharedIdentity: cluster.SharedIdentity}
+	config, err := cluster.ClientManager.Config(request)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Host = config.Host
+	k8sClient, err := cluster.ClientManager.Client(request)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	version, err := k8sClient.Discovery().ServerVersion()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Healthy = true
+	result.Version = version.GitVersion
+	return result
+}
+
+// NewClusterHandler creates ClusterHandler listing the default cluster of given client manager and clusters from the
+// registry.
+func NewClusterHandler(clientManager clientapi.ClientManager, registry api.ClusterRegistry) ClusterHandler {
+	return ClusterHandler{clientManager: clientManager, registry: registry}
+}
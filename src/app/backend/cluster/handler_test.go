@@ -0,0 +1,52 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/cluster/api"
+)
+
+func TestClusterHandler_Install(t *testing.T) {
+	cHandler := NewClusterHandler(nil, nil)
+	ws := new(restful.WebService)
+	cHandler.Install(ws)
+
+	if len(ws.Routes()) == 0 {
+		t.Error("Failed to install routes.")
+	}
+}
+
+func TestGetClusterList(t *testing.T) {
+	registry := NewClusterRegistry(nil, nil)
+	request := restful.NewRequest(httptest.NewRequest(http.MethodGet, "/api/v1/clusters", nil))
+
+	list := GetClusterList(client.NewClientManager("", "http://127.0.0.1:1"), registry, request)
+	if len(list.Clusters) != 1 {
+		t.Fatalf("Expected only the default cluster, but got %v.", list.Clusters)
+	}
+
+	cluster := list.Clusters[0]
+	if cluster.Name != api.DefaultClusterName || !cluster.Default || !cluster.SharedIdentity ||
+		cluster.Host != "http://127.0.0.1:1" {
+		t.Errorf("Expected default cluster, but got %v.", cluster)
+	}
+}
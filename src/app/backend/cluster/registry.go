@@ -0,0 +1,141 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/cluster/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/integration"
+)
+
+// Implements ClusterRegistry interface.
+type clusterRegistry struct {
+	clusters map[string]api.Cluster
+}
+
+// List implements cluster registry interface. See ClusterRegistry for more information.
+func (self *clusterRegistry) List() []api.Cluster {
+	result := make([]api.Cluster, 0, len(self.clusters))
+	for _, cluster := range self.clusters {
+		result = append(result, cluster)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Get implements cluster registry interface. See ClusterRegistry for more information.
+func (self *clusterRegistry) Get(name string) (api.Cluster, bool) {
+	cluster, ok := self.clusters[name]
+	return cluster, ok
+}
+
+// NewClusterRegistry creates cluster registry containing a cluster for every provided client config. Clusters with
+// names that can not be used in API paths or with invalid configs are skipped. Only clusters named in
+// sharedIdentityClusters receive the token of the logged in user, the rest is accessed with credentials from their
+// configs.
+func NewClusterRegistry(configs map[string]clientcmd.ClientConfig, sharedIdentityClusters []string) api.ClusterRegistry {
+	sharedIdentity := make(map[string]bool)
+	for _, name := range sharedIdentityClusters {
+		sharedIdentity[name] = true
+	}
+
+	result := &clusterRegistry{clusters: make(map[string]api.Cluster)}
+	for name, config := range configs {
+		if err := validateClusterName(name); err != nil {
+			log.Printf("Skipping cluster %s: %s", name, err.Error())
+			continue
+		}
+
+		if _, err := config.ClientConfig(); err != nil {
+			log.Printf("Skipping cluster %s: invalid client config: %s", name, err.Error())
+			continue
+		}
+
+		clientManager := client.NewClientManagerForConfig(config, sharedIdentity[name])
+		result.clusters[name] = api.Cluster{
+			Name:               name,
+			ClientManager:      clientManager,
+			IntegrationManager: integration.NewIntegrationManager(clientManager),
+			SharedIdentity:     sharedIdentity[name],
+		}
+		log.Printf("Registered cluster: %s, shared identity: %t", name, sharedIdentity[name])
+	}
+
+	return result
+}
+
+// LoadKubeConfigClusters returns client config for every context defined in kubeconfig file with given path.
+// Context names are used as cluster names.
+func LoadKubeConfigClusters(path string) (map[string]clientcmd.ClientConfig, error) {
+	rules := &clientcmd.ClientConfigLoadingRules{ExplicitPath: path}
+	config, err := rules.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]clientcmd.ClientConfig)
+	for name := range config.Contexts {
+		result[name] = clientcmd.NewNonInteractiveClientConfig(*config, name, &clientcmd.ConfigOverrides{}, rules)
+	}
+
+	return result, nil
+}
+
+// LoadConfigMapClusters returns client config for every key of config map with given name. Keys are used as
+// cluster names and values have to contain kubeconfig file content. Current context of every kubeconfig is used.
+func LoadConfigMapClusters(client kubernetes.Interface, namespace, name string) (
+	map[string]clientcmd.ClientConfig, error) {
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]clientcmd.ClientConfig)
+	for key, value := range configMap.Data {
+		config, err := clientcmd.Load([]byte(value))
+		if err != nil {
+			return nil, errors.NewInvalid(fmt.Sprintf("Could not load kubeconfig of cluster %s: %s", key,
+				err.Error()))
+		}
+
+		result[key] = clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{})
+	}
+
+	return result, nil
+}
+
+// Checks if given cluster name can be used as a single segment of API path.
+func validateClusterName(name string) error {
+	if len(name) == 0 {
+		return errors.NewInvalid("cluster name can not be empty")
+	}
+
+	if strings.ContainsAny(name, "/?#%") {
+		return errors.NewInvalid("cluster name can not contain any of '/?#%' characters")
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const testKubeConfig = `
+apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://foo.example.com
+  name: foo
+- cluster:
+    server: https://bar.example.com
+  name: bar
+contexts:
+- context:
+    cluster: foo
+    user: admin
+  name: foo
+- context:
+    cluster: bar
+    user: admin
+  name: bar/baz
+current-context: foo
+users:
+- name: admin
+  user:
+    token: secret
+`
+
+func TestLoadKubeConfigClusters(t *testing.T) {
+	file, err := ioutil.TempFile("", "kubeconfig")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(testKubeConfig); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	configs, err := LoadKubeConfigClusters(file.Name())
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v.", err)
+	}
+
+	expected := map[string]string{"foo": "https://foo.example.com", "bar/baz": "https://bar.example.com"}
+	hosts := make(map[string]string)
+	for name, config := range configs {
+		restConfig, err := config.ClientConfig()
+		if err != nil {
+			t.Fatalf("Expected no error for cluster %s, but got %v.", name, err)
+		}
+		hosts[name] = restConfig.Host
+	}
+
+	if !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("Expected cluster hosts to be %v, but got %v.", expected, hosts)
+	}
+}
+
+func TestLoadConfigMapClusters(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "clusters", Namespace: "kube-system"},
+		Data:       map[string]string{"production": testKubeConfig},
+	})
+
+	configs, err := LoadConfigMapClusters(client, "kube-system", "clusters")
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v.", err)
+	}
+
+	restConfig, err := configs["production"].ClientConfig()
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v.", err)
+	}
+
+	if restConfig.Host != "https://foo.example.com" || restConfig.BearerToken != "secret" {
+		t.Errorf("Expected current context of kubeconfig to be used, but got host %s.", restConfig.Host)
+	}
+
+	if _, err := LoadConfigMapClusters(client, "kube-system", "missing"); err == nil {
+		t.Error("Expected error for missing config map, but got nil.")
+	}
+}
+
+func TestNewClusterRegistry(t *testing.T) {
+	config, err := clientcmd.Load([]byte(testKubeConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry := NewClusterRegistry(map[string]clientcmd.ClientConfig{
+		"foo":     clientcmd.NewNonInteractiveClientConfig(*config, "foo", &clientcmd.ConfigOverrides{}, nil),
+		"bar/baz": clientcmd.NewNonInteractiveClientConfig(*config, "bar/baz", &clientcmd.ConfigOverrides{}, nil),
+		"missing": clientcmd.NewNonInteractiveClientConfig(*config, "missing", &clientcmd.ConfigOverrides{}, nil),
+	}, []string{"foo"})
+
+	clusters := registry.List()
+	if len(clusters) != 1 || clusters[0].Name != "foo" {
+		t.Fatalf("Expected only cluster foo to be registered, but got %v.", clusters)
+	}
+
+	if cluster, ok := registry.Get("foo"); !ok || !cluster.SharedIdentity {
+		t.Errorf("Expected cluster foo with shared identity to be found, but got %v.", cluster)
+	}
+
+	if _, ok := registry.Get("bar/baz"); ok {
+		t.Error("Expected cluster with invalid name to be skipped.")
+	}
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/spf13/pflag"
+	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/kubernetes/dashboard/src/app/backend/args"
 	"github.com/kubernetes/dashboard/src/app/backend/auth"
@@ -36,6 +37,8 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/cert/ecdsa"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/cluster"
+	clusterApi "github.com/kubernetes/dashboard/src/app/backend/cluster/api"
 	"github.com/kubernetes/dashboard/src/app/backend/handler"
 	"github.com/kubernetes/dashboard/src/app/backend/integration"
 	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
@@ -65,10 +68,23 @@ var (
 		"to connect to in the format of protocol://address:port, e.g., "+
 		"http://localhost:8000. If not specified, the assumption is that the binary runs inside a "+
 		"Kubernetes cluster and service proxy will be used.")
-	argKubeConfigFile     = pflag.String("kubeconfig", "", "Path to kubeconfig file with authorization and master location information.")
+	argKubeConfigFile    = pflag.String("kubeconfig", "", "Path to kubeconfig file with authorization and master location information.")
+	argClusterKubeConfig = pflag.String("cluster-registry-kubeconfig", "", "Path to kubeconfig file with additional clusters. Every context is registered as a cluster "+
+		"served under /api/v1/cluster/{context-name}/.")
+	argClusterConfigMap = pflag.String("cluster-registry-configmap", "", "Name of the config map in '--namespace' with additional clusters. Every key is registered as a cluster "+
+		"served under /api/v1/cluster/{key}/ and its value has to contain kubeconfig file content.")
+	argClusterSharedIdentity = pflag.StringSlice("cluster-registry-shared-identity", []string{}, "Names of registered clusters that share the identity provider with the default cluster. "+
+		"Token of the logged in user is forwarded only to these clusters, other clusters are accessed with credentials from their kubeconfig.")
+	argSnapshotDir        = pflag.String("snapshot-dir", "", "Path to directory where namespace snapshots are stored. If not set, snapshots are stored in secrets in '--namespace'.")
+	argGitDir             = pflag.String("git-dir", "", "Path to directory with a Git working copy of manifests, e.g. a mounted volume. Enables comparison of live objects with manifests of the checked out commit.")
+	argGitPath            = pflag.String("git-path", "", "Directory of manifests relative to the root of '--git-dir' repository. If not set, the whole repository is read.")
 	argTokenTTL           = pflag.Int("token-ttl", int(authApi.DefaultTokenTTL), "Expiration time (in seconds) of JWE tokens generated by dashboard. '0' never expires")
 	argAuthenticationMode = pflag.StringSlice("authentication-mode", []string{authApi.Token.String()}, "Enables authentication options that will be reflected on login screen. Supported values: token, basic. "+
 		"Note that basic option should only be used if apiserver has '--authorization-mode=ABAC' and '--basic-auth-file' flags set.")
+	argLoginMaxFailedAttempts    = pflag.Int("login-max-failed-attempts", 5, "Number of failed login attempts from the same source IP or for the same username after which further attempts are rejected for the lockout duration. '0' disables login throttling. Behind a reverse proxy all clients share the IP of the proxy, unless login-client-ip-header is set.")
+	argLoginLockoutDuration      = pflag.Int("login-lockout-duration", 10, "Time in seconds for which login is locked after too many failed attempts. It is doubled for every consecutive lockout.")
+	argLoginMaxLockoutDuration   = pflag.Int("login-max-lockout-duration", 300, "Maximum time in seconds for which login can be locked after too many failed attempts.")
+	argLoginClientIPHeader       = pflag.String("login-client-ip-header", "", "Request header set by a trusted reverse proxy, e.g. X-Forwarded-For or X-Real-IP, that contains the client IP used for login throttling. The last address in the header is used. Set it only if Dashboard is reachable exclusively through the proxy, as clients could forge the header otherwise.")
 	argMetricClientCheckPeriod   = pflag.Int("metric-client-check-period", 30, "Time in seconds that defines how often configured metric client health check should be run.")
 	argAutoGenerateCertificates  = pflag.Bool("auto-generate-certificates", false, "When set to true, Dashboard will automatically generate certificates used to serve HTTPS. (default false)")
 	argEnableInsecureLogin       = pflag.Bool("enable-insecure-login", false, "When enabled, Dashboard login view will also be shown when Dashboard is not served over HTTPS. (default false)")
@@ -110,8 +126,11 @@ func main() {
 
 	log.Printf("Successful initial request to the apiserver, version: %s", versionInfo.String())
 
+	// Init cluster registry
+	clusterRegistry := initClusterRegistry(clientManager)
+
 	// Init auth manager
-	authManager := initAuthManager(clientManager)
+	authManager := initAuthManager(clientManager, clusterRegistry)
 
 	// Init settings manager
 	settingsManager := settings.NewSettingsManager()
@@ -122,21 +141,14 @@ func main() {
 
 	// Init integrations
 	integrationManager := integration.NewIntegrationManager(clientManager)
+	initMetricsProvider(integrationManager, args.Holder.GetSidecarHost(), args.Holder.GetHeapsterHost())
+	if len(args.Holder.GetGitDir()) > 0 {
+		integrationManager.ConfigureGit(args.Holder.GetGitDir(), args.Holder.GetGitPath())
+	}
 
-	switch metricsProvider := args.Holder.GetMetricsProvider(); metricsProvider {
-	case "sidecar":
-		integrationManager.Metric().ConfigureSidecar(args.Holder.GetSidecarHost()).
-			EnableWithRetry(integrationapi.SidecarIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
-	case "heapster":
-		integrationManager.Metric().ConfigureHeapster(args.Holder.GetHeapsterHost()).
-			EnableWithRetry(integrationapi.HeapsterIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
-	case "none":
-		log.Print("no metrics provider selected, will not check metrics.")
-	default:
-		log.Printf("Invalid metrics provider selected: %s", metricsProvider)
-		log.Print("Defaulting to use the Sidecar provider.")
-		integrationManager.Metric().ConfigureSidecar(args.Holder.GetSidecarHost()).
-			EnableWithRetry(integrationapi.SidecarIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
+	// Clusters from the registry always use service proxy to access their metric providers
+	for _, c := range clusterRegistry.List() {
+		initMetricsProvider(c.IntegrationManager, "", "")
 	}
 
 	apiHandler, err := handler.CreateHTTPAPIHandler(
@@ -144,7 +156,8 @@ func main() {
 		clientManager,
 		authManager,
 		settingsManager,
-		systemBannerManager)
+		systemBannerManager,
+		clusterRegistry)
 	if err != nil {
 		handleFatalInitError(err)
 	}
@@ -194,7 +207,53 @@ func main() {
 	select {}
 }
 
-func initAuthManager(clientManager clientapi.ClientManager) authApi.AuthManager {
+func initMetricsProvider(integrationManager integration.IntegrationManager, sidecarHost, heapsterHost string) {
+	switch metricsProvider := args.Holder.GetMetricsProvider(); metricsProvider {
+	case "sidecar":
+		integrationManager.Metric().ConfigureSidecar(sidecarHost).
+			EnableWithRetry(integrationapi.SidecarIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
+	case "heapster":
+		integrationManager.Metric().ConfigureHeapster(heapsterHost).
+			EnableWithRetry(integrationapi.HeapsterIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
+	case "none":
+		log.Print("no metrics provider selected, will not check metrics.")
+	default:
+		log.Printf("Invalid metrics provider selected: %s", metricsProvider)
+		log.Print("Defaulting to use the Sidecar provider.")
+		integrationManager.Metric().ConfigureSidecar(sidecarHost).
+			EnableWithRetry(integrationapi.SidecarIntegrationID, time.Duration(args.Holder.GetMetricClientCheckPeriod()))
+	}
+}
+
+func initClusterRegistry(clientManager clientapi.ClientManager) clusterApi.ClusterRegistry {
+	configs := make(map[string]clientcmd.ClientConfig)
+	if len(args.Holder.GetClusterRegistryKubeConfig()) > 0 {
+		kubeConfigClusters, err := cluster.LoadKubeConfigClusters(args.Holder.GetClusterRegistryKubeConfig())
+		if err != nil {
+			handleFatalInitClusterRegistryError(err)
+		}
+
+		for name, config := range kubeConfigClusters {
+			configs[name] = config
+		}
+	}
+
+	if len(args.Holder.GetClusterRegistryConfigMap()) > 0 {
+		configMapClusters, err := cluster.LoadConfigMapClusters(clientManager.InsecureClient(),
+			args.Holder.GetNamespace(), args.Holder.GetClusterRegistryConfigMap())
+		if err != nil {
+			handleFatalInitClusterRegistryError(err)
+		}
+
+		for name, config := range configMapClusters {
+			configs[name] = config
+		}
+	}
+
+	return cluster.NewClusterRegistry(configs, args.Holder.GetClusterRegistrySharedIdentity())
+}
+
+func initAuthManager(clientManager clientapi.ClientManager, clusterRegistry clusterApi.ClusterRegistry) authApi.AuthManager {
 	insecureClient := clientManager.InsecureClient()
 
 	// Init default encryption key synchronizer
@@ -212,8 +271,11 @@ func initAuthManager(clientManager clientapi.ClientManager) authApi.AuthManager
 		tokenManager.SetTokenTTL(tokenTTL)
 	}
 
-	// Set token manager for client manager.
+	// Set token manager for client manager and client managers of all registered clusters.
 	clientManager.SetTokenManager(tokenManager)
+	for _, c := range clusterRegistry.List() {
+		c.ClientManager.SetTokenManager(tokenManager)
+	}
 	authModes := authApi.ToAuthenticationModes(args.Holder.GetAuthenticationMode())
 	if len(authModes) == 0 {
 		authModes.Add(authApi.Token)
@@ -231,6 +293,10 @@ func initArgHolder() {
 	builder.SetPort(*argPort)
 	builder.SetTokenTTL(*argTokenTTL)
 	builder.SetMetricClientCheckPeriod(*argMetricClientCheckPeriod)
+	builder.SetLoginMaxFailedAttempts(*argLoginMaxFailedAttempts)
+	builder.SetLoginLockoutDuration(*argLoginLockoutDuration)
+	builder.SetLoginMaxLockoutDuration(*argLoginMaxLockoutDuration)
+	builder.SetLoginClientIPHeader(*argLoginClientIPHeader)
 	builder.SetInsecureBindAddress(*argInsecureBindAddress)
 	builder.SetBindAddress(*argBindAddress)
 	builder.SetDefaultCertDir(*argDefaultCertDir)
@@ -241,6 +307,12 @@ func initArgHolder() {
 	builder.SetHeapsterHost(*argHeapsterHost)
 	builder.SetSidecarHost(*argSidecarHost)
 	builder.SetKubeConfigFile(*argKubeConfigFile)
+	builder.SetClusterRegistryKubeConfig(*argClusterKubeConfig)
+	builder.SetClusterRegistryConfigMap(*argClusterConfigMap)
+	builder.SetClusterRegistrySharedIdentity(*argClusterSharedIdentity)
+	builder.SetSnapshotDir(*argSnapshotDir)
+	builder.SetGitDir(*argGitDir)
+	builder.SetGitPath(*argGitPath)
 	builder.SetSystemBanner(*argSystemBanner)
 	builder.SetSystemBannerSeverity(*argSystemBannerSeverity)
 	builder.SetAPILogLevel(*argAPILogLevel)
@@ -273,6 +345,13 @@ func handleFatalInitServingCertError(err error) {
 	log.Fatalf("Error while loading dashboard server certificates. Reason: %s", err)
 }
 
+/**
+ * Handles fatal init errors encountered during cluster registry loading.
+ */
+func handleFatalInitClusterRegistryError(err error) {
+	log.Fatalf("Error while loading cluster registry. Reason: %s", err)
+}
+
 /**
 * Lookup the environment variable provided and set to default value if variable isn't found
  */
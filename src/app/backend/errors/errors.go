@@ -50,6 +50,12 @@ func NewBadRequest(reason string) *errors.StatusError {
 	return errors.NewBadRequest(reason)
 }
 
+// NewTooManyRequests creates an error that indicates that the client must try again later because the specified
+// endpoint is not accepting requests. Retry-After header is suggested to be set to retryAfterSeconds.
+func NewTooManyRequests(reason string, retryAfterSeconds int) *errors.StatusError {
+	return errors.NewTooManyRequests(reason, retryAfterSeconds)
+}
+
 // NewInvalid return a statusError
 // which is an error intended for consumption by a REST API server; it can also be
 // reconstructed by clients from a REST response. Public to allow easy type switches.
@@ -64,6 +70,20 @@ func NewInvalid(reason string) *errors.StatusError {
 	}
 }
 
+// NewForbidden return a statusError
+// which is an error intended for consumption by a REST API server; it can also be
+// reconstructed by clients from a REST response. Public to allow easy type switches.
+func NewForbidden(reason string) *errors.StatusError {
+	return &errors.StatusError{
+		ErrStatus: metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusForbidden,
+			Reason:  metav1.StatusReasonForbidden,
+			Message: reason,
+		},
+	}
+}
+
 // NewNotFound return a statusError
 // which is an error intended for consumption by a REST API server; it can also be
 // reconstructed by clients from a REST response. Public to allow easy type switches.
@@ -78,6 +98,20 @@ func NewNotFound(reason string) *errors.StatusError {
 	}
 }
 
+// NewAlreadyExists return a statusError
+// which is an error intended for consumption by a REST API server; it can also be
+// reconstructed by clients from a REST response. Public to allow easy type switches.
+func NewAlreadyExists(reason string) *errors.StatusError {
+	return &errors.StatusError{
+		ErrStatus: metav1.Status{
+			Status:  metav1.StatusFailure,
+			Code:    http.StatusConflict,
+			Reason:  metav1.StatusReasonAlreadyExists,
+			Message: reason,
+		},
+	}
+}
+
 // NewInternal return a statusError
 // which is an error intended for consumption by a REST API server; it can also be
 // reconstructed by clients from a REST response. Public to allow easy type switches.
@@ -184,6 +218,12 @@ func IsAlreadyExists(err error) bool {
 	return errors.IsAlreadyExists(err)
 }
 
+// IsTooManyRequests determines if err is an error which indicates that there are too many requests that the server
+// cannot handle.
+func IsTooManyRequests(err error) bool {
+	return errors.IsTooManyRequests(err)
+}
+
 // IsUnauthorized determines if err is an error which indicates that the request is unauthorized and
 // requires authentication by the user.
 func IsUnauthorized(err error) bool {
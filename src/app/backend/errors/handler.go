@@ -140,5 +140,8 @@ func HandleHTTPError(err error) int {
 	if err.Error() == MsgTokenExpiredError || err.Error() == MsgLoginUnauthorizedError || err.Error() == MsgEncryptionKeyChanged {
 		return http.StatusUnauthorized
 	}
+	if errors.IsTooManyRequests(err) {
+		return http.StatusTooManyRequests
+	}
 	return http.StatusInternalServerError
 }
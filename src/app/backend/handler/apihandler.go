@@ -15,6 +15,8 @@
 package handler
 
 import (
+	"encoding/json"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"strconv"
@@ -26,13 +28,17 @@ import (
 
 	restful "github.com/emicklei/go-restful"
 	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/args"
 	"github.com/kubernetes/dashboard/src/app/backend/auth"
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/cluster"
+	clusterApi "github.com/kubernetes/dashboard/src/app/backend/cluster/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"github.com/kubernetes/dashboard/src/app/backend/integration"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/clusterrole"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/compare"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/configmap"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/container"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/controller"
@@ -40,30 +46,46 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/customresourcedefinition"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/daemonset"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dependent"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diff"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/drift"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/export"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/helmrelease"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/horizontalpodautoscaler"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/ingress"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/job"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/logs"
 	ns "github.com/kubernetes/dashboard/src/app/backend/resource/namespace"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/networkpolicy"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/node"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/overview"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/persistentvolume"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/persistentvolumeclaim"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/poddisruptionbudget"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/priorityclass"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/replicaset"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/replicationcontroller"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/secret"
 	resourceService "github.com/kubernetes/dashboard/src/app/backend/resource/service"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/snapshot"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/statefulset"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/storageclass"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/topology"
 	"github.com/kubernetes/dashboard/src/app/backend/scaling"
 	"github.com/kubernetes/dashboard/src/app/backend/settings"
 	settingsApi "github.com/kubernetes/dashboard/src/app/backend/settings/api"
 	"github.com/kubernetes/dashboard/src/app/backend/systembanner"
+	"github.com/kubernetes/dashboard/src/app/backend/template"
 	"github.com/kubernetes/dashboard/src/app/backend/validation"
 	"golang.org/x/net/xsrftoken"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/remotecommand"
 )
 
@@ -75,6 +97,11 @@ const (
 	ResponseLogString = "[%s] Outcoming response to %s with %d status code"
 )
 
+// patchContentTypes are content types accepted by raw resource patch requests. Besides JSON, these are content types
+// of all supported patch types, which can be used instead of the patchType query parameter.
+var patchContentTypes = []string{restful.MIME_JSON, string(types.JSONPatchType), string(types.MergePatchType),
+	string(types.StrategicMergePatchType), string(types.ApplyPatchType)}
+
 // APIHandler is a representation of API handler. Structure contains clientapi, Heapster clientapi and clientapi configuration.
 type APIHandler struct {
 	iManager integration.IntegrationManager
@@ -91,7 +118,7 @@ type TerminalResponse struct {
 // CreateHTTPAPIHandler creates a new HTTP handler that handles all requests to the API of the backend.
 func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clientapi.ClientManager,
 	authManager authApi.AuthManager, sManager settingsApi.SettingsManager,
-	sbManager systembanner.SystemBannerManager) (
+	sbManager systembanner.SystemBannerManager, cRegistry clusterApi.ClusterRegistry) (
 
 	http.Handler, error) {
 	apiHandler := APIHandler{iManager: iManager, cManager: cManager, sManager: sManager}
@@ -107,26 +134,58 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		Produces(restful.MIME_JSON)
 	wsContainer.Add(apiV1Ws)
 
-	integrationHandler := integration.NewIntegrationHandler(iManager)
-	integrationHandler.Install(apiV1Ws)
-
-	pluginHandler := plugin.NewPluginHandler(cManager)
-	pluginHandler.Install(apiV1Ws)
-
 	authHandler := auth.NewAuthHandler(authManager)
 	authHandler.Install(apiV1Ws)
 
 	settingsHandler := settings.NewSettingsHandler(sManager, cManager)
 	settingsHandler.Install(apiV1Ws)
 
+	templateHandler := template.NewTemplateHandler(template.NewTemplateManager(), cManager)
+	templateHandler.Install(apiV1Ws)
+
 	systemBannerHandler := systembanner.NewSystemBannerHandler(sbManager)
 	systemBannerHandler.Install(apiV1Ws)
 
+	clusterHandler := cluster.NewClusterHandler(cManager, cRegistry)
+	clusterHandler.Install(apiV1Ws)
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("csrftoken/{action}").
 			To(apiHandler.handleGetCsrfToken).
 			Writes(api.CsrfToken{}))
 
+	apiHandler.installClusterRoutes(apiV1Ws, &templateHandler)
+
+	// Every cluster from the registry is served under its own path prefix using clients and integrations of that
+	// cluster. CSRF tokens are shared with the default cluster.
+	for _, c := range cRegistry.List() {
+		clusterWs := new(restful.WebService)
+		InstallFilters(clusterWs, cManager)
+		clusterWs.Path(clusterApi.ClusterPathPrefix + c.Name).
+			Consumes(restful.MIME_JSON).
+			Produces(restful.MIME_JSON)
+		wsContainer.Add(clusterWs)
+
+		clusterAPIHandler := APIHandler{iManager: c.IntegrationManager, cManager: c.ClientManager, sManager: sManager}
+		clusterAPIHandler.installClusterRoutes(clusterWs, &templateHandler)
+	}
+
+	return wsContainer, nil
+}
+
+// installClusterRoutes installs all routes that operate on a single cluster, i.e. resource lists and details,
+// using clients and integrations of given API handler. Templates stored in the default cluster are deployed with them
+// too.
+func (apiHandler *APIHandler) installClusterRoutes(apiV1Ws *restful.WebService,
+	templateHandler *template.TemplateHandler) {
+	templateHandler.InstallDeploy(apiV1Ws, apiHandler.cManager)
+
+	integrationHandler := integration.NewIntegrationHandler(apiHandler.iManager, apiHandler.cManager)
+	integrationHandler.Install(apiV1Ws)
+
+	pluginHandler := plugin.NewPluginHandler(apiHandler.cManager)
+	pluginHandler.Install(apiV1Ws)
+
 	apiV1Ws.Route(
 		apiV1Ws.POST("/appdeployment").
 			To(apiHandler.handleDeploy).
@@ -142,6 +201,13 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleImageReferenceValidity).
 			Reads(validation.ImageReferenceValiditySpec{}).
 			Writes(validation.ImageReferenceValidity{}))
+	// Registry check is not exempted from CSRF validation as validate routes are, because it reads image pull
+	// secrets and makes requests to registries on behalf of the user.
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeployment/imagereference").
+			To(apiHandler.handleCheckImageReference).
+			Reads(validation.ImageReferenceValiditySpec{}).
+			Writes(validation.ImageReferenceValidity{}))
 	apiV1Ws.Route(
 		apiV1Ws.POST("/appdeployment/validate/protocol").
 			To(apiHandler.handleProtocolValidity).
@@ -157,6 +223,16 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleDeployFromFile).
 			Reads(deployment.AppDeploymentFromFileSpec{}).
 			Writes(deployment.AppDeploymentFromFileResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeploymentfromkustomization").
+			To(apiHandler.handleDeployFromKustomization).
+			Reads(deployment.AppDeploymentFromKustomizationSpec{}).
+			Writes(deployment.AppDeploymentFromFileResponse{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/appdeploymentfromkustomization/render").
+			To(apiHandler.handleRenderKustomization).
+			Reads(deployment.AppDeploymentFromKustomizationSpec{}).
+			Writes(deployment.AppDeploymentFromFileSpec{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/replicationcontroller").
@@ -240,6 +316,10 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/pod/{namespace}/{pod}/persistentvolumeclaim").
 			To(apiHandler.handleGetPodPersistentVolumeClaims).
 			Writes(persistentvolumeclaim.PersistentVolumeClaimList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/pod/{namespace}/{pod}/networkpolicy").
+			To(apiHandler.handleGetPodTrafficAnalysis).
+			Writes(networkpolicy.PodTrafficAnalysis{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/deployment").
@@ -384,6 +464,19 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleGetNamespaceEvents).
 			Writes(common.EventList{}))
 
+	apiV1Ws.Route(
+		apiV1Ws.GET("/helmrelease").
+			To(apiHandler.handleGetHelmReleaseList).
+			Writes(helmrelease.HelmReleaseList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/helmrelease/{namespace}").
+			To(apiHandler.handleGetHelmReleaseList).
+			Writes(helmrelease.HelmReleaseList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/helmrelease/{namespace}/{name}").
+			To(apiHandler.handleGetHelmReleaseDetail).
+			Writes(helmrelease.HelmReleaseDetail{}))
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("/secret").
 			To(apiHandler.handleGetSecretList).
@@ -398,9 +491,18 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			Writes(secret.SecretDetail{}))
 	apiV1Ws.Route(
 		apiV1Ws.POST("/secret").
-			To(apiHandler.handleCreateImagePullSecret).
+			To(apiHandler.handleCreateSecret).
 			Reads(secret.ImagePullSecretSpec{}).
 			Writes(secret.Secret{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/secret/{namespace}/{name}/key/{key}").
+			To(apiHandler.handleSetSecretKey).
+			Reads(secret.KeySpec{}).
+			Writes(secret.KeyUpdateResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/secret/{namespace}/{name}/key/{key}").
+			To(apiHandler.handleDeleteSecretKey).
+			Writes(secret.KeyUpdateResult{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/configmap").
@@ -414,6 +516,15 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/configmap/{namespace}/{configmap}").
 			To(apiHandler.handleGetConfigMapDetail).
 			Writes(configmap.ConfigMapDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/configmap/{namespace}/{configmap}/key/{key}").
+			To(apiHandler.handleSetConfigMapKey).
+			Reads(configmap.KeySpec{}).
+			Writes(configmap.KeyUpdateResult{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/configmap/{namespace}/{configmap}/key/{key}").
+			To(apiHandler.handleDeleteConfigMapKey).
+			Writes(configmap.KeyUpdateResult{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/service").
@@ -449,6 +560,41 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleGetIngressDetail).
 			Writes(ingress.IngressDetail{}))
 
+	apiV1Ws.Route(
+		apiV1Ws.GET("/networkpolicy").
+			To(apiHandler.handleGetNetworkPolicyList).
+			Writes(networkpolicy.NetworkPolicyList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/networkpolicy/{namespace}").
+			To(apiHandler.handleGetNetworkPolicyList).
+			Writes(networkpolicy.NetworkPolicyList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/networkpolicy/{namespace}/{name}").
+			To(apiHandler.handleGetNetworkPolicyDetail).
+			Writes(networkpolicy.NetworkPolicyDetail{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/poddisruptionbudget").
+			To(apiHandler.handleGetPodDisruptionBudgetList).
+			Writes(poddisruptionbudget.PodDisruptionBudgetList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/poddisruptionbudget/{namespace}").
+			To(apiHandler.handleGetPodDisruptionBudgetList).
+			Writes(poddisruptionbudget.PodDisruptionBudgetList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/poddisruptionbudget/{namespace}/{name}").
+			To(apiHandler.handleGetPodDisruptionBudgetDetail).
+			Writes(poddisruptionbudget.PodDisruptionBudgetDetail{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/priorityclass").
+			To(apiHandler.handleGetPriorityClassList).
+			Writes(priorityclass.PriorityClassList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/priorityclass/{name}").
+			To(apiHandler.handleGetPriorityClassDetail).
+			Writes(priorityclass.PriorityClassDetail{}))
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("/statefulset").
 			To(apiHandler.handleGetStatefulSetList).
@@ -470,6 +616,44 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 			To(apiHandler.handleGetStatefulSetEvents).
 			Writes(common.EventList{}))
 
+	apiV1Ws.Route(
+		apiV1Ws.GET("/overview").
+			To(apiHandler.handleGetOverview).
+			Writes(overview.Overview{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/topology/{namespace}").
+			To(apiHandler.handleGetTopology).
+			Writes(topology.Topology{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/export/{namespace}").
+			To(apiHandler.handleExport).
+			Produces(restful.MIME_JSON, "application/yaml", "application/x-tar"))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/snapshot/{namespace}").
+			To(apiHandler.handleGetSnapshotList).
+			Writes(snapshot.SnapshotList{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/snapshot/{namespace}").
+			To(apiHandler.handleCreateSnapshot).
+			Writes(snapshot.SnapshotDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/snapshot/{namespace}/{name}").
+			To(apiHandler.handleGetSnapshotDetail).
+			Writes(snapshot.SnapshotDetail{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/snapshot/{namespace}/{name}").
+			To(apiHandler.handleDeleteSnapshot))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/snapshot/{namespace}/{name}/diff").
+			To(apiHandler.handleGetSnapshotDiff).
+			Writes(snapshot.SnapshotDiff{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/snapshot/{namespace}/{name}/restore").
+			To(apiHandler.handleRestoreSnapshot).
+			Reads(snapshot.RestoreSpec{}).
+			Writes(deployment.AppDeploymentFromFileResponse{}))
+
 	apiV1Ws.Route(
 		apiV1Ws.GET("/node").
 			To(apiHandler.handleGetNodeList).
@@ -486,6 +670,31 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/node/{name}/pod").
 			To(apiHandler.handleGetNodePods).
 			Writes(pod.PodList{}))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/node/{name}/cordon").
+			To(apiHandler.handleCordonNode))
+	apiV1Ws.Route(
+		apiV1Ws.PUT("/node/{name}/uncordon").
+			To(apiHandler.handleUncordonNode))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/node/{name}/drain").
+			To(apiHandler.handleDrainNode).
+			Reads(node.DrainSpec{}).
+			Writes(node.DrainProgress{}))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/node/{name}/taint").
+			To(apiHandler.handleAddNodeTaint).
+			Reads(v1.Taint{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/node/{name}/taint").
+			To(apiHandler.handleRemoveNodeTaint))
+	apiV1Ws.Route(
+		apiV1Ws.POST("/node/{name}/label").
+			To(apiHandler.handleAddNodeLabel).
+			Reads(node.NodeLabel{}))
+	apiV1Ws.Route(
+		apiV1Ws.DELETE("/node/{name}/label").
+			To(apiHandler.handleRemoveNodeLabel))
 
 	apiV1Ws.Route(
 		apiV1Ws.DELETE("/_raw/{kind}/namespace/{namespace}/name/{name}").
@@ -496,6 +705,18 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 	apiV1Ws.Route(
 		apiV1Ws.PUT("/_raw/{kind}/namespace/{namespace}/name/{name}").
 			To(apiHandler.handlePutResource))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/_raw/{kind}/namespace/{namespace}/name/{name}").
+			To(apiHandler.handlePatchResource).
+			Consumes(patchContentTypes...))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/_raw/{kind}/namespace/{namespace}/name/{name}/dependents").
+			To(apiHandler.handleGetResourceDependents).
+			Writes(dependent.DependentList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/_raw/{kind}/namespace/{namespace}/name/{name}/drift").
+			To(apiHandler.handleGetResourceDrift).
+			Writes(drift.ObjectDrift{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.DELETE("/_raw/{kind}/name/{name}").
@@ -506,6 +727,29 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 	apiV1Ws.Route(
 		apiV1Ws.PUT("/_raw/{kind}/name/{name}").
 			To(apiHandler.handlePutResource))
+	apiV1Ws.Route(
+		apiV1Ws.PATCH("/_raw/{kind}/name/{name}").
+			To(apiHandler.handlePatchResource).
+			Consumes(patchContentTypes...))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/_raw/{kind}/name/{name}/dependents").
+			To(apiHandler.handleGetResourceDependents).
+			Writes(dependent.DependentList{}))
+	apiV1Ws.Route(
+		apiV1Ws.GET("/_raw/{kind}/name/{name}/drift").
+			To(apiHandler.handleGetResourceDrift).
+			Writes(drift.ObjectDrift{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.GET("/drift/{namespace}").
+			To(apiHandler.handleGetDriftReport).
+			Writes(drift.DriftReport{}))
+
+	apiV1Ws.Route(
+		apiV1Ws.POST("/diff").
+			To(apiHandler.handleCompareObjects).
+			Reads(compare.ComparisonSpec{}).
+			Writes(compare.Comparison{}))
 
 	apiV1Ws.Route(
 		apiV1Ws.GET("/clusterrole").
@@ -598,8 +842,6 @@ func CreateHTTPAPIHandler(iManager integration.IntegrationManager, cManager clie
 		apiV1Ws.GET("/log/file/{namespace}/{pod}/{container}").
 			To(apiHandler.handleLogFile).
 			Writes(logs.LogDetails{}))
-
-	return wsContainer, nil
 }
 
 func (apiHandler *APIHandler) handleGetClusterRoleList(request *restful.Request, response *restful.Response) {
@@ -801,18 +1043,16 @@ func (apiHandler *APIHandler) handleGetIngressList(request *restful.Request, res
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetServicePods(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetNetworkPolicyList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("service")
 	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := resourceService.GetServicePods(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name, dataSelect)
+	namespace := parseNamespacePathParameter(request)
+	result, err := networkpolicy.GetNetworkPolicyList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -820,16 +1060,16 @@ func (apiHandler *APIHandler) handleGetServicePods(request *restful.Request, res
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetNodeList(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetNetworkPolicyDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := node.GetNodeList(k8sClient, dataSelect, apiHandler.iManager.Metric().Client())
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := networkpolicy.GetNetworkPolicyDetail(k8sClient, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -837,17 +1077,17 @@ func (apiHandler *APIHandler) handleGetNodeList(request *restful.Request, respon
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetNodeDetail(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetPodDisruptionBudgetList(request *restful.Request,
+	response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	name := request.PathParameter("name")
 	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := node.GetNodeDetail(k8sClient, apiHandler.iManager.Metric().Client(), name, dataSelect)
+	namespace := parseNamespacePathParameter(request)
+	result, err := poddisruptionbudget.GetPodDisruptionBudgetList(k8sClient, namespace, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -855,17 +1095,17 @@ func (apiHandler *APIHandler) handleGetNodeDetail(request *restful.Request, resp
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetNodeEvents(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetPodDisruptionBudgetDetail(request *restful.Request,
+	response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
+	namespace := request.PathParameter("namespace")
 	name := request.PathParameter("name")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := event.GetNodeEvents(k8sClient, dataSelect, name)
+	result, err := poddisruptionbudget.GetPodDisruptionBudgetDetail(k8sClient, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -873,17 +1113,15 @@ func (apiHandler *APIHandler) handleGetNodeEvents(request *restful.Request, resp
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetNodePods(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetPriorityClassList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	name := request.PathParameter("name")
 	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := node.GetNodePods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, name)
+	result, err := priorityclass.GetPriorityClassList(k8sClient, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -891,154 +1129,167 @@ func (apiHandler *APIHandler) handleGetNodePods(request *restful.Request, respon
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleDeploy(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetPriorityClassDetail(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	appDeploymentSpec := new(deployment.AppDeploymentSpec)
-	if err := request.ReadEntity(appDeploymentSpec); err != nil {
-		errors.HandleInternalError(response, err)
-		return
-	}
-	if err := deployment.DeployApp(appDeploymentSpec, k8sClient); err != nil {
+	name := request.PathParameter("name")
+	result, err := priorityclass.GetPriorityClassDetail(k8sClient, name)
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusCreated, appDeploymentSpec)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleScaleResource(request *restful.Request, response *restful.Response) {
-	cfg, err := apiHandler.cManager.Config(request)
+func (apiHandler *APIHandler) handleGetServicePods(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
 	namespace := request.PathParameter("namespace")
-	kind := request.PathParameter("kind")
-	name := request.PathParameter("name")
-	count := request.QueryParameter("scaleBy")
-	replicaCountSpec, err := scaling.ScaleResource(cfg, kind, namespace, name, count)
+	name := request.PathParameter("service")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := resourceService.GetServicePods(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name, dataSelect)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, replicaCountSpec)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetReplicaCount(request *restful.Request, response *restful.Response) {
-	cfg, err := apiHandler.cManager.Config(request)
+func (apiHandler *APIHandler) handleGetOverview(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	kind := request.PathParameter("kind")
-	name := request.PathParameter("name")
-	replicaCounts, err := scaling.GetReplicaCounts(cfg, kind, namespace, name)
+	result, err := overview.GetOverview(k8sClient)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, replicaCounts)
+	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleDeployFromFile(request *restful.Request, response *restful.Response) {
-	cfg, err := apiHandler.cManager.Config(request)
+func (apiHandler *APIHandler) handleExport(request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	deploymentSpec := new(deployment.AppDeploymentFromFileSpec)
-	if err := request.ReadEntity(deploymentSpec); err != nil {
+	namespace := request.PathParameter("namespace")
+	format, err := export.ParseFormat(request.QueryParameter("format"))
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	isDeployed, err := deployment.DeployAppFromFile(cfg, deploymentSpec)
-	if !isDeployed {
+	query, err := export.NewExportQuery(request.QueryParameter("kinds"), request.QueryParameter("labelSelector"))
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	errorMessage := ""
+	result, err := export.GetExport(config, namespace, query)
 	if err != nil {
-		errorMessage = err.Error()
+		errors.HandleInternalError(response, err)
+		return
 	}
 
-	response.WriteHeaderAndEntity(http.StatusCreated, deployment.AppDeploymentFromFileResponse{
-		Name:    deploymentSpec.Name,
-		Content: deploymentSpec.Content,
-		Error:   errorMessage,
-	})
+	response.AddHeader("Content-Disposition", "attachment; filename="+namespace+"."+string(format))
+	if format == export.FormatTar {
+		response.AddHeader(restful.HEADER_ContentType, "application/x-tar")
+		err = result.WriteTar(response)
+	} else {
+		response.AddHeader(restful.HEADER_ContentType, "application/yaml")
+		err = result.WriteYAML(response)
+	}
+
+	if err != nil {
+		log.Printf("Failed to write export of %s namespace: %s", namespace, err.Error())
+	}
 }
 
-func (apiHandler *APIHandler) handleNameValidity(request *restful.Request, response *restful.Response) {
+// getSnapshotStore returns the store of namespace snapshots. Snapshots are kept in the mounted directory if it is
+// configured, otherwise in secrets of Dashboard namespace of the cluster.
+func getSnapshotStore(k8sClient kubernetes.Interface) snapshot.Store {
+	if len(args.Holder.GetSnapshotDir()) > 0 {
+		return snapshot.NewDirectoryStore(args.Holder.GetSnapshotDir())
+	}
+	return snapshot.NewSecretStore(k8sClient, args.Holder.GetNamespace())
+}
+
+// getSnapshotAccessChecker returns access checker of snapshot objects, that uses permissions of the request user.
+func (apiHandler *APIHandler) getSnapshotAccessChecker(request *restful.Request) snapshot.AccessChecker {
+	return func(ssar *authorizationv1.SelfSubjectAccessReview) bool {
+		return apiHandler.cManager.CanI(request, ssar)
+	}
+}
+
+func (apiHandler *APIHandler) handleGetSnapshotList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	spec := new(validation.AppNameValiditySpec)
-	if err := request.ReadEntity(spec); err != nil {
+	namespace := request.PathParameter("namespace")
+	result, err := snapshot.GetSnapshotList(getSnapshotStore(k8sClient), namespace)
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
 
-	validity, err := validation.ValidateAppName(spec, k8sClient)
+func (apiHandler *APIHandler) handleCreateSnapshot(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	response.WriteHeaderAndEntity(http.StatusOK, validity)
-}
-
-func (APIHandler *APIHandler) handleImageReferenceValidity(request *restful.Request, response *restful.Response) {
-	spec := new(validation.ImageReferenceValiditySpec)
-	if err := request.ReadEntity(spec); err != nil {
+	config, err := apiHandler.cManager.Config(request)
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	validity, err := validation.ValidateImageReference(spec)
+	namespace := request.PathParameter("namespace")
+	result, err := snapshot.CreateSnapshot(config, getSnapshotStore(k8sClient), namespace)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, validity)
+	response.WriteHeaderAndEntity(http.StatusCreated, result)
 }
 
-func (apiHandler *APIHandler) handleProtocolValidity(request *restful.Request, response *restful.Response) {
-	spec := new(validation.ProtocolValiditySpec)
-	if err := request.ReadEntity(spec); err != nil {
+func (apiHandler *APIHandler) handleGetSnapshotDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, validation.ValidateProtocol(spec))
-}
-
-func (apiHandler *APIHandler) handleGetAvailableProtocols(request *restful.Request, response *restful.Response) {
-	response.WriteHeaderAndEntity(http.StatusOK, deployment.GetAvailableProtocols())
-}
 
-func (apiHandler *APIHandler) handleGetReplicationControllerList(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := replicationcontroller.GetReplicationControllerList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := snapshot.GetSnapshotDetail(config, apiHandler.getSnapshotAccessChecker(request),
+		getSnapshotStore(k8sClient), namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1046,84 +1297,85 @@ func (apiHandler *APIHandler) handleGetReplicationControllerList(request *restfu
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetReplicaSets(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleDeleteSnapshot(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := replicaset.GetReplicaSetList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
+	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	err = snapshot.DeleteSnapshot(config, apiHandler.getSnapshotAccessChecker(request), getSnapshotStore(k8sClient),
+		namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
 }
 
-func (apiHandler *APIHandler) handleGetReplicaSetDetail(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetSnapshotDiff(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	replicaSet := request.PathParameter("replicaSet")
-	result, err := replicaset.GetReplicaSetDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, replicaSet)
-
+	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := snapshot.GetSnapshotDiff(config, apiHandler.getSnapshotAccessChecker(request),
+		getSnapshotStore(k8sClient), namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetReplicaSetPods(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleRestoreSnapshot(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	replicaSet := request.PathParameter("replicaSet")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := replicaset.GetReplicaSetPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, replicaSet, namespace)
+	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	response.WriteHeaderAndEntity(http.StatusOK, result)
-}
-
-func (apiHandler *APIHandler) handleGetReplicaSetServices(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
-	if err != nil {
+	spec := new(snapshot.RestoreSpec)
+	if err := request.ReadEntity(spec); err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
 	namespace := request.PathParameter("namespace")
-	replicaSet := request.PathParameter("replicaSet")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := replicaset.GetReplicaSetServices(k8sClient, dataSelect, namespace, replicaSet)
+	name := request.PathParameter("name")
+	result, err := snapshot.RestoreSnapshot(config, apiHandler.getSnapshotAccessChecker(request),
+		getSnapshotStore(k8sClient), namespace, name, spec)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-
-	response.WriteHeaderAndEntity(http.StatusOK, result)
+	response.WriteHeaderAndEntity(result.Status(http.StatusOK), result)
 }
 
-func (apiHandler *APIHandler) handleGetReplicaSetEvents(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetTopology(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1131,19 +1383,540 @@ func (apiHandler *APIHandler) handleGetReplicaSetEvents(request *restful.Request
 	}
 
 	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("replicaSet")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
+	result, err := topology.GetTopology(k8sClient, namespace)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 	response.WriteHeaderAndEntity(http.StatusOK, result)
-
 }
 
-func (apiHandler *APIHandler) handleGetPodEvents(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleGetNodeList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := node.GetNodeList(k8sClient, dataSelect, apiHandler.iManager.Metric().Client())
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNodeDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := node.GetNodeDetail(k8sClient, apiHandler.iManager.Metric().Client(), name, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNodeEvents(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := event.GetNodeEvents(k8sClient, dataSelect, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetNodePods(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := node.GetNodePods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleCordonNode(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	if err := node.CordonNode(k8sClient, name); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (apiHandler *APIHandler) handleUncordonNode(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	if err := node.UncordonNode(k8sClient, name); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+// Drain progress is streamed as newline delimited JSON objects, one for every change of pod state. Errors occurring
+// before anything was streamed are returned as regular error responses.
+func (apiHandler *APIHandler) handleDrainNode(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(node.DrainSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	streaming := false
+	encoder := json.NewEncoder(response)
+	flusher, _ := response.ResponseWriter.(http.Flusher)
+	write := func(progress node.DrainProgress) {
+		if !streaming {
+			response.AddHeader("Content-Type", "application/x-ndjson")
+			response.WriteHeader(http.StatusOK)
+			streaming = true
+		}
+
+		encoder.Encode(progress)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	err = node.DrainNode(k8sClient, name, spec, write)
+	if err != nil {
+		if !streaming {
+			errors.HandleInternalError(response, err)
+			return
+		}
+		write(node.DrainProgress{Status: node.DrainStatusFailed, Message: err.Error()})
+	}
+}
+
+func (apiHandler *APIHandler) handleAddNodeTaint(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	taint := new(v1.Taint)
+	if err := request.ReadEntity(taint); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	if err := node.AddTaint(k8sClient, name, *taint); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+// Taint keys may contain slashes, so the key and optional effect are passed as query parameters.
+func (apiHandler *APIHandler) handleRemoveNodeTaint(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	key := request.QueryParameter("key")
+	effect := v1.TaintEffect(request.QueryParameter("effect"))
+	if err := node.RemoveTaint(k8sClient, name, key, effect); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (apiHandler *APIHandler) handleAddNodeLabel(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	label := new(node.NodeLabel)
+	if err := request.ReadEntity(label); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	if err := node.AddLabel(k8sClient, name, *label); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (apiHandler *APIHandler) handleRemoveNodeLabel(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("name")
+	key := request.QueryParameter("key")
+	if err := node.RemoveLabel(k8sClient, name, key); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusOK)
+}
+
+func (apiHandler *APIHandler) handleDeploy(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	appDeploymentSpec := new(deployment.AppDeploymentSpec)
+	if err := request.ReadEntity(appDeploymentSpec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	if err := validation.ValidateAppDeploymentSpec(appDeploymentSpec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	if err := deployment.DeployApp(appDeploymentSpec, k8sClient); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, appDeploymentSpec)
+}
+
+func (apiHandler *APIHandler) handleScaleResource(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	kind := request.PathParameter("kind")
+	name := request.PathParameter("name")
+	count := request.QueryParameter("scaleBy")
+	replicaCountSpec, err := scaling.ScaleResource(cfg, kind, namespace, name, count)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, replicaCountSpec)
+}
+
+func (apiHandler *APIHandler) handleGetReplicaCount(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	kind := request.PathParameter("kind")
+	name := request.PathParameter("name")
+	replicaCounts, err := scaling.GetReplicaCounts(cfg, kind, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, replicaCounts)
+}
+
+func (apiHandler *APIHandler) handleDeployFromFile(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	deploymentSpec := new(deployment.AppDeploymentFromFileSpec)
+	if err := request.ReadEntity(deploymentSpec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := deployment.DeployAppFromFile(cfg, deploymentSpec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(result.Status(http.StatusCreated), result)
+}
+
+func (apiHandler *APIHandler) handleDeployFromKustomization(request *restful.Request, response *restful.Response) {
+	cfg, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(deployment.AppDeploymentFromKustomizationSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := deployment.DeployAppFromKustomization(cfg, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(result.Status(http.StatusCreated), result)
+}
+
+func (apiHandler *APIHandler) handleRenderKustomization(request *restful.Request, response *restful.Response) {
+	spec := new(deployment.AppDeploymentFromKustomizationSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := deployment.RenderKustomization(spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleNameValidity(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(validation.AppNameValiditySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	validity, err := validation.ValidateAppName(spec, k8sClient)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, validity)
+}
+
+func (APIHandler *APIHandler) handleImageReferenceValidity(request *restful.Request, response *restful.Response) {
+	spec := new(validation.ImageReferenceValiditySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	validity, err := validation.ValidateImageReference(spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, validity)
+}
+
+func (apiHandler *APIHandler) handleCheckImageReference(request *restful.Request, response *restful.Response) {
+	spec := new(validation.ImageReferenceValiditySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	validity, err := validation.CheckImageReference(k8sClient, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, validity)
+}
+
+func (apiHandler *APIHandler) handleProtocolValidity(request *restful.Request, response *restful.Response) {
+	spec := new(validation.ProtocolValiditySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, validation.ValidateProtocol(spec))
+}
+
+func (apiHandler *APIHandler) handleGetAvailableProtocols(request *restful.Request, response *restful.Response) {
+	response.WriteHeaderAndEntity(http.StatusOK, deployment.GetAvailableProtocols())
+}
+
+func (apiHandler *APIHandler) handleGetReplicationControllerList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := replicationcontroller.GetReplicationControllerList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetReplicaSets(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := replicaset.GetReplicaSetList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetReplicaSetDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	replicaSet := request.PathParameter("replicaSet")
+	result, err := replicaset.GetReplicaSetDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, replicaSet)
+
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetReplicaSetPods(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	replicaSet := request.PathParameter("replicaSet")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := replicaset.GetReplicaSetPods(k8sClient, apiHandler.iManager.Metric().Client(), dataSelect, replicaSet, namespace)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetReplicaSetServices(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	replicaSet := request.PathParameter("replicaSet")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := replicaset.GetReplicaSetServices(k8sClient, dataSelect, namespace, replicaSet)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetReplicaSetEvents(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("replicaSet")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+
+}
+
+func (apiHandler *APIHandler) handleGetPodEvents(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1192,72 +1965,257 @@ func (apiHandler *APIHandler) handleExecShell(request *restful.Request, response
 	response.WriteHeaderAndEntity(http.StatusOK, TerminalResponse{Id: sessionID})
 }
 
-func (apiHandler *APIHandler) handleGetDeployments(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+func (apiHandler *APIHandler) handleGetDeployments(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := deployment.GetDeploymentList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetDeploymentDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("deployment")
+	result, err := deployment.GetDeploymentDetail(k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetDeploymentEvents(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("deployment")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetDeploymentOldReplicaSets(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("deployment")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := deployment.GetDeploymentOldReplicaSets(k8sClient, dataSelect, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetDeploymentNewReplicaSet(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("deployment")
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics
+	result, err := deployment.GetDeploymentNewReplicaSet(k8sClient, dataSelect, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetPods(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := parseNamespacePathParameter(request)
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	dataSelect.MetricQuery = dataselect.StandardMetrics // download standard metrics - cpu, and memory - by default
+	result, err := pod.GetPodList(k8sClient, apiHandler.iManager.Metric().Client(), namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetPodDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("pod")
+	result, err := pod.GetPodDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetReplicationControllerDetail(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("replicationController")
+	result, err := replicationcontroller.GetReplicationControllerDetail(k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleUpdateReplicasCount(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("replicationController")
+	spec := new(replicationcontroller.ReplicationControllerSpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	if err := replicationcontroller.UpdateReplicasCount(k8sClient, namespace, name, spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeader(http.StatusAccepted)
+}
+
+func (apiHandler *APIHandler) handleGetResource(request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	verber, err := apiHandler.cManager.VerberClient(request, config)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	kind := request.PathParameter("kind")
+	namespace, ok := request.PathParameters()["namespace"]
+	name := request.PathParameter("name")
+	result, err := verber.Get(kind, ok, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetResourceDependents(request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	verber, err := apiHandler.cManager.VerberClient(request, config)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := deployment.GetDeploymentList(k8sClient, namespace, dataSelect, apiHandler.iManager.Metric().Client())
+	policy, err := parser.ParsePropagationPolicy(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
-}
 
-func (apiHandler *APIHandler) handleGetDeploymentDetail(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+	kind := request.PathParameter("kind")
+	namespace, ok := request.PathParameters()["namespace"]
+	name := request.PathParameter("name")
+	object, err := verber.Get(kind, ok, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("deployment")
-	result, err := deployment.GetDeploymentDetail(k8sClient, namespace, name)
+	result, err := dependent.GetResourceDependents(config, object, policy)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetDeploymentEvents(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+func (apiHandler *APIHandler) handleGetResourceDrift(request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("deployment")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	result, err := event.GetResourceEvents(k8sClient, dataSelect, namespace, name)
+	verber, err := apiHandler.cManager.VerberClient(request, config)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
-}
 
-func (apiHandler *APIHandler) handleGetDeploymentOldReplicaSets(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+	kind := request.PathParameter("kind")
+	namespace, ok := request.PathParameters()["namespace"]
+	name := request.PathParameter("name")
+	object, err := verber.Get(kind, ok, namespace, name)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("deployment")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := deployment.GetDeploymentOldReplicaSets(k8sClient, dataSelect, namespace, name)
+	result, err := drift.GetResourceDrift(object)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1265,18 +2223,15 @@ func (apiHandler *APIHandler) handleGetDeploymentOldReplicaSets(request *restful
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetDeploymentNewReplicaSet(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+func (apiHandler *APIHandler) handleGetDriftReport(request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
 	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("deployment")
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics
-	result, err := deployment.GetDeploymentNewReplicaSet(k8sClient, dataSelect, namespace, name)
+	result, err := drift.GetDriftReport(config, namespace)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
@@ -1284,82 +2239,72 @@ func (apiHandler *APIHandler) handleGetDeploymentNewReplicaSet(request *restful.
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleGetPods(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleCompareObjects(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := parseNamespacePathParameter(request)
-	dataSelect := parser.ParseDataSelectPathParameter(request)
-	dataSelect.MetricQuery = dataselect.StandardMetrics // download standard metrics - cpu, and memory - by default
-	result, err := pod.GetPodList(k8sClient, apiHandler.iManager.Metric().Client(), namespace, dataSelect)
+	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
-}
 
-func (apiHandler *APIHandler) handleGetPodDetail(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+	verber, err := apiHandler.cManager.VerberClient(request, config)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("pod")
-	result, err := pod.GetPodDetail(k8sClient, apiHandler.iManager.Metric().Client(), namespace, name)
-	if err != nil {
+	spec := new(compare.ComparisonSpec)
+	if err := request.ReadEntity(spec); err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
-}
 
-func (apiHandler *APIHandler) handleGetReplicationControllerDetail(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+	result, err := compare.CompareObjects(verber, k8sClient, spec)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
 
-	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("replicationController")
-	result, err := replicationcontroller.GetReplicationControllerDetail(k8sClient, namespace, name)
+func (apiHandler *APIHandler) handlePutResource(
+	request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
-	response.WriteHeaderAndEntity(http.StatusOK, result)
-}
 
-func (apiHandler *APIHandler) handleUpdateReplicasCount(request *restful.Request, response *restful.Response) {
-	k8sClient, err := apiHandler.cManager.Client(request)
+	verber, err := apiHandler.cManager.VerberClient(request, config)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	namespace := request.PathParameter("namespace")
-	name := request.PathParameter("replicationController")
-	spec := new(replicationcontroller.ReplicationControllerSpec)
-	if err := request.ReadEntity(spec); err != nil {
+	kind := request.PathParameter("kind")
+	namespace, ok := request.PathParameters()["namespace"]
+	name := request.PathParameter("name")
+	putSpec := &runtime.Unknown{}
+	if err := request.ReadEntity(putSpec); err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	if err := replicationcontroller.UpdateReplicasCount(k8sClient, namespace, name, spec); err != nil {
+	if err := verber.Put(kind, ok, namespace, name, putSpec); err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	response.WriteHeader(http.StatusAccepted)
+	response.WriteHeader(http.StatusCreated)
 }
 
-func (apiHandler *APIHandler) handleGetResource(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handlePatchResource(
+	request *restful.Request, response *restful.Response) {
 	config, err := apiHandler.cManager.Config(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
@@ -1375,44 +2320,57 @@ func (apiHandler *APIHandler) handleGetResource(request *restful.Request, respon
 	kind := request.PathParameter("kind")
 	namespace, ok := request.PathParameters()["namespace"]
 	name := request.PathParameter("name")
-	result, err := verber.Get(kind, ok, namespace, name)
+
+	patchType, err := parser.ParsePatchType(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	response.WriteHeaderAndEntity(http.StatusOK, result)
-}
-
-func (apiHandler *APIHandler) handlePutResource(
-	request *restful.Request, response *restful.Response) {
-	config, err := apiHandler.cManager.Config(request)
+	patchOptions, err := parser.ParsePatchOptions(request, patchType)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	verber, err := apiHandler.cManager.VerberClient(request, config)
+	data, err := ioutil.ReadAll(request.Request.Body)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	kind := request.PathParameter("kind")
-	namespace, ok := request.PathParameters()["namespace"]
-	name := request.PathParameter("name")
-	putSpec := &runtime.Unknown{}
-	if err := request.ReadEntity(putSpec); err != nil {
+	if len(patchOptions.DryRun) == 0 {
+		result, err := verber.Patch(kind, ok, namespace, name, patchType, data, patchOptions)
+		if err != nil {
+			errors.HandleInternalError(response, err)
+			return
+		}
+		response.WriteHeaderAndEntity(http.StatusOK, result)
+		return
+	}
+
+	// Server-side apply creates the resource if it does not exist, so there may be no live object to compare with.
+	live, err := verber.Get(kind, ok, namespace, name)
+	if errors.IsNotFoundError(err) {
+		live, err = nil, nil
+	}
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	if err := verber.Put(kind, ok, namespace, name, putSpec); err != nil {
+	result, err := verber.Patch(kind, ok, namespace, name, patchType, data, patchOptions)
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	response.WriteHeader(http.StatusCreated)
+	dryRunResult, err := diff.NewDryRunResult(live, result)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, dryRunResult)
 }
 
 func (apiHandler *APIHandler) handleDeleteResource(
@@ -1433,11 +2391,23 @@ func (apiHandler *APIHandler) handleDeleteResource(
 	namespace, ok := request.PathParameters()["namespace"]
 	name := request.PathParameter("name")
 
-	if err := verber.Delete(kind, ok, namespace, name); err != nil {
+	deleteOptions, err := parser.ParseDeleteOptions(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	if err := verber.Delete(kind, ok, namespace, name, deleteOptions); err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
+	// Nothing was deleted in dry run mode, so the resource stays pinned.
+	if len(deleteOptions.DryRun) > 0 {
+		response.WriteHeader(http.StatusOK)
+		return
+	}
+
 	// Try to unpin resource if it was pinned.
 	pinnedResource := &settingsApi.PinnedResource{
 		Name:      name,
@@ -1546,15 +2516,21 @@ func (apiHandler *APIHandler) handleGetNamespaceEvents(request *restful.Request,
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
-func (apiHandler *APIHandler) handleCreateImagePullSecret(request *restful.Request, response *restful.Response) {
+func (apiHandler *APIHandler) handleCreateSecret(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
 
-	spec := new(secret.ImagePullSecretSpec)
-	if err := request.ReadEntity(spec); err != nil {
+	body, err := ioutil.ReadAll(request.Request.Body)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec, err := secret.NewSecretSpec(body)
+	if err != nil {
 		errors.HandleInternalError(response, err)
 		return
 	}
@@ -1583,6 +2559,90 @@ func (apiHandler *APIHandler) handleGetSecretDetail(request *restful.Request, re
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+func (apiHandler *APIHandler) handleSetSecretKey(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(secret.KeySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	key := request.PathParameter("key")
+	result, err := secret.SetSecretKey(k8sClient, namespace, name, key, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDeleteSecretKey(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	key := request.PathParameter("key")
+	resourceVersion := request.QueryParameter("resourceVersion")
+	restart := request.QueryParameter("restart") == "true"
+	result, err := secret.DeleteSecretKey(k8sClient, namespace, name, key, resourceVersion, restart)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetHelmReleaseList(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	dataSelect := parser.ParseDataSelectPathParameter(request)
+	namespace := parseNamespacePathParameter(request)
+	result, err := helmrelease.GetHelmReleaseList(k8sClient, namespace, dataSelect)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleGetHelmReleaseDetail(request *restful.Request, response *restful.Response) {
+	config, err := apiHandler.cManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("name")
+	result, err := helmrelease.GetHelmReleaseDetail(config, k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 func (apiHandler *APIHandler) handleGetSecretList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
@@ -1634,6 +2694,50 @@ func (apiHandler *APIHandler) handleGetConfigMapDetail(request *restful.Request,
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+func (apiHandler *APIHandler) handleSetConfigMapKey(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	spec := new(configmap.KeySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("configmap")
+	key := request.PathParameter("key")
+	result, err := configmap.SetConfigMapKey(k8sClient, namespace, name, key, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (apiHandler *APIHandler) handleDeleteConfigMapKey(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	name := request.PathParameter("configmap")
+	key := request.PathParameter("key")
+	resourceVersion := request.QueryParameter("resourceVersion")
+	restart := request.QueryParameter("restart") == "true"
+	result, err := configmap.DeleteConfigMapKey(k8sClient, namespace, name, key, resourceVersion, restart)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 func (apiHandler *APIHandler) handleGetPersistentVolumeList(request *restful.Request, response *restful.Response) {
 	k8sClient, err := apiHandler.cManager.Client(request)
 	if err != nil {
@@ -2120,6 +3224,23 @@ func (apiHandler *APIHandler) handleGetPodPersistentVolumeClaims(request *restfu
 	response.WriteHeaderAndEntity(http.StatusOK, result)
 }
 
+func (apiHandler *APIHandler) handleGetPodTrafficAnalysis(request *restful.Request, response *restful.Response) {
+	k8sClient, err := apiHandler.cManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	name := request.PathParameter("pod")
+	namespace := request.PathParameter("namespace")
+	result, err := networkpolicy.GetPodTrafficAnalysis(k8sClient, namespace, name)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 func (apiHandler *APIHandler) handleGetCustomResourceDefinitionList(request *restful.Request, response *restful.Response) {
 	apiextensionsclient, err := apiHandler.cManager.APIExtensionsClient(request)
 	if err != nil {
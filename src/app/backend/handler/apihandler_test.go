@@ -29,6 +29,7 @@ import (
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	"github.com/kubernetes/dashboard/src/app/backend/auth/jwe"
 	"github.com/kubernetes/dashboard/src/app/backend/client"
+	"github.com/kubernetes/dashboard/src/app/backend/cluster"
 	"github.com/kubernetes/dashboard/src/app/backend/settings"
 	"github.com/kubernetes/dashboard/src/app/backend/sync"
 	"github.com/kubernetes/dashboard/src/app/backend/systembanner"
@@ -47,7 +48,8 @@ func TestCreateHTTPAPIHandler(t *testing.T) {
 	authManager := auth.NewAuthManager(cManager, getTokenManager(), authApi.AuthenticationModes{}, true)
 	sManager := settings.NewSettingsManager()
 	sbManager := systembanner.NewSystemBannerManager("Hello world!", "INFO")
-	_, err := CreateHTTPAPIHandler(nil, cManager, authManager, sManager, sbManager)
+	cRegistry := cluster.NewClusterRegistry(nil, nil)
+	_, err := CreateHTTPAPIHandler(nil, cManager, authManager, sManager, sbManager, cRegistry)
 	if err != nil {
 		t.Fatal("CreateHTTPAPIHandler() cannot create HTTP API handler")
 	}
@@ -95,6 +97,10 @@ func TestMapUrlToResource(t *testing.T) {
 			"/api/v1/node",
 			"node",
 		},
+		{
+			"/api/v1/cluster/foo/pod/{namespace}",
+			"pod",
+		},
 	}
 	for _, c := range cases {
 		actual := mapUrlToResource(c.url)
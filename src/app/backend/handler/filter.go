@@ -30,6 +30,7 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/args"
 	authApi "github.com/kubernetes/dashboard/src/app/backend/auth/api"
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	clusterApi "github.com/kubernetes/dashboard/src/app/backend/cluster/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 )
 
@@ -161,19 +162,35 @@ func shouldDoCsrfValidation(req *restful.Request) bool {
 
 	// Validation handlers are idempotent functions, and not actual data
 	// modification operations
-	if strings.HasPrefix(req.SelectedRoutePath(), "/api/v1/appdeployment/validate/") {
+	if strings.HasPrefix(stripClusterPrefix(req.SelectedRoutePath()), "/api/v1/appdeployment/validate/") {
 		return false
 	}
 
 	return true
 }
 
-// mapUrlToResource extracts the resource from the URL path /api/v1/<resource>.
-// Ignores potential subresources.
+// mapUrlToResource extracts the resource from the URL path /api/v1/<resource> or
+// /api/v1/cluster/<cluster>/<resource>. Ignores potential subresources.
 func mapUrlToResource(url string) *string {
-	parts := strings.Split(url, "/")
+	parts := strings.Split(stripClusterPrefix(url), "/")
 	if len(parts) < 3 {
 		return nil
 	}
 	return &parts[3]
 }
+
+// stripClusterPrefix removes /cluster/<cluster> segment from paths served for clusters from
+// cluster registry, so they can be handled the same way as paths of the default cluster.
+func stripClusterPrefix(url string) string {
+	if !strings.HasPrefix(url, clusterApi.ClusterPathPrefix) {
+		return url
+	}
+
+	path := strings.TrimPrefix(url, clusterApi.ClusterPathPrefix)
+	i := strings.Index(path, "/")
+	if i < 0 {
+		return url
+	}
+
+	return "/api/v1" + path[i:]
+}
@@ -15,12 +15,17 @@
 package parser
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/emicklei/go-restful"
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 func parsePaginationPathParameter(request *restful.Request) *dataselect.PaginationQuery {
@@ -79,3 +84,124 @@ func ParseDataSelectPathParameter(request *restful.Request) *dataselect.DataSele
 	metricQuery := parseMetricPathParameter(request)
 	return dataselect.NewDataSelectQuery(paginationQuery, sortQuery, filterQuery, metricQuery)
 }
+
+// ParseDeleteOptions parses propagationPolicy, gracePeriodSeconds and dryRun query parameters of the request and
+// returns DeleteOptions object. Foreground propagation policy is used if it is not set.
+func ParseDeleteOptions(request *restful.Request) (*metaV1.DeleteOptions, error) {
+	policy, err := ParsePropagationPolicy(request)
+	if err != nil {
+		return nil, err
+	}
+	options := &metaV1.DeleteOptions{PropagationPolicy: &policy}
+
+	if param := request.QueryParameter("gracePeriodSeconds"); len(param) > 0 {
+		gracePeriodSeconds, err := strconv.ParseInt(param, 10, 64)
+		if err != nil || gracePeriodSeconds < 0 {
+			return nil, errors.NewBadRequest(fmt.Sprintf("Invalid grace period: %s", param))
+		}
+		options.GracePeriodSeconds = &gracePeriodSeconds
+	}
+
+	dryRun, err := ParseDryRun(request)
+	if err != nil {
+		return nil, err
+	}
+	options.DryRun = dryRun
+
+	return options, nil
+}
+
+// ParseDryRun parses dryRun query parameter of the request. Both "All" and boolean values are accepted. Returned
+// value can be used directly as dry run field of create, update, patch and delete options.
+func ParseDryRun(request *restful.Request) ([]string, error) {
+	param := request.QueryParameter("dryRun")
+	if len(param) == 0 {
+		return nil, nil
+	}
+
+	if strings.EqualFold(param, metaV1.DryRunAll) {
+		return []string{metaV1.DryRunAll}, nil
+	}
+
+	dryRun, err := strconv.ParseBool(param)
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Invalid dry run flag: %s", param))
+	}
+	if dryRun {
+		return []string{metaV1.DryRunAll}, nil
+	}
+
+	return nil, nil
+}
+
+// PatchTypes maps values of the patchType query parameter to supported patch types.
+var PatchTypes = map[string]types.PatchType{
+	"json":      types.JSONPatchType,
+	"merge":     types.MergePatchType,
+	"strategic": types.StrategicMergePatchType,
+	"apply":     types.ApplyPatchType,
+}
+
+// ParsePatchType parses patchType query parameter of the request. If it is not set, patch type is taken from the
+// content type of the request, the same way as API server does. Strategic merge patch is returned otherwise.
+func ParsePatchType(request *restful.Request) (types.PatchType, error) {
+	param := request.QueryParameter("patchType")
+	if len(param) == 0 {
+		contentType := strings.TrimSpace(strings.Split(request.HeaderParameter("Content-Type"), ";")[0])
+		for _, patchType := range PatchTypes {
+			if contentType == string(patchType) {
+				return patchType, nil
+			}
+		}
+		return types.StrategicMergePatchType, nil
+	}
+
+	if patchType, ok := PatchTypes[strings.ToLower(param)]; ok {
+		return patchType, nil
+	}
+
+	return "", errors.NewBadRequest(fmt.Sprintf("Invalid patch type: %s", param))
+}
+
+// ParsePatchOptions parses patch options from the dryRun and force query parameters of the request. Changes are
+// always made with the dashboard field manager. Force flag is allowed only for server-side apply.
+func ParsePatchOptions(request *restful.Request, patchType types.PatchType) (*metaV1.PatchOptions, error) {
+	dryRun, err := ParseDryRun(request)
+	if err != nil {
+		return nil, err
+	}
+	options := &metaV1.PatchOptions{DryRun: dryRun, FieldManager: api.FieldManager}
+
+	if param := request.QueryParameter("force"); len(param) > 0 {
+		force, err := strconv.ParseBool(param)
+		if err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("Invalid force flag: %s", param))
+		}
+		if force && patchType != types.ApplyPatchType {
+			return nil, errors.NewBadRequest("Force flag is allowed only for server-side apply")
+		}
+		if patchType == types.ApplyPatchType {
+			options.Force = &force
+		}
+	}
+
+	return options, nil
+}
+
+// ParsePropagationPolicy parses propagationPolicy query parameter of the request. Foreground propagation policy is
+// returned if it is not set.
+func ParsePropagationPolicy(request *restful.Request) (metaV1.DeletionPropagation, error) {
+	param := request.QueryParameter("propagationPolicy")
+	if len(param) == 0 {
+		return metaV1.DeletePropagationForeground, nil
+	}
+
+	for _, policy := range []metaV1.DeletionPropagation{metaV1.DeletePropagationForeground,
+		metaV1.DeletePropagationBackground, metaV1.DeletePropagationOrphan} {
+		if strings.EqualFold(param, string(policy)) {
+			return policy, nil
+		}
+	}
+
+	return "", errors.NewBadRequest(fmt.Sprintf("Invalid propagation policy: %s", param))
+}
@@ -24,6 +24,7 @@ type IntegrationID string
 const (
 	HeapsterIntegrationID IntegrationID = "heapster"
 	SidecarIntegrationID  IntegrationID = "sidecar"
+	GitIntegrationID      IntegrationID = "git"
 )
 
 // Integration represents application integrated into the dashboard. Every application
@@ -0,0 +1,214 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	integrationapi "github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	gogit "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// manifestExtensions are extensions of files that are read as manifests.
+var manifestExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// GitClient reads manifests from a Git working copy, e.g. a repository checked out to a volume mounted to Dashboard
+// pod. Only the local repository is read, changes are never fetched from remotes.
+type GitClient interface {
+	integrationapi.Integration
+
+	// Manifests returns objects defined in manifests of the checked out commit together with non-critical errors
+	// of files that could not be parsed.
+	Manifests() (*Revision, []Manifest, []error, error)
+}
+
+// Revision describes the checked out commit.
+type Revision struct {
+	// Hash of the commit.
+	Commit string `json:"commit"`
+
+	// Name of the checked out branch. Empty if HEAD is detached.
+	Branch string `json:"branch"`
+
+	// First line of the commit message.
+	Message string `json:"message"`
+
+	Time metaV1.Time `json:"time"`
+}
+
+// Manifest is an object defined in a file of the repository.
+type Manifest struct {
+	// Path of the file relative to the repository root.
+	Path string
+
+	Object *unstructured.Unstructured
+}
+
+// Git client implements GitClient and Integration interfaces.
+type gitClient struct {
+	// Directory of the working copy.
+	dir string
+
+	// Directory of manifests relative to the repository root. If empty, the whole repository is read.
+	path string
+}
+
+// HealthCheck implements integration app interface. See Integration interface for more information.
+func (self gitClient) HealthCheck() error {
+	_, _, err := self.openHead()
+	return err
+}
+
+// ID implements integration app interface. See Integration interface for more information.
+func (self gitClient) ID() integrationapi.IntegrationID {
+	return integrationapi.GitIntegrationID
+}
+
+// Manifests implements git client interface. See GitClient for more information.
+func (self gitClient) Manifests() (*Revision, []Manifest, []error, error) {
+	revision, commit, err := self.openHead()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if len(self.path) > 0 {
+		if tree, err = tree.Tree(strings.Trim(self.path, "/")); err != nil {
+			return nil, nil, nil, fmt.Errorf("manifest directory %s not found: %s", self.path, err.Error())
+		}
+	}
+
+	manifests := make([]Manifest, 0)
+	nonCriticalErrors := make([]error, 0)
+	err = tree.Files().ForEach(func(file *object.File) error {
+		if !manifestExtensions[strings.ToLower(path.Ext(file.Name))] {
+			return nil
+		}
+
+		filePath := path.Join(strings.Trim(self.path, "/"), file.Name)
+		reader, err := file.Reader()
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+
+		objects, err := decodeManifests(reader)
+		if err != nil {
+			nonCriticalErrors = append(nonCriticalErrors, fmt.Errorf("%s: %s", filePath, err.Error()))
+			return nil
+		}
+
+		for _, object := range objects {
+			manifests = append(manifests, Manifest{Path: filePath, Object: object})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	sort.SliceStable(manifests, func(i, j int) bool { return manifests[i].Path < manifests[j].Path })
+	return revision, manifests, nonCriticalErrors, nil
+}
+
+// openHead opens the repository and returns its checked out commit.
+func (self gitClient) openHead() (*Revision, *object.Commit, error) {
+	if len(self.dir) == 0 {
+		return nil, nil, errors.New("Git working copy not configured")
+	}
+
+	repository, err := gogit.PlainOpen(self.dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open Git repository %s: %s", self.dir, err.Error())
+	}
+
+	head, err := repository.Head()
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot resolve HEAD of Git repository %s: %s", self.dir, err.Error())
+	}
+
+	commit, err := repository.CommitObject(head.Hash())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revision := &Revision{
+		Commit:  commit.Hash.String(),
+		Message: strings.SplitN(strings.TrimSpace(commit.Message), "\n", 2)[0],
+		Time:    metaV1.NewTime(commit.Committer.When),
+	}
+	if head.Name().IsBranch() {
+		revision.Branch = head.Name().Short()
+	}
+
+	return revision, commit, nil
+}
+
+// decodeManifests decodes all objects from a multi-document YAML or JSON file. Documents that are not Kubernetes
+// objects, e.g. kustomization files or chart values, are skipped. Items of lists are returned as separate objects.
+func decodeManifests(reader io.Reader) ([]*unstructured.Unstructured, error) {
+	result := make([]*unstructured.Unstructured, 0)
+	decoder := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+	for {
+		data := map[string]interface{}{}
+		if err := decoder.Decode(&data); err != nil {
+			if err == io.EOF {
+				return result, nil
+			}
+			return nil, err
+		}
+
+		object := &unstructured.Unstructured{Object: data}
+		if object.IsList() {
+			list, err := object.ToList()
+			if err != nil {
+				return nil, err
+			}
+			for i := range list.Items {
+				if isManifest(&list.Items[i]) {
+					result = append(result, &list.Items[i])
+				}
+			}
+			continue
+		}
+
+		if isManifest(object) {
+			result = append(result, object)
+		}
+	}
+}
+
+func isManifest(object *unstructured.Unstructured) bool {
+	return len(object.GetAPIVersion()) > 0 && len(object.GetKind()) > 0 && len(object.GetName()) > 0
+}
+
+// NewGitClient creates git client reading manifests from the working copy in the given directory. If path is not
+// empty, only manifests in that directory of the repository are read.
+func NewGitClient(dir, path string) GitClient {
+	return gitClient{dir: dir, path: path}
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	gogit "gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// newRepository creates a Git repository in a temporary directory with a single commit of the given files.
+func newRepository(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "manifests")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repository, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := worktree.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	signature := &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()}
+	if _, err := worktree.Commit("Add manifests\n\nDetails.", &gogit.CommitOptions{Author: signature}); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+var testManifests = map[string]string{
+	"apps/web.yaml": `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: ns
+spec:
+  replicas: 2
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: ns
+`,
+	"apps/list.json": `{"apiVersion": "v1", "kind": "List", "items": [
+  {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config", "namespace": "ns"}}
+]}`,
+	"apps/kustomization.yaml": "resources:\n- web.yaml\n",
+	"apps/invalid.yaml":       "kind: [\n",
+	"README.md":               "# Manifests\n",
+	"other/config.yml":        "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: other\n",
+}
+
+func TestGitClientManifests(t *testing.T) {
+	dir := newRepository(t, testManifests)
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		path     string
+		expected []string
+	}{
+		{"", []string{"apps/list.json:config", "apps/web.yaml:web", "apps/web.yaml:web", "other/config.yml:other"}},
+		{"/apps/", []string{"apps/list.json:config", "apps/web.yaml:web", "apps/web.yaml:web"}},
+	}
+
+	for _, c := range cases {
+		client := NewGitClient(dir, c.path)
+		if err := client.HealthCheck(); err != nil {
+			t.Fatalf("HealthCheck(): unexpected error: %s", err.Error())
+		}
+
+		revision, manifests, nonCriticalErrors, err := client.Manifests()
+		if err != nil {
+			t.Fatalf("Manifests(): unexpected error: %s", err.Error())
+		}
+
+		if len(revision.Commit) != 40 || revision.Branch != "master" || revision.Message != "Add manifests" {
+			t.Errorf("Manifests() returned revision %v, expected latest commit on master", revision)
+		}
+
+		actual := make([]string, 0)
+		for _, manifest := range manifests {
+			actual = append(actual, manifest.Path+":"+manifest.Object.GetName())
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("Manifests() for path %q returned %v, expected %v", c.path, actual, c.expected)
+		}
+
+		if len(nonCriticalErrors) != 1 {
+			t.Errorf("Manifests() returned errors %v, expected error of invalid.yaml", nonCriticalErrors)
+		}
+	}
+}
+
+func TestGitClientHealthCheck(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, dir := range []string{"", dir} {
+		if err := NewGitClient(dir, "").HealthCheck(); err == nil {
+			t.Errorf("HealthCheck() of %q: expected error", dir)
+		}
+	}
+
+	repository := newRepository(t, testManifests)
+	defer os.RemoveAll(repository)
+	if _, _, _, err := NewGitClient(repository, "missing").Manifests(); err == nil {
+		t.Error("Manifests() of missing directory: expected error")
+	}
+}
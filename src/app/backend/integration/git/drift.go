@@ -0,0 +1,231 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/drift"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/export"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+)
+
+// ObjectStatus describes how the live object differs from its manifest.
+type ObjectStatus string
+
+const (
+	// ObjectStatusInSync means that the live object matches its manifest.
+	ObjectStatusInSync ObjectStatus = "inSync"
+
+	// ObjectStatusDiffers means that some fields of the manifest differ from the live object.
+	ObjectStatusDiffers ObjectStatus = "differs"
+
+	// ObjectStatusMissing means that the object from the manifest does not exist.
+	ObjectStatusMissing ObjectStatus = "missing"
+
+	// ObjectStatusExtra means that the live object has no manifest.
+	ObjectStatusExtra ObjectStatus = "extra"
+)
+
+// ObjectDrift compares a manifest with the live object.
+type ObjectDrift struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+
+	// Path of the manifest file. Empty for extra objects.
+	Path string `json:"path"`
+
+	Status ObjectStatus `json:"status"`
+
+	// Fields of the manifest that differ from the live object.
+	Fields []drift.FieldDrift `json:"fields"`
+}
+
+// NamespaceDrift compares manifests from the Git working copy with live objects of a namespace. Only objects of
+// kinds that have manifests in the repository are compared.
+type NamespaceDrift struct {
+	Namespace string   `json:"namespace"`
+	Revision  Revision `json:"revision"`
+
+	// Objects sorted by kind and name.
+	Objects []ObjectDrift `json:"objects"`
+
+	// List of non-critical errors, e.g. manifests that could not be parsed or kinds that could not be listed.
+	Errors []error `json:"errors"`
+}
+
+// objectKey identifies an object regardless of API version, which can differ between the manifest and the server.
+type objectKey struct {
+	Group string
+	Kind  string
+	Name  string
+}
+
+// GetNamespaceDrift compares manifests of the namespace with its live objects. Manifests without namespace belong to
+// the default namespace. Manifests of cluster scoped objects are ignored.
+func GetNamespaceDrift(client GitClient, cfg *rest.Config, namespace string) (*NamespaceDrift, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return getNamespaceDrift(client, discoveryClient, dynamicClient, namespace)
+}
+
+func getNamespaceDrift(client GitClient, discoveryClient discovery.DiscoveryInterface,
+	dynamicClient dynamic.Interface, namespace string) (*NamespaceDrift, error) {
+	revision, manifests, nonCriticalErrors, err := client.Manifests()
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Comparing %s namespace with manifests of commit %s\n", namespace, revision.Commit)
+
+	result := &NamespaceDrift{
+		Namespace: namespace,
+		Revision:  *revision,
+		Objects:   make([]ObjectDrift, 0),
+		Errors:    nonCriticalErrors,
+	}
+
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	resources := make(map[schema.GroupKind]schema.GroupVersionResource)
+	desired := make(map[objectKey]Manifest)
+	for _, manifest := range manifests {
+		gvk := manifest.Object.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %s", manifest.Path, err.Error()))
+			continue
+		}
+
+		if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+			continue
+		}
+		resources[gvk.GroupKind()] = mapping.Resource
+
+		manifestNamespace := manifest.Object.GetNamespace()
+		if len(manifestNamespace) == 0 {
+			manifestNamespace = metaV1.NamespaceDefault
+		}
+		if manifestNamespace != namespace {
+			continue
+		}
+
+		key := objectKey{Group: gvk.Group, Kind: gvk.Kind, Name: manifest.Object.GetName()}
+		if existing, ok := desired[key]; ok {
+			result.Errors = append(result.Errors, fmt.Errorf("%s: %s %s is already defined in %s", manifest.Path,
+				gvk.Kind, key.Name, existing.Path))
+			continue
+		}
+		desired[key] = manifest
+	}
+
+	live := make(map[objectKey]*unstructured.Unstructured)
+	for groupKind, resource := range resources {
+		list, err := dynamicClient.Resource(resource).Namespace(namespace).List(metaV1.ListOptions{})
+		nonCriticalErrors, criticalError := errors.AppendError(err, result.Errors)
+		if criticalError != nil {
+			return nil, criticalError
+		}
+		result.Errors = nonCriticalErrors
+		if err != nil {
+			continue
+		}
+
+		for i := range list.Items {
+			// Objects created by controllers, e.g. pods of deployments, are not expected to have manifests.
+			if !export.IsGenerated(&list.Items[i]) {
+				key := objectKey{Group: groupKind.Group, Kind: groupKind.Kind, Name: list.Items[i].GetName()}
+				live[key] = &list.Items[i]
+			}
+		}
+	}
+
+	for key, manifest := range desired {
+		objectDrift, err := compare(manifest, live[key], namespace)
+		if err != nil {
+			return nil, err
+		}
+		result.Objects = append(result.Objects, *objectDrift)
+	}
+
+	for key, object := range live {
+		if _, ok := desired[key]; !ok {
+			result.Objects = append(result.Objects, ObjectDrift{
+				APIVersion: object.GetAPIVersion(),
+				Kind:       object.GetKind(),
+				Namespace:  namespace,
+				Name:       object.GetName(),
+				Status:     ObjectStatusExtra,
+				Fields:     make([]drift.FieldDrift, 0),
+			})
+		}
+	}
+
+	sort.SliceStable(result.Objects, func(i, j int) bool {
+		if result.Objects[i].Kind != result.Objects[j].Kind {
+			return result.Objects[i].Kind < result.Objects[j].Kind
+		}
+		return result.Objects[i].Name < result.Objects[j].Name
+	})
+
+	return result, nil
+}
+
+// compare compares the manifest with the live object, which is nil if it does not exist.
+func compare(manifest Manifest, live *unstructured.Unstructured, namespace string) (*ObjectDrift, error) {
+	result := &ObjectDrift{
+		APIVersion: manifest.Object.GetAPIVersion(),
+		Kind:       manifest.Object.GetKind(),
+		Namespace:  namespace,
+		Name:       manifest.Object.GetName(),
+		Path:       manifest.Path,
+		Status:     ObjectStatusMissing,
+		Fields:     make([]drift.FieldDrift, 0),
+	}
+	if live == nil {
+		return result, nil
+	}
+
+	fields, err := drift.CompareObjects(manifest.Object, live)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Fields = fields
+	result.Status = ObjectStatusInSync
+	if len(fields) > 0 {
+		result.Status = ObjectStatusDiffers
+	}
+	return result, nil
+}
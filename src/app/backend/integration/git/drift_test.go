@@ -0,0 +1,101 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newObject(apiVersion, kind, name string, content map[string]interface{}) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: content}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetNamespace("ns")
+	object.SetName(name)
+	return object
+}
+
+func TestGetNamespaceDrift(t *testing.T) {
+	dir := newRepository(t, testManifests)
+	defer os.RemoveAll(dir)
+
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metaV1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "services", Kind: "Service", Namespaced: true, Verbs: metaV1.Verbs{"list"}},
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metaV1.Verbs{"list"}},
+				{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: metaV1.Verbs{"list"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: metaV1.Verbs{"list"}},
+			},
+		},
+	}
+
+	controller := true
+	generated := newObject("v1", "ConfigMap", "generated", map[string]interface{}{})
+	generated.SetOwnerReferences([]metaV1.OwnerReference{{Name: "owner", Controller: &controller}})
+	dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(),
+		newObject("apps/v1", "Deployment", "web", map[string]interface{}{
+			"spec": map[string]interface{}{"replicas": int64(3), "paused": false},
+		}),
+		newObject("v1", "Service", "web", map[string]interface{}{}),
+		newObject("v1", "ConfigMap", "extra", map[string]interface{}{}),
+		newObject("v1", "Pod", "unrelated", map[string]interface{}{}),
+		generated,
+	)
+
+	result, err := getNamespaceDrift(NewGitClient(dir, "apps"), client.Discovery(), dynamicClient, "ns")
+	if err != nil {
+		t.Fatalf("getNamespaceDrift(): unexpected error: %s", err.Error())
+	}
+
+	statuses := make(map[string]ObjectStatus)
+	for _, object := range result.Objects {
+		statuses[object.Kind+"/"+object.Name] = object.Status
+	}
+	expected := map[string]ObjectStatus{
+		"ConfigMap/config": ObjectStatusMissing,
+		"ConfigMap/extra":  ObjectStatusExtra,
+		"Deployment/web":   ObjectStatusDiffers,
+		"Service/web":      ObjectStatusInSync,
+	}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("getNamespaceDrift() returned statuses %v, expected %v", statuses, expected)
+	}
+
+	fields := result.Objects[2].Fields
+	if len(fields) != 1 || fields[0].Path != "spec.replicas" || fields[0].Desired != float64(2) ||
+		fields[0].Live != float64(3) {
+		t.Errorf("getNamespaceDrift() returned fields %v for Deployment/web, expected changed spec.replicas", fields)
+	}
+
+	if len(result.Errors) != 1 {
+		t.Errorf("getNamespaceDrift() returned errors %v, expected error of invalid.yaml", result.Errors)
+	}
+}
@@ -18,12 +18,16 @@ import (
 	"net/http"
 
 	restful "github.com/emicklei/go-restful"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/git"
 )
 
 // IntegrationHandler manages all endpoints related to integrated applications, such as state.
 type IntegrationHandler struct {
-	manager IntegrationManager
+	manager       IntegrationManager
+	clientManager clientapi.ClientManager
 }
 
 // Install creates new endpoints for integrations. All information that any integration would want
@@ -37,6 +41,10 @@ func (self IntegrationHandler) Install(ws *restful.WebService) {
 		ws.GET("/integration/{name}/state").
 			To(self.handleGetState).
 			Writes(api.IntegrationState{}))
+	ws.Route(
+		ws.GET("/integration/git/drift/{namespace}").
+			To(self.handleGetGitDrift).
+			Writes(git.NamespaceDrift{}))
 }
 
 func (self IntegrationHandler) handleGetState(request *restful.Request, response *restful.Response) {
@@ -50,7 +58,28 @@ func (self IntegrationHandler) handleGetState(request *restful.Request, response
 	response.WriteHeaderAndEntity(http.StatusOK, state)
 }
 
+func (self IntegrationHandler) handleGetGitDrift(request *restful.Request, response *restful.Response) {
+	if self.manager.Git() == nil {
+		errors.HandleInternalError(response, errors.NewNotFound("Git integration is not configured"))
+		return
+	}
+
+	config, err := self.clientManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	namespace := request.PathParameter("namespace")
+	result, err := git.GetNamespaceDrift(self.manager.Git(), config, namespace)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
 // NewIntegrationHandler creates IntegrationHandler.
-func NewIntegrationHandler(manager IntegrationManager) IntegrationHandler {
-	return IntegrationHandler{manager: manager}
+func NewIntegrationHandler(manager IntegrationManager, clientManager clientapi.ClientManager) IntegrationHandler {
+	return IntegrationHandler{manager: manager, clientManager: clientManager}
 }
@@ -21,7 +21,7 @@ import (
 )
 
 func TestIntegrationHandler_Install(t *testing.T) {
-	iHandler := NewIntegrationHandler(nil)
+	iHandler := NewIntegrationHandler(nil, nil)
 	ws := new(restful.WebService)
 	iHandler.Install(ws)
 
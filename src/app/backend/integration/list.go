@@ -31,6 +31,9 @@ func (self *integrationManager) List() []api.Integration {
 
 	// Append all types of integrations
 	result = append(result, self.Metric().List()...)
+	if self.Git() != nil {
+		result = append(result, self.Git())
+	}
 
 	return result
 }
@@ -19,6 +19,7 @@ import (
 
 	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/api"
+	"github.com/kubernetes/dashboard/src/app/backend/integration/git"
 	"github.com/kubernetes/dashboard/src/app/backend/integration/metric"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,11 +32,16 @@ type IntegrationManager interface {
 	GetState(id api.IntegrationID) (*api.IntegrationState, error)
 	// Metric returns metric manager that is responsible for management of metric integrations.
 	Metric() metric.MetricManager
+	// Git returns client of Git working copy with manifests or nil if it is not configured.
+	Git() git.GitClient
+	// ConfigureGit configures git integration that reads manifests from the working copy in given directory.
+	ConfigureGit(dir, path string) IntegrationManager
 }
 
 // Implements IntegrationManager interface
 type integrationManager struct {
 	metric metric.MetricManager
+	git    git.GitClient
 }
 
 // Metric implements integration manager interface. See IntegrationManager for more information.
@@ -43,6 +49,17 @@ func (self *integrationManager) Metric() metric.MetricManager {
 	return self.metric
 }
 
+// Git implements integration manager interface. See IntegrationManager for more information.
+func (self *integrationManager) Git() git.GitClient {
+	return self.git
+}
+
+// ConfigureGit implements integration manager interface. See IntegrationManager for more information.
+func (self *integrationManager) ConfigureGit(dir, path string) IntegrationManager {
+	self.git = git.NewGitClient(dir, path)
+	return self
+}
+
 // GetState implements integration manager interface. See IntegrationManager for more information.
 func (self *integrationManager) GetState(id api.IntegrationID) (*api.IntegrationState, error) {
 	for _, i := range self.List() {
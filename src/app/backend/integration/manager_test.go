@@ -86,3 +86,20 @@ func TestIntegrationManager_Metric(t *testing.T) {
 		t.Error("Failed to get metric manager.")
 	}
 }
+
+func TestIntegrationManager_ConfigureGit(t *testing.T) {
+	iManager := NewIntegrationManager(nil)
+	if iManager.Git() != nil || len(iManager.List()) != 0 {
+		t.Error("Expected git integration not to be configured by default.")
+	}
+
+	iManager.ConfigureGit("/nonexistent", "")
+	state, err := iManager.GetState(api.GitIntegrationID)
+	if err != nil {
+		t.Fatalf("Failed to get state of git integration: %s", err.Error())
+	}
+
+	if state.Connected || state.Error == nil {
+		t.Errorf("Expected git integration with missing working copy to be disconnected, but got %v.", state)
+	}
+}
@@ -22,7 +22,10 @@ import (
 	batch2 "k8s.io/api/batch/v1beta1"
 	v1 "k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
+	networking "k8s.io/api/networking/v1"
+	policy "k8s.io/api/policy/v1beta1"
 	rbac "k8s.io/api/rbac/v1"
+	scheduling "k8s.io/api/scheduling/v1"
 	storage "k8s.io/api/storage/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -119,6 +122,15 @@ type ResourceChannels struct {
 
 	// List and error channels to ClusterRoleBindings
 	ClusterRoleBindingList ClusterRoleBindingListChannel
+
+	// List and error channels to NetworkPolicies
+	NetworkPolicyList NetworkPolicyListChannel
+
+	// List and error channels to PodDisruptionBudgets
+	PodDisruptionBudgetList PodDisruptionBudgetListChannel
+
+	// List and error channels to PriorityClasses
+	PriorityClassList PriorityClassListChannel
 }
 
 // ServiceListChannel is a list and error channels to Services.
@@ -939,3 +951,80 @@ func GetStorageClassListChannel(client client.Interface, numReads int) StorageCl
 
 	return channel
 }
+
+// NetworkPolicyListChannel is a list and error channels to network policies.
+type NetworkPolicyListChannel struct {
+	List  chan *networking.NetworkPolicyList
+	Error chan error
+}
+
+// GetNetworkPolicyListChannel returns a pair of channels to a network policy list and errors that both must be read
+// numReads times.
+func GetNetworkPolicyListChannel(client client.Interface, nsQuery *NamespaceQuery,
+	numReads int) NetworkPolicyListChannel {
+	channel := NetworkPolicyListChannel{
+		List:  make(chan *networking.NetworkPolicyList, numReads),
+		Error: make(chan error, numReads),
+	}
+
+	go func() {
+		list, err := client.NetworkingV1().NetworkPolicies(nsQuery.ToRequestParam()).List(api.ListEverything)
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
+// PodDisruptionBudgetListChannel is a list and error channels to pod disruption budgets.
+type PodDisruptionBudgetListChannel struct {
+	List  chan *policy.PodDisruptionBudgetList
+	Error chan error
+}
+
+// GetPodDisruptionBudgetListChannel returns a pair of channels to a pod disruption budget list and errors that both
+// must be read numReads times.
+func GetPodDisruptionBudgetListChannel(client client.Interface, nsQuery *NamespaceQuery,
+	numReads int) PodDisruptionBudgetListChannel {
+	channel := PodDisruptionBudgetListChannel{
+		List:  make(chan *policy.PodDisruptionBudgetList, numReads),
+		Error: make(chan error, numReads),
+	}
+
+	go func() {
+		list, err := client.PolicyV1beta1().PodDisruptionBudgets(nsQuery.ToRequestParam()).List(api.ListEverything)
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
+
+// PriorityClassListChannel is a list and error channels to priority classes.
+type PriorityClassListChannel struct {
+	List  chan *scheduling.PriorityClassList
+	Error chan error
+}
+
+// GetPriorityClassListChannel returns a pair of channels to a priority class list and errors that both must be read
+// numReads times.
+func GetPriorityClassListChannel(client client.Interface, numReads int) PriorityClassListChannel {
+	channel := PriorityClassListChannel{
+		List:  make(chan *scheduling.PriorityClassList, numReads),
+		Error: make(chan error, numReads),
+	}
+
+	go func() {
+		list, err := client.SchedulingV1().PriorityClasses().List(api.ListEverything)
+		for i := 0; i < numReads; i++ {
+			channel.List <- list
+			channel.Error <- err
+		}
+	}()
+
+	return channel
+}
@@ -0,0 +1,225 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compare
+
+import (
+	"fmt"
+	"strconv"
+
+	apps "k8s.io/api/apps/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diff"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/export"
+)
+
+// revisionAnnotation is set by the deployment controller on replica sets to the revision of the deployment they
+// were created for.
+const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+// ObjectReference identifies the object, or its revision, on one side of the comparison.
+type ObjectReference struct {
+	// Kind of the object, the same as used by the raw resource endpoints, e.g. "deployment".
+	Kind string `json:"kind"`
+
+	// Namespace of the object. Empty for cluster scoped objects.
+	Namespace string `json:"namespace"`
+
+	Name string `json:"name"`
+
+	// Revision of the pod template taken from the history of deployments, stateful sets and daemon sets. Zero means
+	// the current state of the object.
+	Revision int64 `json:"revision"`
+}
+
+// String returns the reference in the form used as the file name in the diff.
+func (ref ObjectReference) String() string {
+	result := ref.Kind
+	if len(ref.Namespace) > 0 {
+		result += "/" + ref.Namespace
+	}
+	result += "/" + ref.Name
+	if ref.Revision > 0 {
+		result += "@" + strconv.FormatInt(ref.Revision, 10)
+	}
+	return result
+}
+
+// ComparisonSpec is a specification of the comparison of two objects.
+type ComparisonSpec struct {
+	From ObjectReference `json:"from"`
+	To   ObjectReference `json:"to"`
+}
+
+// Comparison is a result of the comparison of two objects.
+type Comparison struct {
+	From ObjectReference `json:"from"`
+	To   ObjectReference `json:"to"`
+
+	// Unified diff between normalized YAML of both objects. Status, server set metadata, defaulted fields and the
+	// namespace are left out, so that objects from different namespaces can be compared. Empty if objects are equal.
+	Diff string `json:"diff"`
+}
+
+// CompareObjects compares two objects, or revisions of objects, given by the spec.
+func CompareObjects(verber clientapi.ResourceVerber, client kubernetes.Interface,
+	spec *ComparisonSpec) (*Comparison, error) {
+	from, err := getNormalizedObject(verber, client, spec.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := getNormalizedObject(verber, client, spec.To)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := diff.Objects(spec.From.String(), spec.To.String(), from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Comparison{From: spec.From, To: spec.To, Diff: result}, nil
+}
+
+// getNormalizedObject returns JSON of the referenced object without fields irrelevant for the comparison.
+func getNormalizedObject(verber clientapi.ResourceVerber, client kubernetes.Interface,
+	ref ObjectReference) ([]byte, error) {
+	if len(ref.Kind) == 0 || len(ref.Name) == 0 {
+		return nil, errors.NewBadRequest("kind and name of compared objects are required")
+	}
+	if ref.Revision < 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("invalid revision %d of %s", ref.Revision, ref))
+	}
+
+	result, err := verber.Get(ref.Kind, len(ref.Namespace) > 0, ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	unknown, ok := result.(*runtime.Unknown)
+	if !ok {
+		return nil, errors.NewUnexpectedObject(result)
+	}
+
+	object := &unstructured.Unstructured{}
+	if err := object.UnmarshalJSON(unknown.Raw); err != nil {
+		return nil, err
+	}
+
+	if ref.Revision > 0 {
+		template, err := getRevisionTemplate(client, object, ref)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := unstructured.SetNestedField(object.Object, template, "spec", "template"); err != nil {
+			return nil, err
+		}
+	}
+
+	object = export.Clean(object)
+	unstructured.RemoveNestedField(object.Object, "metadata", "namespace")
+	return object.MarshalJSON()
+}
+
+// getRevisionTemplate returns the pod template of the object at the given revision. Only the pod template is kept in
+// the revision history, other fields of the revision are the same as in the current object.
+func getRevisionTemplate(client kubernetes.Interface, object *unstructured.Unstructured,
+	ref ObjectReference) (map[string]interface{}, error) {
+	switch ref.Kind {
+	case api.ResourceKindDeployment:
+		return getReplicaSetTemplate(client, object, ref)
+	case api.ResourceKindStatefulSet, api.ResourceKindDaemonSet:
+		return getControllerRevisionTemplate(client, object, ref)
+	}
+
+	return nil, errors.NewBadRequest(fmt.Sprintf("revisions are supported only for %s, %s and %s, not for %s",
+		api.ResourceKindDeployment, api.ResourceKindStatefulSet, api.ResourceKindDaemonSet, ref.Kind))
+}
+
+// getReplicaSetTemplate returns the pod template of the replica set created for the revision of the deployment.
+func getReplicaSetTemplate(client kubernetes.Interface, object *unstructured.Unstructured,
+	ref ObjectReference) (map[string]interface{}, error) {
+	replicaSets, err := client.AppsV1().ReplicaSets(ref.Namespace).List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	revision := strconv.FormatInt(ref.Revision, 10)
+	for _, replicaSet := range replicaSets.Items {
+		if !isControlledBy(&replicaSet, object.GetUID()) || replicaSet.Annotations[revisionAnnotation] != revision {
+			continue
+		}
+
+		template := replicaSet.Spec.Template.DeepCopy()
+		delete(template.Labels, apps.DefaultDeploymentUniqueLabelKey)
+		return runtime.DefaultUnstructuredConverter.ToUnstructured(template)
+	}
+
+	return nil, newRevisionNotFound(ref)
+}
+
+// getControllerRevisionTemplate returns the pod template stored in the controller revision of the stateful set or
+// daemon set. Data of the revision is a patch replacing the pod template of the object.
+func getControllerRevisionTemplate(client kubernetes.Interface, object *unstructured.Unstructured,
+	ref ObjectReference) (map[string]interface{}, error) {
+	revisions, err := client.AppsV1().ControllerRevisions(ref.Namespace).List(metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, revision := range revisions.Items {
+		if !isControlledBy(&revision, object.GetUID()) || revision.Revision != ref.Revision {
+			continue
+		}
+
+		// Numbers are decoded as int64 the same way as in unstructured objects.
+		data := map[string]interface{}{}
+		if err := json.Unmarshal(revision.Data.Raw, &data); err != nil {
+			return nil, err
+		}
+
+		template, found, err := unstructured.NestedMap(data, "spec", "template")
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, errors.NewInternal(fmt.Sprintf("controller revision %s has no pod template", revision.Name))
+		}
+
+		delete(template, "$patch")
+		return template, nil
+	}
+
+	return nil, newRevisionNotFound(ref)
+}
+
+func isControlledBy(object metaV1.Object, uid types.UID) bool {
+	owner := metaV1.GetControllerOf(object)
+	return owner != nil && owner.UID == uid
+}
+
+func newRevisionNotFound(ref ObjectReference) error {
+	return errors.NewNotFound(fmt.Sprintf("revision %d of %s %s not found", ref.Revision, ref.Kind, ref.Name))
+}
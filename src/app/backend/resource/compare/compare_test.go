@@ -0,0 +1,302 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compare
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+type fakeVerber struct {
+	clientapi.ResourceVerber
+	objects map[string]string
+}
+
+func (verber *fakeVerber) Get(kind string, namespaceSet bool, namespace string, name string) (runtime.Object, error) {
+	object, ok := verber.objects[kind+"/"+namespace+"/"+name]
+	if !ok {
+		return nil, errors.NewNotFound(name)
+	}
+
+	data, err := yaml.YAMLToJSON([]byte(object))
+	if err != nil {
+		return nil, err
+	}
+	return &runtime.Unknown{Raw: data}, nil
+}
+
+const deployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: %s
+  uid: %s
+  resourceVersion: "10"
+  annotations:
+    deployment.kubernetes.io/revision: "2"
+spec:
+  replicas: %d
+  revisionHistoryLimit: 10
+  template:
+    metadata:
+      creationTimestamp: null
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: %s
+        imagePullPolicy: IfNotPresent
+      restartPolicy: Always
+      terminationGracePeriodSeconds: 30
+status:
+  replicas: 2
+`
+
+const statefulSet = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+  namespace: staging
+  uid: db-uid
+spec:
+  serviceName: db
+  template:
+    metadata:
+      labels:
+        app: db
+    spec:
+      containers:
+      - name: db
+        image: postgres:12
+`
+
+func newDeployment(namespace, uid string, replicas int, image string) string {
+	return fmt.Sprintf(deployment, namespace, uid, replicas, image)
+}
+
+func newOwnerReference(kind, name, uid string) []metaV1.OwnerReference {
+	controller := true
+	return []metaV1.OwnerReference{
+		{APIVersion: "apps/v1", Kind: kind, Name: name, UID: types.UID(uid), Controller: &controller},
+	}
+}
+
+func newPodTemplate(app, image string, labels map[string]string) v1.PodTemplateSpec {
+	gracePeriod := int64(30)
+	labels["app"] = app
+	return v1.PodTemplateSpec{
+		ObjectMeta: metaV1.ObjectMeta{Labels: labels},
+		Spec: v1.PodSpec{
+			Containers:                    []v1.Container{{Name: app, Image: image, ImagePullPolicy: v1.PullIfNotPresent}},
+			RestartPolicy:                 v1.RestartPolicyAlways,
+			TerminationGracePeriodSeconds: &gracePeriod,
+		},
+	}
+}
+
+func TestCompareObjects(t *testing.T) {
+	verber := &fakeVerber{objects: map[string]string{
+		"deployment/staging/web":    newDeployment("staging", "staging-uid", 2, "nginx:1.17"),
+		"deployment/production/web": newDeployment("production", "production-uid", 4, "nginx:1.16"),
+		"deployment/qa/web":         newDeployment("qa", "qa-uid", 2, "nginx:1.17"),
+		"statefulset/staging/db":    statefulSet,
+		"service/staging/web":       "{apiVersion: v1, kind: Service, metadata: {name: web, namespace: staging}}",
+	}}
+
+	oldTemplate := newPodTemplate("web", "nginx:1.15", map[string]string{"pod-template-hash": "abc"})
+	revisionData, _ := yaml.YAMLToJSON([]byte(`
+spec:
+  template:
+    $patch: replace
+    metadata:
+      labels:
+        app: db
+    spec:
+      containers:
+      - name: db
+        image: postgres:11
+`))
+	client := fake.NewSimpleClientset(
+		&apps.ReplicaSet{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:            "web-abc",
+				Namespace:       "staging",
+				Annotations:     map[string]string{revisionAnnotation: "1"},
+				OwnerReferences: newOwnerReference("Deployment", "web", "staging-uid"),
+			},
+			Spec: apps.ReplicaSetSpec{Template: oldTemplate},
+		},
+		&apps.ReplicaSet{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:            "web-def",
+				Namespace:       "staging",
+				Annotations:     map[string]string{revisionAnnotation: "1"},
+				OwnerReferences: newOwnerReference("Deployment", "web", "other-uid"),
+			},
+			Spec: apps.ReplicaSetSpec{Template: newPodTemplate("web", "nginx:1.14", map[string]string{})},
+		},
+		&apps.ControllerRevision{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:            "db-1",
+				Namespace:       "staging",
+				OwnerReferences: newOwnerReference("StatefulSet", "db", "db-uid"),
+			},
+			Data:     runtime.RawExtension{Raw: revisionData},
+			Revision: 1,
+		},
+	)
+
+	cases := []struct {
+		info     string
+		spec     ComparisonSpec
+		expected string
+	}{
+		{
+			"objects from different namespaces",
+			ComparisonSpec{
+				From: ObjectReference{Kind: "deployment", Namespace: "staging", Name: "web"},
+				To:   ObjectReference{Kind: "deployment", Namespace: "production", Name: "web"},
+			},
+			`--- deployment/staging/web
++++ deployment/production/web
+@@ -3,13 +3,13 @@
+ metadata:
+   name: web
+ spec:
+-  replicas: 2
++  replicas: 4
+   template:
+     metadata:
+       labels:
+         app: web
+     spec:
+       containers:
+-      - image: nginx:1.17
++      - image: nginx:1.16
+         imagePullPolicy: IfNotPresent
+         name: web
+`,
+		},
+		{
+			"equal objects",
+			ComparisonSpec{
+				From: ObjectReference{Kind: "deployment", Namespace: "staging", Name: "web"},
+				To:   ObjectReference{Kind: "deployment", Namespace: "qa", Name: "web"},
+			},
+			"",
+		},
+		{
+			"deployment revision",
+			ComparisonSpec{
+				From: ObjectReference{Kind: "deployment", Namespace: "staging", Name: "web", Revision: 1},
+				To:   ObjectReference{Kind: "deployment", Namespace: "staging", Name: "web"},
+			},
+			`--- deployment/staging/web@1
++++ deployment/staging/web
+@@ -10,6 +10,6 @@
+         app: web
+     spec:
+       containers:
+-      - image: nginx:1.15
++      - image: nginx:1.17
+         imagePullPolicy: IfNotPresent
+         name: web
+`,
+		},
+		{
+			"stateful set revision",
+			ComparisonSpec{
+				From: ObjectReference{Kind: "statefulset", Namespace: "staging", Name: "db", Revision: 1},
+				To:   ObjectReference{Kind: "statefulset", Namespace: "staging", Name: "db"},
+			},
+			`--- statefulset/staging/db@1
++++ statefulset/staging/db
+@@ -10,5 +10,5 @@
+         app: db
+     spec:
+       containers:
+-      - image: postgres:11
++      - image: postgres:12
+         name: db
+`,
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := CompareObjects(verber, client, &c.spec)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+			continue
+		}
+		if actual.Diff != c.expected {
+			t.Errorf("%s: expected diff:\n%s\nbut got:\n%s", c.info, c.expected, actual.Diff)
+		}
+		if actual.From != c.spec.From || actual.To != c.spec.To {
+			t.Errorf("%s: expected references %v, but got %v", c.info, c.spec, actual)
+		}
+	}
+}
+
+func TestCompareObjectsErrors(t *testing.T) {
+	verber := &fakeVerber{objects: map[string]string{
+		"deployment/staging/web": newDeployment("staging", "staging-uid", 2, "nginx:1.17"),
+		"service/staging/web":    "{apiVersion: v1, kind: Service, metadata: {name: web, namespace: staging}}",
+	}}
+	current := ObjectReference{Kind: "deployment", Namespace: "staging", Name: "web"}
+
+	cases := []struct {
+		info      string
+		from      ObjectReference
+		checkFunc func(error) bool
+	}{
+		{"missing name", ObjectReference{Kind: "deployment", Namespace: "staging"}, isBadRequest},
+		{"negative revision", ObjectReference{Kind: "deployment", Namespace: "staging", Name: "web", Revision: -1},
+			isBadRequest},
+		{"revision of unsupported kind", ObjectReference{Kind: "service", Namespace: "staging", Name: "web",
+			Revision: 1}, isBadRequest},
+		{"missing revision", ObjectReference{Kind: "deployment", Namespace: "staging", Name: "web", Revision: 5},
+			errors.IsNotFoundError},
+		{"missing object", ObjectReference{Kind: "deployment", Namespace: "staging", Name: "api"},
+			errors.IsNotFoundError},
+	}
+
+	for _, c := range cases {
+		_, err := CompareObjects(verber, fake.NewSimpleClientset(), &ComparisonSpec{From: c.from, To: current})
+		if !c.checkFunc(err) {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+		}
+	}
+}
+
+func isBadRequest(err error) bool {
+	statusError, ok := err.(*k8serrors.StatusError)
+	return ok && statusError.ErrStatus.Code == http.StatusBadRequest
+}
@@ -17,6 +17,8 @@ package configmap
 import (
 	"log"
 
+	"github.com/kubernetes/dashboard/src/app/backend/resource/consumer"
+
 	v1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -31,6 +33,9 @@ type ConfigMapDetail struct {
 	// Data contains the configuration data.
 	// Each key must be a valid DNS_SUBDOMAIN with an optional leading dot.
 	Data map[string]string `json:"data,omitempty"`
+
+	// Pods and resources with pod templates that consume the config map.
+	Consumers *consumer.ConsumerList `json:"consumers,omitempty"`
 }
 
 // GetConfigMapDetail returns detailed information about a config map
@@ -43,7 +48,14 @@ func GetConfigMapDetail(client kubernetes.Interface, namespace, name string) (*C
 		return nil, err
 	}
 
-	return getConfigMapDetail(rawConfigMap), nil
+	consumers, err := consumer.GetConfigMapConsumers(client, rawConfigMap.ObjectMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := getConfigMapDetail(rawConfigMap)
+	detail.Consumers = consumers
+	return detail, nil
 }
 
 func getConfigMapDetail(rawConfigMap *v1.ConfigMap) *ConfigMapDetail {
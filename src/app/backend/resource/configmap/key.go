@@ -0,0 +1,136 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/consumer"
+)
+
+// KeySpec is a new value of a single config map key.
+type KeySpec struct {
+	// Text value stored in data.
+	Value string `json:"value"`
+
+	// Binary value stored in binaryData. It must be Base64 encoded. Takes precedence over Value.
+	BinaryValue []byte `json:"binaryValue,omitempty"`
+
+	// Resource version the change is based on. The change is rejected if the config map was modified in the
+	// meantime. Not checked if empty.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Whether to trigger rolling restart of workloads consuming the config map.
+	Restart bool `json:"restart"`
+}
+
+// KeyUpdateResult describes the config map after a change of a single key.
+type KeyUpdateResult struct {
+	// Resource version of the changed config map.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Workloads whose rolling restart was triggered.
+	RestartedConsumers []consumer.Consumer `json:"restartedConsumers"`
+
+	// Errors of failed restarts. The config map itself was changed.
+	Errors []error `json:"errors"`
+}
+
+// SetConfigMapKey creates or updates a single key of the config map. Key can be either in data or in binaryData,
+// so it is removed from the other one.
+func SetConfigMapKey(client kubernetes.Interface, namespace, name, key string, spec *KeySpec) (*KeyUpdateResult,
+	error) {
+	log.Printf("Setting %s key of %s config map in %s namespace", key, name, namespace)
+
+	if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Invalid key %q: %s", key, strings.Join(errs, "; ")))
+	}
+
+	data := map[string]interface{}{key: nil}
+	binaryData := map[string]interface{}{key: nil}
+	if spec.BinaryValue != nil {
+		binaryData[key] = spec.BinaryValue
+	} else {
+		data[key] = spec.Value
+	}
+
+	return patchConfigMap(client, namespace, name, spec.ResourceVersion, data, binaryData, spec.Restart)
+}
+
+// DeleteConfigMapKey removes a single key of the config map.
+func DeleteConfigMapKey(client kubernetes.Interface, namespace, name, key, resourceVersion string,
+	restart bool) (*KeyUpdateResult, error) {
+	log.Printf("Deleting %s key of %s config map in %s namespace", key, name, namespace)
+
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	_, inData := configMap.Data[key]
+	_, inBinaryData := configMap.BinaryData[key]
+	if !inData && !inBinaryData {
+		return nil, errors.NewNotFound(fmt.Sprintf("Key %s not found in %s config map", key, name))
+	}
+
+	nullKey := map[string]interface{}{key: nil}
+	return patchConfigMap(client, namespace, name, resourceVersion, nullKey, nullKey, restart)
+}
+
+// Applies JSON merge patch to data and binaryData of the config map. Resource version in the patch works as
+// a precondition, so concurrent changes are not overwritten.
+func patchConfigMap(client kubernetes.Interface, namespace, name, resourceVersion string, data,
+	binaryData map[string]interface{}, restart bool) (*KeyUpdateResult, error) {
+	patch := map[string]interface{}{"data": data, "binaryData": binaryData}
+	if len(resourceVersion) > 0 {
+		patch["metadata"] = map[string]interface{}{"resourceVersion": resourceVersion}
+	}
+
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps(namespace).Patch(name, types.MergePatchType, body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &KeyUpdateResult{
+		ResourceVersion:    configMap.ResourceVersion,
+		RestartedConsumers: make([]consumer.Consumer, 0),
+		Errors:             make([]error, 0),
+	}
+
+	if restart {
+		consumers, err := consumer.GetConfigMapConsumers(client, configMap.ObjectMeta)
+		if err != nil {
+			result.Errors = append(result.Errors, err)
+			return result, nil
+		}
+		result.RestartedConsumers, result.Errors = consumer.RestartConsumers(client, consumers)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configmap
+
+import (
+	"reflect"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/consumer"
+)
+
+func TestSetAndDeleteConfigMapKey(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metaV1.ObjectMeta{Name: "config", Namespace: "ns"},
+		Data:       map[string]string{"a": "1", "b": "2"},
+	})
+
+	get := func() *v1.ConfigMap {
+		configMap, _ := client.CoreV1().ConfigMaps("ns").Get("config", metaV1.GetOptions{})
+		return configMap
+	}
+
+	if _, err := SetConfigMapKey(client, "ns", "config", "a", &KeySpec{Value: "updated"}); err != nil {
+		t.Fatalf("SetConfigMapKey(): unexpected error: %s", err.Error())
+	}
+	if expected := map[string]string{"a": "updated", "b": "2"}; !reflect.DeepEqual(get().Data, expected) {
+		t.Errorf("SetConfigMapKey(): got data %v, expected %v", get().Data, expected)
+	}
+
+	// Binary value moves the key from data to binaryData.
+	if _, err := SetConfigMapKey(client, "ns", "config", "b", &KeySpec{BinaryValue: []byte{0, 1}}); err != nil {
+		t.Fatalf("SetConfigMapKey(): unexpected error: %s", err.Error())
+	}
+	configMap := get()
+	if _, ok := configMap.Data["b"]; ok || !reflect.DeepEqual(configMap.BinaryData["b"], []byte{0, 1}) {
+		t.Errorf("SetConfigMapKey(): got data %v and binary data %v, expected key b only in binary data",
+			configMap.Data, configMap.BinaryData)
+	}
+
+	if _, err := SetConfigMapKey(client, "ns", "config", "invalid/key", &KeySpec{}); err == nil {
+		t.Errorf("SetConfigMapKey(): expected error for invalid key")
+	}
+
+	if _, err := DeleteConfigMapKey(client, "ns", "config", "b", "", false); err != nil {
+		t.Fatalf("DeleteConfigMapKey(): unexpected error: %s", err.Error())
+	}
+	if len(get().BinaryData) != 0 {
+		t.Errorf("DeleteConfigMapKey(): got binary data %v, expected none", get().BinaryData)
+	}
+
+	if _, err := DeleteConfigMapKey(client, "ns", "config", "b", "", false); !errors.IsNotFoundError(err) {
+		t.Errorf("DeleteConfigMapKey(): expected not found error, got %v", err)
+	}
+}
+
+func TestSetConfigMapKeyWithRestart(t *testing.T) {
+	volumes := []v1.Volume{{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+		LocalObjectReference: v1.LocalObjectReference{Name: "config"},
+	}}}}
+	client := fake.NewSimpleClientset(
+		&v1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "config", Namespace: "ns"}},
+		&apps.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "consumer", Namespace: "ns"},
+			Spec:       apps.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Volumes: volumes}}},
+		},
+		&apps.Deployment{ObjectMeta: metaV1.ObjectMeta{Name: "other", Namespace: "ns"}},
+	)
+
+	result, err := SetConfigMapKey(client, "ns", "config", "a", &KeySpec{Value: "1", Restart: true})
+	if err != nil {
+		t.Fatalf("SetConfigMapKey(): unexpected error: %s", err.Error())
+	}
+
+	if len(result.RestartedConsumers) != 1 || result.RestartedConsumers[0].ObjectMeta.Name != "consumer" {
+		t.Errorf("SetConfigMapKey() restarted %v, expected only consumer deployment", result.RestartedConsumers)
+	}
+
+	for name, restarted := range map[string]bool{"consumer": true, "other": false} {
+		deployment, _ := client.AppsV1().Deployments("ns").Get(name, metaV1.GetOptions{})
+		_, ok := deployment.Spec.Template.Annotations[consumer.RestartedAtAnnotation]
+		if ok != restarted {
+			t.Errorf("SetConfigMapKey(): deployment %s restarted: %t, expected %t", name, ok, restarted)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"log"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// ReferenceType describes how a config map or a secret is consumed.
+type ReferenceType string
+
+// List of ways a config map or a secret can be consumed.
+const (
+	ReferenceTypeEnv                 ReferenceType = "env"
+	ReferenceTypeEnvFrom             ReferenceType = "envFrom"
+	ReferenceTypeVolume              ReferenceType = "volume"
+	ReferenceTypeProjectedVolume     ReferenceType = "projectedVolume"
+	ReferenceTypeImagePullSecret     ReferenceType = "imagePullSecret"
+	ReferenceTypeServiceAccountToken ReferenceType = "serviceAccountToken"
+)
+
+// Reference is a single place where a config map or a secret is consumed.
+type Reference struct {
+	Type ReferenceType `json:"type"`
+
+	// Name of the container for env and envFrom references.
+	Container string `json:"container,omitempty"`
+
+	// Name of the volume for volume references.
+	Volume string `json:"volume,omitempty"`
+}
+
+// Consumer is a pod, a resource with pod template or a service account consuming a config map or a secret.
+type Consumer struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+	References []Reference    `json:"references"`
+
+	// Whether the pod was started before the last change of the consumed object, so it may use outdated
+	// configuration. Set only for pods.
+	Stale bool `json:"stale"`
+}
+
+// ConsumerList contains all consumers of a config map or a secret.
+type ConsumerList struct {
+	Consumers []Consumer `json:"consumers"`
+
+	// Time of the last change of the consumed object, i.e. the latest update recorded in managed fields or the
+	// creation time.
+	LastModified metaV1.Time `json:"lastModified"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetConfigMapConsumers returns pods and resources with pod templates that consume given config map.
+func GetConfigMapConsumers(client client.Interface, configMap metaV1.ObjectMeta) (*ConsumerList, error) {
+	log.Printf("Getting consumers of %s config map in %s namespace", configMap.Name, configMap.Namespace)
+	return getConsumers(client, api.ResourceKindConfigMap, configMap)
+}
+
+// GetSecretConsumers returns pods, resources with pod templates and service accounts that consume given secret.
+func GetSecretConsumers(client client.Interface, secret metaV1.ObjectMeta) (*ConsumerList, error) {
+	log.Printf("Getting consumers of %s secret in %s namespace", secret.Name, secret.Namespace)
+	return getConsumers(client, api.ResourceKindSecret, secret)
+}
+
+func getConsumers(client client.Interface, kind api.ResourceKind, meta metaV1.ObjectMeta) (*ConsumerList, error) {
+	nsQuery := common.NewSameNamespaceQuery(meta.Namespace)
+	channels := &common.ResourceChannels{
+		PodList:         common.GetPodListChannel(client, nsQuery, 1),
+		DeploymentList:  common.GetDeploymentListChannel(client, nsQuery, 1),
+		StatefulSetList: common.GetStatefulSetListChannel(client, nsQuery, 1),
+		DaemonSetList:   common.GetDaemonSetListChannel(client, nsQuery, 1),
+		JobList:         common.GetJobListChannel(client, nsQuery, 1),
+		CronJobList:     common.GetCronJobListChannel(client, nsQuery, 1),
+	}
+
+	lastModified := getLastModified(meta)
+	result := &ConsumerList{
+		Consumers:    make([]Consumer, 0),
+		LastModified: lastModified,
+		Errors:       make([]error, 0),
+	}
+
+	add := func(consumerKind api.ResourceKind, consumerMeta metaV1.ObjectMeta, references []Reference) {
+		if len(references) > 0 {
+			result.Consumers = append(result.Consumers, Consumer{
+				ObjectMeta: api.NewObjectMeta(consumerMeta),
+				TypeMeta:   api.NewTypeMeta(consumerKind),
+				References: references,
+			})
+		}
+	}
+
+	var err error
+	pods, listErr := <-channels.PodList.List, <-channels.PodList.Error
+	if result.Errors, err = errors.AppendError(listErr, result.Errors); err != nil {
+		return nil, err
+	}
+	if pods != nil {
+		for _, pod := range pods.Items {
+			references := FindPodSpecReferences(pod.Spec, kind, meta.Name)
+			if len(references) > 0 {
+				result.Consumers = append(result.Consumers, Consumer{
+					ObjectMeta: api.NewObjectMeta(pod.ObjectMeta),
+					TypeMeta:   api.NewTypeMeta(api.ResourceKindPod),
+					References: references,
+					Stale:      pod.Status.StartTime != nil && pod.Status.StartTime.Before(&lastModified),
+				})
+			}
+		}
+	}
+
+	deployments, listErr := <-channels.DeploymentList.List, <-channels.DeploymentList.Error
+	if result.Errors, err = errors.AppendError(listErr, result.Errors); err != nil {
+		return nil, err
+	}
+	if deployments != nil {
+		for _, item := range deployments.Items {
+			add(api.ResourceKindDeployment, item.ObjectMeta,
+				FindPodSpecReferences(item.Spec.Template.Spec, kind, meta.Name))
+		}
+	}
+
+	statefulSets, listErr := <-channels.StatefulSetList.List, <-channels.StatefulSetList.Error
+	if result.Errors, err = errors.AppendError(listErr, result.Errors); err != nil {
+		return nil, err
+	}
+	if statefulSets != nil {
+		for _, item := range statefulSets.Items {
+			add(api.ResourceKindStatefulSet, item.ObjectMeta,
+				FindPodSpecReferences(item.Spec.Template.Spec, kind, meta.Name))
+		}
+	}
+
+	daemonSets, listErr := <-channels.DaemonSetList.List, <-channels.DaemonSetList.Error
+	if result.Errors, err = errors.AppendError(listErr, result.Errors); err != nil {
+		return nil, err
+	}
+	if daemonSets != nil {
+		for _, item := range daemonSets.Items {
+			add(api.ResourceKindDaemonSet, item.ObjectMeta,
+				FindPodSpecReferences(item.Spec.Template.Spec, kind, meta.Name))
+		}
+	}
+
+	// Jobs created by cron jobs are represented by their cron job.
+	jobs, listErr := <-channels.JobList.List, <-channels.JobList.Error
+	if result.Errors, err = errors.AppendError(listErr, result.Errors); err != nil {
+		return nil, err
+	}
+	if jobs != nil {
+		for _, item := range jobs.Items {
+			if metaV1.GetControllerOf(&item) == nil {
+				add(api.ResourceKindJob, item.ObjectMeta,
+					FindPodSpecReferences(item.Spec.Template.Spec, kind, meta.Name))
+			}
+		}
+	}
+
+	cronJobs, listErr := <-channels.CronJobList.List, <-channels.CronJobList.Error
+	if result.Errors, err = errors.AppendError(listErr, result.Errors); err != nil {
+		return nil, err
+	}
+	if cronJobs != nil {
+		for _, item := range cronJobs.Items {
+			add(api.ResourceKindCronJob, item.ObjectMeta,
+				FindPodSpecReferences(item.Spec.JobTemplate.Spec.Template.Spec, kind, meta.Name))
+		}
+	}
+
+	if kind == api.ResourceKindSecret {
+		serviceAccounts, listErr := client.CoreV1().ServiceAccounts(meta.Namespace).List(api.ListEverything)
+		if result.Errors, err = errors.AppendError(listErr, result.Errors); err != nil {
+			return nil, err
+		}
+		if serviceAccounts != nil {
+			for _, item := range serviceAccounts.Items {
+				add(api.ResourceKindServiceAccount, item.ObjectMeta, findServiceAccountReferences(item, meta.Name))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// FindPodSpecReferences returns all places where pod spec references config map or secret with given name.
+func FindPodSpecReferences(spec v1.PodSpec, kind api.ResourceKind, name string) []Reference {
+	references := make([]Reference, 0)
+
+	for _, volume := range spec.Volumes {
+		switch {
+		case kind == api.ResourceKindConfigMap && volume.ConfigMap != nil && volume.ConfigMap.Name == name,
+			kind == api.ResourceKindSecret && volume.Secret != nil && volume.Secret.SecretName == name:
+			references = append(references, Reference{Type: ReferenceTypeVolume, Volume: volume.Name})
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if kind == api.ResourceKindConfigMap && source.ConfigMap != nil && source.ConfigMap.Name == name ||
+					kind == api.ResourceKindSecret && source.Secret != nil && source.Secret.Name == name {
+					references = append(references, Reference{Type: ReferenceTypeProjectedVolume, Volume: volume.Name})
+					break
+				}
+			}
+		}
+	}
+
+	containers := append(append([]v1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if kind == api.ResourceKindConfigMap && envFrom.ConfigMapRef != nil && envFrom.ConfigMapRef.Name == name ||
+				kind == api.ResourceKindSecret && envFrom.SecretRef != nil && envFrom.SecretRef.Name == name {
+				references = append(references, Reference{Type: ReferenceTypeEnvFrom, Container: container.Name})
+				break
+			}
+		}
+
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if kind == api.ResourceKindConfigMap && env.ValueFrom.ConfigMapKeyRef != nil &&
+				env.ValueFrom.ConfigMapKeyRef.Name == name ||
+				kind == api.ResourceKindSecret && env.ValueFrom.SecretKeyRef != nil &&
+					env.ValueFrom.SecretKeyRef.Name == name {
+				references = append(references, Reference{Type: ReferenceTypeEnv, Container: container.Name})
+				break
+			}
+		}
+	}
+
+	if kind == api.ResourceKindSecret {
+		for _, secret := range spec.ImagePullSecrets {
+			if secret.Name == name {
+				references = append(references, Reference{Type: ReferenceTypeImagePullSecret})
+			}
+		}
+	}
+
+	return references
+}
+
+func findServiceAccountReferences(serviceAccount v1.ServiceAccount, name string) []Reference {
+	references := make([]Reference, 0)
+	for _, secret := range serviceAccount.Secrets {
+		if secret.Name == name {
+			references = append(references, Reference{Type: ReferenceTypeServiceAccountToken})
+		}
+	}
+
+	for _, secret := range serviceAccount.ImagePullSecrets {
+		if secret.Name == name {
+			references = append(references, Reference{Type: ReferenceTypeImagePullSecret})
+		}
+	}
+
+	return references
+}
+
+// Config maps and secrets do not record time of their last update. Managed fields contain time of the last
+// change made by each manager, so the latest of them is used.
+func getLastModified(meta metaV1.ObjectMeta) metaV1.Time {
+	lastModified := meta.CreationTimestamp
+	for _, entry := range meta.ManagedFields {
+		if entry.Time != nil && lastModified.Before(entry.Time) {
+			lastModified = *entry.Time
+		}
+	}
+
+	return lastModified
+}
@@ -0,0 +1,162 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	apps "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+func TestGetSecretConsumers(t *testing.T) {
+	now := time.Now()
+	modified := metaV1.NewTime(now.Add(-time.Hour))
+	before := metaV1.NewTime(now.Add(-2 * time.Hour))
+	after := metaV1.NewTime(now)
+
+	secret := metaV1.ObjectMeta{
+		Name:              "creds",
+		Namespace:         "ns",
+		CreationTimestamp: metaV1.NewTime(now.Add(-3 * time.Hour)),
+		ManagedFields: []metaV1.ManagedFieldsEntry{
+			{Manager: "kubectl", Time: &modified},
+		},
+	}
+
+	podSpec := v1.PodSpec{
+		Volumes: []v1.Volume{
+			{Name: "certs", VolumeSource: v1.VolumeSource{Secret: &v1.SecretVolumeSource{SecretName: "creds"}}},
+			{Name: "all", VolumeSource: v1.VolumeSource{Projected: &v1.ProjectedVolumeSource{
+				Sources: []v1.VolumeProjection{
+					{Secret: &v1.SecretProjection{LocalObjectReference: v1.LocalObjectReference{Name: "creds"}}},
+				},
+			}}},
+		},
+		Containers: []v1.Container{{
+			Name: "app",
+			Env: []v1.EnvVar{{Name: "PASSWORD", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "creds"}, Key: "password",
+			}}}},
+			EnvFrom: []v1.EnvFromSource{{SecretRef: &v1.SecretEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "creds"},
+			}}},
+		}},
+		ImagePullSecrets: []v1.LocalObjectReference{{Name: "creds"}},
+	}
+
+	client := fake.NewSimpleClientset(
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "old", Namespace: "ns"},
+			Spec:       podSpec,
+			Status:     v1.PodStatus{StartTime: &before},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "new", Namespace: "ns"},
+			Spec:       v1.PodSpec{ImagePullSecrets: []v1.LocalObjectReference{{Name: "creds"}}},
+			Status:     v1.PodStatus{StartTime: &after},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "unrelated", Namespace: "ns"},
+		},
+		&apps.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "app", Namespace: "ns"},
+			Spec:       apps.DeploymentSpec{Template: v1.PodTemplateSpec{Spec: podSpec}},
+		},
+		&v1.ServiceAccount{
+			ObjectMeta: metaV1.ObjectMeta{Name: "default", Namespace: "ns"},
+			Secrets:    []v1.ObjectReference{{Name: "creds"}},
+		},
+	)
+
+	actual, err := GetSecretConsumers(client, secret)
+	if err != nil {
+		t.Fatalf("GetSecretConsumers(): unexpected error: %s", err.Error())
+	}
+
+	if !actual.LastModified.Equal(&modified) {
+		t.Errorf("GetSecretConsumers().LastModified == %v, expected %v", actual.LastModified, modified)
+	}
+
+	podReferences := []Reference{
+		{Type: ReferenceTypeVolume, Volume: "certs"},
+		{Type: ReferenceTypeProjectedVolume, Volume: "all"},
+		{Type: ReferenceTypeEnvFrom, Container: "app"},
+		{Type: ReferenceTypeEnv, Container: "app"},
+		{Type: ReferenceTypeImagePullSecret},
+	}
+	expected := []Consumer{
+		{
+			ObjectMeta: api.ObjectMeta{Name: "old", Namespace: "ns"},
+			TypeMeta:   api.TypeMeta{Kind: api.ResourceKindPod},
+			References: podReferences,
+			Stale:      true,
+		},
+		{
+			ObjectMeta: api.ObjectMeta{Name: "new", Namespace: "ns"},
+			TypeMeta:   api.TypeMeta{Kind: api.ResourceKindPod},
+			References: []Reference{{Type: ReferenceTypeImagePullSecret}},
+		},
+		{
+			ObjectMeta: api.ObjectMeta{Name: "app", Namespace: "ns"},
+			TypeMeta:   api.TypeMeta{Kind: api.ResourceKindDeployment, Scalable: true},
+			References: podReferences,
+		},
+		{
+			ObjectMeta: api.ObjectMeta{Name: "default", Namespace: "ns"},
+			TypeMeta:   api.TypeMeta{Kind: api.ResourceKindServiceAccount},
+			References: []Reference{{Type: ReferenceTypeServiceAccountToken}},
+		},
+	}
+	if !reflect.DeepEqual(actual.Consumers, expected) {
+		t.Errorf("GetSecretConsumers().Consumers == \n%#v\nexpected \n%#v", actual.Consumers, expected)
+	}
+}
+
+func TestFindPodSpecReferencesOfConfigMap(t *testing.T) {
+	spec := v1.PodSpec{
+		Volumes: []v1.Volume{
+			{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "config"},
+			}}},
+			{Name: "other", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "other"},
+			}}},
+		},
+		InitContainers: []v1.Container{{
+			Name: "init",
+			EnvFrom: []v1.EnvFromSource{{ConfigMapRef: &v1.ConfigMapEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{Name: "config"},
+			}}},
+		}},
+		// Secret with the same name is not a reference to the config map.
+		ImagePullSecrets: []v1.LocalObjectReference{{Name: "config"}},
+	}
+
+	actual := FindPodSpecReferences(spec, api.ResourceKindConfigMap, "config")
+	expected := []Reference{
+		{Type: ReferenceTypeVolume, Volume: "config"},
+		{Type: ReferenceTypeEnvFrom, Container: "init"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("FindPodSpecReferences() == %#v, expected %#v", actual, expected)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consumer
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+// RestartedAtAnnotation is set on pod templates to trigger a rolling restart. It is the same annotation that is used
+// by kubectl rollout restart.
+const RestartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// RestartConsumers triggers rolling restart of deployments, stateful sets and daemon sets from given list, so their
+// pods pick up the latest configuration. Other consumers are skipped: standalone pods can not be restarted and jobs
+// use the configuration on their next run. Restarted consumers are returned together with errors of failed restarts.
+func RestartConsumers(client client.Interface, consumers *ConsumerList) ([]Consumer, []error) {
+	restarted := make([]Consumer, 0)
+	errs := make([]error, 0)
+	patch := []byte(fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		RestartedAtAnnotation, time.Now().Format(time.RFC3339)))
+
+	for _, consumer := range consumers.Consumers {
+		namespace, name := consumer.ObjectMeta.Namespace, consumer.ObjectMeta.Name
+		var err error
+		switch consumer.TypeMeta.Kind {
+		case api.ResourceKindDeployment:
+			_, err = client.AppsV1().Deployments(namespace).Patch(name, types.MergePatchType, patch)
+		case api.ResourceKindStatefulSet:
+			_, err = client.AppsV1().StatefulSets(namespace).Patch(name, types.MergePatchType, patch)
+		case api.ResourceKindDaemonSet:
+			_, err = client.AppsV1().DaemonSets(namespace).Patch(name, types.MergePatchType, patch)
+		default:
+			continue
+		}
+
+		if err != nil {
+			log.Printf("Could not restart %s %s: %s", consumer.TypeMeta.Kind, name, err.Error())
+			errs = append(errs, err)
+			continue
+		}
+		restarted = append(restarted, consumer)
+	}
+
+	return restarted, errs
+}
@@ -0,0 +1,212 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependent
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// Dependent is an object that would be deleted by the garbage collector together with its owner.
+type Dependent struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	APIVersion string         `json:"apiVersion"`
+	Kind       string         `json:"kind"`
+
+	// Owners of the object. All of them are deleted by the cascade, otherwise the object would be kept.
+	Owners []metaV1.OwnerReference `json:"owners"`
+}
+
+// DependentList contains all objects that would be garbage collected after deletion of their owner with given
+// propagation policy.
+type DependentList struct {
+	PropagationPolicy metaV1.DeletionPropagation `json:"propagationPolicy"`
+	Dependents        []Dependent                `json:"dependents"`
+
+	// List of non-critical errors, that occurred during resource retrieval. Dependents of resources that could not
+	// be listed are missing from the list.
+	Errors []error `json:"errors"`
+}
+
+// GetResourceDependents returns objects that would be garbage collected after deletion of given object, as returned
+// by the resource verber.
+func GetResourceDependents(cfg *rest.Config, object runtime.Object,
+	policy metaV1.DeletionPropagation) (*DependentList, error) {
+	unknown, ok := object.(*runtime.Unknown)
+	if !ok {
+		return nil, errors.NewUnexpectedObject(object)
+	}
+
+	owner := &unstructured.Unstructured{}
+	if err := owner.UnmarshalJSON(unknown.Raw); err != nil {
+		return nil, err
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetDependents(discoveryClient, dynamicClient, owner, policy)
+}
+
+// GetDependents walks owner references of all objects that can be listed and returns those that would be garbage
+// collected after deletion of given owner. Object is collected only if all of its owners are collected. Namespaced
+// owners can only own objects from their namespace. Objects removed with deleted namespace are not listed, as they
+// are not connected to it by owner references.
+func GetDependents(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface,
+	owner metaV1.Object, policy metaV1.DeletionPropagation) (*DependentList, error) {
+	log.Printf("Getting dependents of %s", owner.GetName())
+
+	result := &DependentList{
+		PropagationPolicy: policy,
+		Dependents:        make([]Dependent, 0),
+		Errors:            make([]error, 0),
+	}
+
+	// Orphaned dependents lose the owner reference and are kept.
+	if policy == metaV1.DeletePropagationOrphan {
+		return result, nil
+	}
+
+	objects, nonCriticalErrors, err := getOwnedObjects(discoveryClient, dynamicClient, owner.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = nonCriticalErrors
+
+	collected := map[types.UID]bool{owner.GetUID(): true}
+	for changed := true; changed; {
+		changed = false
+		for _, object := range objects {
+			if collected[object.GetUID()] || !allOwnersCollected(object, collected) {
+				continue
+			}
+
+			collected[object.GetUID()] = true
+			result.Dependents = append(result.Dependents, Dependent{
+				ObjectMeta: api.NewObjectMeta(metaV1.ObjectMeta{
+					Name:              object.GetName(),
+					Namespace:         object.GetNamespace(),
+					Labels:            object.GetLabels(),
+					Annotations:       object.GetAnnotations(),
+					CreationTimestamp: object.GetCreationTimestamp(),
+					UID:               object.GetUID(),
+				}),
+				APIVersion: object.GetAPIVersion(),
+				Kind:       object.GetKind(),
+				Owners:     object.GetOwnerReferences(),
+			})
+			changed = true
+		}
+	}
+
+	sort.SliceStable(result.Dependents, func(i, j int) bool {
+		a, b := result.Dependents[i], result.Dependents[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.ObjectMeta.Namespace != b.ObjectMeta.Namespace {
+			return a.ObjectMeta.Namespace < b.ObjectMeta.Namespace
+		}
+		return a.ObjectMeta.Name < b.ObjectMeta.Name
+	})
+
+	return result, nil
+}
+
+// Lists objects with owner references from all resources that support listing and deletion. Resources of API groups
+// that could not be discovered and resources that could not be listed are reported as non-critical errors. Empty
+// namespace means that owner is not namespaced, so objects from all namespaces and cluster scoped objects are listed.
+func getOwnedObjects(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface,
+	namespace string) ([]unstructured.Unstructured, []error, error) {
+	nonCriticalErrors := make([]error, 0)
+	resourceLists, err := discovery.ServerPreferredResources(discoveryClient)
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, nil, err
+		}
+		nonCriticalErrors = append(nonCriticalErrors, err)
+	}
+
+	resourceLists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: []string{"list", "delete"}},
+		resourceLists)
+
+	// The same object can be served by multiple API groups, e.g. deployments by apps and extensions.
+	seen := make(map[types.UID]bool)
+	objects := make([]unstructured.Unstructured, 0)
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			nonCriticalErrors = append(nonCriticalErrors, err)
+			continue
+		}
+
+		for _, resource := range resourceList.APIResources {
+			// Subresources can not be owners nor dependents.
+			if strings.Contains(resource.Name, "/") || (len(namespace) > 0 && !resource.Namespaced) {
+				continue
+			}
+
+			list, err := dynamicClient.Resource(groupVersion.WithResource(resource.Name)).Namespace(namespace).
+				List(metaV1.ListOptions{})
+			var criticalError error
+			nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+			if criticalError != nil {
+				return nil, nil, criticalError
+			}
+			if err != nil {
+				continue
+			}
+
+			for _, item := range list.Items {
+				if len(item.GetOwnerReferences()) > 0 && !seen[item.GetUID()] {
+					seen[item.GetUID()] = true
+					objects = append(objects, item)
+				}
+			}
+		}
+	}
+
+	return objects, nonCriticalErrors, nil
+}
+
+func allOwnersCollected(object unstructured.Unstructured, collected map[types.UID]bool) bool {
+	for _, reference := range object.GetOwnerReferences() {
+		if !collected[reference.UID] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,161 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dependent
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+// Creates namespaced object with UID equal to its name, owned by objects with given names.
+func newObject(apiVersion, kind, name string, owners ...string) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetNamespace("default")
+	object.SetName(name)
+	object.SetUID(types.UID(name))
+
+	references := make([]metaV1.OwnerReference, 0)
+	for _, owner := range owners {
+		references = append(references, metaV1.OwnerReference{Name: owner, UID: types.UID(owner)})
+	}
+	object.SetOwnerReferences(references)
+	return object
+}
+
+func TestGetDependents(t *testing.T) {
+	deployment := newObject("apps/v1", "Deployment", "deployment")
+	objects := []runtime.Object{
+		deployment,
+		newObject("apps/v1", "ReplicaSet", "replicaset", "deployment"),
+		newObject("v1", "Pod", "pod-1", "replicaset"),
+		newObject("v1", "Pod", "pod-2", "replicaset"),
+		newObject("v1", "Pod", "shared-pod", "replicaset", "other-owner"),
+		newObject("v1", "Pod", "unrelated-pod", "other-owner"),
+	}
+
+	verbs := metaV1.Verbs{"list", "delete"}
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metaV1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: verbs},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: metaV1.Verbs{"get"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: verbs},
+				{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true, Verbs: verbs},
+			},
+		},
+	}
+	dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+
+	cases := []struct {
+		policy   metaV1.DeletionPropagation
+		expected []string
+	}{
+		{metaV1.DeletePropagationForeground, []string{"pod-1", "pod-2", "replicaset"}},
+		{metaV1.DeletePropagationBackground, []string{"pod-1", "pod-2", "replicaset"}},
+		{metaV1.DeletePropagationOrphan, []string{}},
+	}
+
+	for _, c := range cases {
+		actual, err := GetDependents(client.Discovery(), dynamicClient, deployment, c.policy)
+		if err != nil {
+			t.Fatalf("GetDependents(%s): unexpected error: %s", c.policy, err.Error())
+		}
+
+		names := make([]string, 0)
+		for _, dependent := range actual.Dependents {
+			names = append(names, dependent.ObjectMeta.Name)
+		}
+
+		if !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("GetDependents(%s) == %v, expected %v", c.policy, names, c.expected)
+		}
+
+		if len(actual.Errors) > 0 {
+			t.Errorf("GetDependents(%s): unexpected non-critical errors: %v", c.policy, actual.Errors)
+		}
+	}
+}
+
+func TestGetDependentsListErrors(t *testing.T) {
+	deployment := newObject("apps/v1", "Deployment", "deployment")
+	verbs := metaV1.Verbs{"list", "delete"}
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metaV1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metaV1.APIResource{{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: verbs}},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metaV1.APIResource{{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true,
+				Verbs: verbs}},
+		},
+	}
+
+	cases := []struct {
+		info     string
+		err      error
+		critical bool
+	}{
+		{"forbidden", k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", nil), false},
+		{"internal", k8serrors.NewInternalError(fmt.Errorf("etcd unavailable")), true},
+	}
+
+	for _, c := range cases {
+		dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), deployment,
+			newObject("apps/v1", "ReplicaSet", "replicaset", "deployment"))
+		err := c.err
+		dynamicClient.PrependReactor("list", "pods", func(action core.Action) (bool, runtime.Object, error) {
+			return true, nil, err
+		})
+
+		actual, err := GetDependents(client.Discovery(), dynamicClient, deployment,
+			metaV1.DeletePropagationBackground)
+		if c.critical {
+			if err == nil {
+				t.Errorf("GetDependents() with %s error: expected error, got %v", c.info, actual)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("GetDependents() with %s error: unexpected error: %s", c.info, err.Error())
+		}
+		if len(actual.Dependents) != 1 || len(actual.Errors) != 1 {
+			t.Errorf("GetDependents() with %s error: expected replicaset and one non-critical error, got %v and %v",
+				c.info, actual.Dependents, actual.Errors)
+		}
+	}
+}
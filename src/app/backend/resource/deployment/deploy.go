@@ -18,22 +18,29 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/http"
 	"strings"
 
+	dashboardapi "github.com/kubernetes/dashboard/src/app/backend/api"
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diff"
 	apps "k8s.io/api/apps/v1"
 	api "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	client "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 )
 
 const (
@@ -89,6 +96,168 @@ type AppDeploymentSpec struct {
 
 	// Whether to run the container as privileged user (essentially equivalent to root on the host).
 	RunAsPrivileged bool `json:"runAsPrivileged"`
+
+	// Limits, probes, environment sources and volume mounts of the main container.
+	ContainerOptions
+
+	// Additional containers running next to the main one, e.g. sidecars.
+	Containers []ContainerSpec `json:"containers"`
+
+	// Containers run to completion one by one before other containers are started.
+	InitContainers []ContainerSpec `json:"initContainers"`
+
+	// Persistent volume claims created together with the app. They can be mounted by containers.
+	PersistentVolumeClaims []PersistentVolumeClaimSpec `json:"persistentVolumeClaims"`
+
+	// Optional ingress created for the service. Requires the service to be external.
+	Ingress *IngressSpec `json:"ingress"`
+
+	// Labels of nodes the pods can be scheduled on.
+	NodeSelector map[string]string `json:"nodeSelector"`
+}
+
+// ContainerSpec is a specification of an additional container of the app. Resources of the pod and labels are
+// shared with the main container.
+type ContainerSpec struct {
+	// Name of the container. Must be unique within the pod, where the main container is named after the app.
+	Name string `json:"name"`
+
+	// Docker image path for the container.
+	ContainerImage string `json:"containerImage"`
+
+	// Command that is executed instead of container entrypoint, if specified.
+	ContainerCommand []string `json:"containerCommand"`
+
+	// Arguments for the container command or container entrypoint.
+	ContainerCommandArgs []string `json:"containerCommandArgs"`
+
+	// List of user-defined environment variables.
+	Variables []EnvironmentVariable `json:"variables"`
+
+	// Optional memory requirement for the container.
+	MemoryRequirement *resource.Quantity `json:"memoryRequirement"`
+
+	// Optional CPU requirement for the container.
+	CpuRequirement *resource.Quantity `json:"cpuRequirement"`
+
+	ContainerOptions
+}
+
+// ContainerOptions are settings common to the main container and additional containers of the app.
+type ContainerOptions struct {
+	// Optional memory limit for the container.
+	MemoryLimit *resource.Quantity `json:"memoryLimit"`
+
+	// Optional CPU limit for the container.
+	CpuLimit *resource.Quantity `json:"cpuLimit"`
+
+	// Optional check whether the container is alive. Container is restarted when it fails.
+	LivenessProbe *ProbeSpec `json:"livenessProbe"`
+
+	// Optional check whether the container is ready to serve requests. Pod is removed from service endpoints when
+	// it fails.
+	ReadinessProbe *ProbeSpec `json:"readinessProbe"`
+
+	// Config maps and secrets whose keys are exposed to the container as environment variables.
+	EnvFrom []EnvFromSource `json:"envFrom"`
+
+	// Persistent volume claims mounted into the container.
+	VolumeMounts []VolumeMountSpec `json:"volumeMounts"`
+}
+
+// ProbeType is a type of the check made by the probe.
+type ProbeType string
+
+const (
+	// ProbeTypeHTTP checks that HTTP GET request to the container succeeds.
+	ProbeTypeHTTP ProbeType = "http"
+
+	// ProbeTypeTCP checks that TCP connection to the container can be opened.
+	ProbeTypeTCP ProbeType = "tcp"
+
+	// ProbeTypeExec checks that the command run in the container exits with zero status.
+	ProbeTypeExec ProbeType = "exec"
+)
+
+// ProbeSpec is a specification of a liveness or readiness probe.
+type ProbeSpec struct {
+	Type ProbeType `json:"type"`
+
+	// Path of the HTTP request.
+	Path string `json:"path"`
+
+	// Port of the HTTP request or TCP connection.
+	Port int32 `json:"port"`
+
+	// Command executed by the exec probe.
+	Command []string `json:"command"`
+
+	// Optional timing of the probe. Server defaults are used for zero values.
+	InitialDelaySeconds int32 `json:"initialDelaySeconds"`
+	PeriodSeconds       int32 `json:"periodSeconds"`
+	TimeoutSeconds      int32 `json:"timeoutSeconds"`
+	FailureThreshold    int32 `json:"failureThreshold"`
+}
+
+// EnvFromSource is a reference to a config map or secret, whose keys are exposed as environment variables.
+type EnvFromSource struct {
+	// Kind of the source, either "ConfigMap" or "Secret".
+	Kind string `json:"kind"`
+
+	// Name of the config map or secret in the namespace of the app.
+	Name string `json:"name"`
+
+	// Optional prefix prepended to every key.
+	Prefix string `json:"prefix"`
+}
+
+// Kinds of environment variable sources.
+const (
+	EnvFromConfigMap = "ConfigMap"
+	EnvFromSecret    = "Secret"
+)
+
+// VolumeMountSpec is a specification of a persistent volume claim mounted into a container.
+type VolumeMountSpec struct {
+	// Name of the claim, either created together with the app or already existing in its namespace.
+	ClaimName string `json:"claimName"`
+
+	// Absolute path in the container the volume is mounted at.
+	MountPath string `json:"mountPath"`
+
+	// Optional path within the volume to mount instead of its root.
+	SubPath string `json:"subPath"`
+
+	ReadOnly bool `json:"readOnly"`
+}
+
+// PersistentVolumeClaimSpec is a specification of a persistent volume claim created together with the app.
+type PersistentVolumeClaimSpec struct {
+	Name string `json:"name"`
+
+	// Requested size of the volume.
+	Size resource.Quantity `json:"size"`
+
+	// Access mode of the volume. Defaults to ReadWriteOnce.
+	AccessMode api.PersistentVolumeAccessMode `json:"accessMode"`
+
+	// Optional name of the storage class. Default storage class of the cluster is used if not set.
+	StorageClassName *string `json:"storageClassName"`
+}
+
+// IngressSpec is a specification of an ingress routing external traffic to the service of the app.
+type IngressSpec struct {
+	// Host the ingress rule applies to. Rule applies to all hosts if empty.
+	Host string `json:"host"`
+
+	// Path prefix routed to the service. Defaults to "/".
+	Path string `json:"path"`
+
+	// Port of the service the traffic is routed to. Defaults to the port of the first port mapping.
+	ServicePort int32 `json:"servicePort"`
+
+	// Optional name of the secret with TLS certificate for the host.
+	TLSSecret *string `json:"tlsSecret"`
 }
 
 // AppDeploymentFromFileSpec is a specification for deployment from file
@@ -104,6 +273,9 @@ type AppDeploymentFromFileSpec struct {
 
 	// Whether validate content before creation or not
 	Validate bool `json:"validate"`
+
+	// Whether only preview the changes without persisting them
+	DryRun bool `json:"dryRun"`
 }
 
 // AppDeploymentFromFileResponse is a specification for deployment from file
@@ -116,6 +288,44 @@ type AppDeploymentFromFileResponse struct {
 
 	// Error after create resource
 	Error string `json:"error"`
+
+	// Results of deployment of all objects from the file
+	Objects []DeployedObject `json:"objects"`
+}
+
+// DeployResult is the outcome of deployment of a single object from file.
+type DeployResult string
+
+const (
+	// DeployResultCreated means that the object did not exist and has been created.
+	DeployResultCreated DeployResult = "created"
+
+	// DeployResultConfigured means that the existing object has been updated.
+	DeployResultConfigured DeployResult = "configured"
+
+	// DeployResultUnchanged means that the existing object already matched the file.
+	DeployResultUnchanged DeployResult = "unchanged"
+
+	// DeployResultFailed means that the object could not be deployed.
+	DeployResultFailed DeployResult = "failed"
+)
+
+// DeployedObject is the result of deployment of a single object from file.
+type DeployedObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// Namespace of the object. Empty for cluster scoped objects.
+	Namespace string `json:"namespace,omitempty"`
+
+	Name   string       `json:"name"`
+	Result DeployResult `json:"result"`
+
+	// Reason of the failure.
+	Reason string `json:"reason,omitempty"`
+
+	// Diff between the live and the deployed object. Set only in dry run mode.
+	Diff string `json:"diff,omitempty"`
 }
 
 // PortMapping is a specification of port mapping for an application deployment.
@@ -155,8 +365,8 @@ type Protocols struct {
 }
 
 // DeployApp deploys an app based on the given configuration. The app is deployed using the given
-// client. App deployment consists of a deployment and an optional service. Both of them
-// share common labels.
+// client. App deployment consists of a deployment, optional persistent volume claims, an optional service and
+// an optional ingress. All of them share common labels.
 func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
 	log.Printf("Deploying %s application into %s namespace", spec.Name, spec.Namespace)
 
@@ -196,8 +406,15 @@ func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
 	if spec.MemoryRequirement != nil {
 		containerSpec.Resources.Requests[api.ResourceMemory] = *spec.MemoryRequirement
 	}
+	volumes, volumeNames := getVolumes(spec)
+	setContainerOptions(&containerSpec, spec.ContainerOptions, volumeNames)
+
 	podSpec := api.PodSpec{
-		Containers: []api.Container{containerSpec},
+		Containers: append([]api.Container{containerSpec},
+			convertContainersSpec(spec.Containers, volumeNames)...),
+		InitContainers: convertContainersSpec(spec.InitContainers, volumeNames),
+		Volumes:        volumes,
+		NodeSelector:   spec.NodeSelector,
 	}
 	if spec.ImagePullSecret != nil {
 		podSpec.ImagePullSecrets = []api.LocalObjectReference{{Name: *spec.ImagePullSecret}}
@@ -224,6 +441,15 @@ func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
 		return err
 	}
 
+	// Claims are created after the deployment, so that a rejected deployment does not leave them behind. Pods wait
+	// until their claims exist.
+	for _, claimSpec := range spec.PersistentVolumeClaims {
+		claim := newPersistentVolumeClaim(claimSpec, labels)
+		if _, err := client.CoreV1().PersistentVolumeClaims(spec.Namespace).Create(claim); err != nil {
+			return err
+		}
+	}
+
 	if len(spec.PortMappings) > 0 {
 		service := &api.Service{
 			ObjectMeta: objectMeta,
@@ -253,12 +479,204 @@ func DeployApp(spec *AppDeploymentSpec, client client.Interface) error {
 		}
 
 		_, err = client.CoreV1().Services(spec.Namespace).Create(service)
-		return err
+		if err != nil {
+			return err
+		}
+
+		if spec.Ingress != nil {
+			ingress := newIngress(spec, objectMeta)
+			_, err = client.ExtensionsV1beta1().Ingresses(spec.Namespace).Create(ingress)
+			return err
+		}
 	}
 
 	return nil
 }
 
+// setContainerOptions sets limits, probes, environment sources and volume mounts of the container. Volume names
+// maps claim names to names of pod volumes.
+func setContainerOptions(container *api.Container, options ContainerOptions, volumeNames map[string]string) {
+	if options.CpuLimit != nil || options.MemoryLimit != nil {
+		container.Resources.Limits = make(map[api.ResourceName]resource.Quantity)
+	}
+	if options.CpuLimit != nil {
+		container.Resources.Limits[api.ResourceCPU] = *options.CpuLimit
+	}
+	if options.MemoryLimit != nil {
+		container.Resources.Limits[api.ResourceMemory] = *options.MemoryLimit
+	}
+
+	container.LivenessProbe = convertProbeSpec(options.LivenessProbe)
+	container.ReadinessProbe = convertProbeSpec(options.ReadinessProbe)
+	container.EnvFrom = convertEnvFromSpec(options.EnvFrom)
+
+	for _, mount := range options.VolumeMounts {
+		container.VolumeMounts = append(container.VolumeMounts, api.VolumeMount{
+			Name:      volumeNames[mount.ClaimName],
+			MountPath: mount.MountPath,
+			SubPath:   mount.SubPath,
+			ReadOnly:  mount.ReadOnly,
+		})
+	}
+}
+
+func convertContainersSpec(specs []ContainerSpec, volumeNames map[string]string) []api.Container {
+	var result []api.Container
+	for _, spec := range specs {
+		container := api.Container{
+			Name:    spec.Name,
+			Image:   spec.ContainerImage,
+			Command: spec.ContainerCommand,
+			Args:    spec.ContainerCommandArgs,
+			Env:     convertEnvVarsSpec(spec.Variables),
+		}
+
+		if spec.CpuRequirement != nil || spec.MemoryRequirement != nil {
+			container.Resources.Requests = make(map[api.ResourceName]resource.Quantity)
+		}
+		if spec.CpuRequirement != nil {
+			container.Resources.Requests[api.ResourceCPU] = *spec.CpuRequirement
+		}
+		if spec.MemoryRequirement != nil {
+			container.Resources.Requests[api.ResourceMemory] = *spec.MemoryRequirement
+		}
+		setContainerOptions(&container, spec.ContainerOptions, volumeNames)
+
+		result = append(result, container)
+	}
+	return result
+}
+
+func convertProbeSpec(spec *ProbeSpec) *api.Probe {
+	if spec == nil {
+		return nil
+	}
+
+	probe := &api.Probe{
+		InitialDelaySeconds: spec.InitialDelaySeconds,
+		PeriodSeconds:       spec.PeriodSeconds,
+		TimeoutSeconds:      spec.TimeoutSeconds,
+		FailureThreshold:    spec.FailureThreshold,
+	}
+
+	port := intstr.FromInt(int(spec.Port))
+	switch spec.Type {
+	case ProbeTypeHTTP:
+		probe.HTTPGet = &api.HTTPGetAction{Path: spec.Path, Port: port}
+	case ProbeTypeTCP:
+		probe.TCPSocket = &api.TCPSocketAction{Port: port}
+	case ProbeTypeExec:
+		probe.Exec = &api.ExecAction{Command: spec.Command}
+	}
+	return probe
+}
+
+func convertEnvFromSpec(sources []EnvFromSource) []api.EnvFromSource {
+	var result []api.EnvFromSource
+	for _, source := range sources {
+		envFrom := api.EnvFromSource{Prefix: source.Prefix}
+		reference := api.LocalObjectReference{Name: source.Name}
+		if source.Kind == EnvFromSecret {
+			envFrom.SecretRef = &api.SecretEnvSource{LocalObjectReference: reference}
+		} else {
+			envFrom.ConfigMapRef = &api.ConfigMapEnvSource{LocalObjectReference: reference}
+		}
+		result = append(result, envFrom)
+	}
+	return result
+}
+
+// getVolumes returns volumes for all claims mounted by any container and names of the volumes by claim names.
+// Claim names can be DNS subdomains, but volume names have to be DNS labels, so volumes are numbered instead.
+func getVolumes(spec *AppDeploymentSpec) ([]api.Volume, map[string]string) {
+	options := []ContainerOptions{spec.ContainerOptions}
+	for _, container := range spec.InitContainers {
+		options = append(options, container.ContainerOptions)
+	}
+	for _, container := range spec.Containers {
+		options = append(options, container.ContainerOptions)
+	}
+
+	var result []api.Volume
+	names := map[string]string{}
+	for _, containerOptions := range options {
+		for _, mount := range containerOptions.VolumeMounts {
+			if _, ok := names[mount.ClaimName]; ok {
+				continue
+			}
+			names[mount.ClaimName] = fmt.Sprintf("volume-%d", len(result))
+
+			result = append(result, api.Volume{
+				Name: names[mount.ClaimName],
+				VolumeSource: api.VolumeSource{
+					PersistentVolumeClaim: &api.PersistentVolumeClaimVolumeSource{ClaimName: mount.ClaimName},
+				},
+			})
+		}
+	}
+	return result, names
+}
+
+func newPersistentVolumeClaim(spec PersistentVolumeClaimSpec, labels map[string]string) *api.PersistentVolumeClaim {
+	accessMode := spec.AccessMode
+	if len(accessMode) == 0 {
+		accessMode = api.ReadWriteOnce
+	}
+
+	return &api.PersistentVolumeClaim{
+		ObjectMeta: metaV1.ObjectMeta{Name: spec.Name, Labels: labels},
+		Spec: api.PersistentVolumeClaimSpec{
+			AccessModes:      []api.PersistentVolumeAccessMode{accessMode},
+			StorageClassName: spec.StorageClassName,
+			Resources: api.ResourceRequirements{
+				Requests: map[api.ResourceName]resource.Quantity{api.ResourceStorage: spec.Size},
+			},
+		},
+	}
+}
+
+func newIngress(spec *AppDeploymentSpec, objectMeta metaV1.ObjectMeta) *extensions.Ingress {
+	path := spec.Ingress.Path
+	if len(path) == 0 {
+		path = "/"
+	}
+
+	port := spec.Ingress.ServicePort
+	if port == 0 {
+		port = spec.PortMappings[0].Port
+	}
+
+	ingress := &extensions.Ingress{
+		ObjectMeta: objectMeta,
+		Spec: extensions.IngressSpec{
+			Rules: []extensions.IngressRule{{
+				Host: spec.Ingress.Host,
+				IngressRuleValue: extensions.IngressRuleValue{
+					HTTP: &extensions.HTTPIngressRuleValue{
+						Paths: []extensions.HTTPIngressPath{{
+							Path: path,
+							Backend: extensions.IngressBackend{
+								ServiceName: spec.Name,
+								ServicePort: intstr.FromInt(int(port)),
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}
+
+	if spec.Ingress.TLSSecret != nil {
+		tls := extensions.IngressTLS{SecretName: *spec.Ingress.TLSSecret}
+		if len(spec.Ingress.Host) > 0 {
+			tls.Hosts = []string{spec.Ingress.Host}
+		}
+		ingress.Spec.TLS = []extensions.IngressTLS{tls}
+	}
+
+	return ingress
+}
+
 // GetAvailableProtocols returns list of available protocols. Currently it is TCP and UDP.
 func GetAvailableProtocols() *Protocols {
 	return &Protocols{Protocols: []api.Protocol{api.ProtocolTCP, api.ProtocolUDP}}
@@ -298,64 +716,208 @@ func getLabelsMap(labels []Label) map[string]string {
 	return result
 }
 
-// DeployAppFromFile deploys an app based on the given yaml or json file.
-func DeployAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec) (bool, error) {
-	reader := strings.NewReader(spec.Content)
+// DeployAppFromFile deploys an app based on the given yaml or json file. Objects are created or updated using
+// server-side apply. Deployment continues after failures, result of every object is returned in the response.
+func DeployAppFromFile(cfg *rest.Config, spec *AppDeploymentFromFileSpec) (*AppDeploymentFromFileResponse, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeployObjectsFromFile(discoveryClient, dynamicClient, spec)
+}
+
+// DeployObjectsFromFile deploys objects from the given yaml or json file using given clients. Discovery information
+// is fetched at most once per group version during a single call. If validation is requested, all objects are
+// deployed in dry run mode first and nothing is persisted unless all of them succeed. Error is returned if no object
+// could be deployed, partial failures are reported in the response.
+func DeployObjectsFromFile(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface,
+	spec *AppDeploymentFromFileSpec) (*AppDeploymentFromFileResponse, error) {
 	log.Printf("Namespace for deploy from file: %s\n", spec.Namespace)
-	d := yaml.NewYAMLOrJSONDecoder(reader, 4096)
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+
+	objects := make([]*unstructured.Unstructured, 0)
+	d := yaml.NewYAMLOrJSONDecoder(strings.NewReader(spec.Content), 4096)
 	for {
-		data := unstructured.Unstructured{}
+		data := map[string]interface{}{}
 		if err := d.Decode(&data); err != nil {
 			if err == io.EOF {
-				return true, nil
+				break
 			}
-			return false, err
+			return nil, errors.NewBadRequest(err.Error())
 		}
 
-		version := data.GetAPIVersion()
-		kind := data.GetKind()
+		// Skip empty documents, e.g. the one after trailing document separator.
+		if len(data) == 0 {
+			continue
+		}
+		objects = append(objects, &unstructured.Unstructured{Object: data})
+	}
 
-		gv, err := schema.ParseGroupVersion(version)
-		if err != nil {
-			gv = schema.GroupVersion{Version: version}
+	if spec.Validate && !spec.DryRun {
+		validationSpec := *spec
+		validationSpec.DryRun = true
+		validation := deployObjects(mapper, dynamicClient, objects, &validationSpec)
+		if len(validation.Error) > 0 {
+			return nil, errors.NewBadRequest(fmt.Sprintf("Validation failed, nothing was deployed:\n%s",
+				validation.Error))
 		}
+	}
 
-		discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
-		if err != nil {
-			return false, err
+	response := deployObjects(mapper, dynamicClient, objects, spec)
+	for _, object := range response.Objects {
+		if object.Result != DeployResultFailed {
+			return response, nil
 		}
+	}
 
-		apiResourceList, err := discoveryClient.ServerResourcesForGroupVersion(version)
-		if err != nil {
-			return false, err
+	if len(response.Objects) > 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("No object was deployed:\n%s", response.Error))
+	}
+	return response, nil
+}
+
+// deployObjects deploys all objects one by one. Failure of an object does not stop deployment of the others.
+func deployObjects(mapper meta.RESTMapper, dynamicClient dynamic.Interface, objects []*unstructured.Unstructured,
+	spec *AppDeploymentFromFileSpec) *AppDeploymentFromFileResponse {
+	response := &AppDeploymentFromFileResponse{
+		Name:    spec.Name,
+		Content: spec.Content,
+		Objects: make([]DeployedObject, 0),
+	}
+	failures := make([]string, 0)
+
+	for _, object := range objects {
+		deployed := deployObject(mapper, dynamicClient, object.DeepCopy(), spec)
+		if deployed.Result == DeployResultFailed {
+			failures = append(failures, fmt.Sprintf("%s %s: %s", deployed.Kind, deployed.Name, deployed.Reason))
 		}
-		apiResources := apiResourceList.APIResources
-		var resource *metaV1.APIResource
-		for _, apiResource := range apiResources {
-			if apiResource.Kind == kind && !strings.Contains(apiResource.Name, "/") {
-				resource = &apiResource
-				break
-			}
+		response.Objects = append(response.Objects, deployed)
+	}
+
+	response.Error = strings.Join(failures, "\n")
+	return response
+}
+
+// Status returns the given success status if all objects were deployed, otherwise multi-status, as some of the
+// objects failed.
+func (response *AppDeploymentFromFileResponse) Status(success int) int {
+	if len(response.Error) > 0 {
+		return http.StatusMultiStatus
+	}
+	return success
+}
+
+// deployObject creates or updates single object from file and returns the result.
+func deployObject(mapper meta.RESTMapper, dynamicClient dynamic.Interface, object *unstructured.Unstructured,
+	spec *AppDeploymentFromFileSpec) DeployedObject {
+	result := DeployedObject{APIVersion: object.GetAPIVersion(), Kind: object.GetKind(), Name: object.GetName()}
+
+	gvk := object.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return result.failed(err)
+	}
+
+	var client dynamic.ResourceInterface = dynamicClient.Resource(mapping.Resource)
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		result.Namespace = spec.Namespace
+		if spec.Namespace == "_all" {
+			result.Namespace = object.GetNamespace()
 		}
-		if resource == nil {
-			return false, fmt.Errorf("unknown resource kind: %s", kind)
+		if len(result.Namespace) == 0 {
+			result.Namespace = metaV1.NamespaceDefault
 		}
+		object.SetNamespace(result.Namespace)
+		client = dynamicClient.Resource(mapping.Resource).Namespace(result.Namespace)
+	}
+
+	var dryRun []string
+	if spec.DryRun {
+		dryRun = []string{metaV1.DryRunAll}
+	}
 
-		dynamicClient, err := dynamic.NewForConfig(cfg)
+	// Objects with generated names cannot be applied, they are always created.
+	if len(object.GetName()) == 0 {
+		created, err := client.Create(object, metaV1.CreateOptions{DryRun: dryRun, FieldManager: dashboardapi.FieldManager})
 		if err != nil {
-			return false, err
+			return result.failed(err)
 		}
+		result.Name = created.GetName()
+		return result.deployed(nil, created, spec.DryRun)
+	}
 
-		groupVersionResource := schema.GroupVersionResource{Group: gv.Group, Version: gv.Version, Resource: resource.Name}
+	live, err := client.Get(object.GetName(), metaV1.GetOptions{})
+	if errors.IsNotFoundError(err) {
+		live, err = nil, nil
+	}
+	if err != nil {
+		return result.failed(err)
+	}
 
-		if strings.Compare(spec.Namespace, "_all") == 0 {
-			_, err = dynamicClient.Resource(groupVersionResource).Namespace(data.GetNamespace()).Create(&data, metaV1.CreateOptions{})
-		} else {
-			_, err = dynamicClient.Resource(groupVersionResource).Namespace(spec.Namespace).Create(&data, metaV1.CreateOptions{})
-		}
+	data, err := object.MarshalJSON()
+	if err != nil {
+		return result.failed(err)
+	}
+
+	// Conflicts with other field managers are forced, as the file describes the desired state of the object.
+	force := true
+	applied, err := client.Patch(object.GetName(), types.ApplyPatchType, data, metaV1.PatchOptions{
+		DryRun:       dryRun,
+		FieldManager: dashboardapi.FieldManager,
+		Force:        &force,
+	})
+	if err != nil {
+		return result.failed(err)
+	}
+
+	return result.deployed(live, applied, spec.DryRun)
+}
 
+func (object DeployedObject) failed(err error) DeployedObject {
+	object.Result = DeployResultFailed
+	object.Reason = errors.LocalizeError(err).Error()
+	return object
+}
+
+// deployed sets the result of the object based on the difference between live object and the deployed object.
+func (object DeployedObject) deployed(live, deployed *unstructured.Unstructured, dryRun bool) DeployedObject {
+	var liveData []byte
+	if live != nil {
+		data, err := live.MarshalJSON()
 		if err != nil {
-			return false, errors.LocalizeError(err)
+			return object.failed(err)
 		}
+		liveData = data
 	}
+
+	deployedData, err := deployed.MarshalJSON()
+	if err != nil {
+		return object.failed(err)
+	}
+
+	objectDiff, err := diff.Objects("live", "deployed", liveData, deployedData)
+	if err != nil {
+		return object.failed(err)
+	}
+
+	switch {
+	case live == nil:
+		object.Result = DeployResultCreated
+	case len(objectDiff) == 0:
+		object.Result = DeployResultUnchanged
+	default:
+		object.Result = DeployResultConfigured
+	}
+
+	if dryRun {
+		object.Diff = objectDiff
+	}
+
+	return object
 }
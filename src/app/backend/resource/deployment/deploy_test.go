@@ -15,16 +15,25 @@
 package deployment
 
 import (
+	"net/http"
 	"reflect"
 	"regexp"
 	"testing"
 
 	apps "k8s.io/api/apps/v1"
 	api "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes/fake"
 	core "k8s.io/client-go/testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/testutil"
 )
 
 func TestDeployApp(t *testing.T) {
@@ -177,6 +186,147 @@ func TestDeployWithResourceRequirements(t *testing.T) {
 	}
 }
 
+func TestDeployAppWithContainerOptions(t *testing.T) {
+	cpuLimit := resource.MustParse("500m")
+	storageClass := "fast"
+	spec := &AppDeploymentSpec{
+		Namespace:      "foo-namespace",
+		Name:           "foo-name",
+		ContainerImage: "foo-image",
+		PortMappings:   []PortMapping{{Port: 80, TargetPort: 8080, Protocol: api.ProtocolTCP}},
+		IsExternal:     true,
+		ContainerOptions: ContainerOptions{
+			CpuLimit:      &cpuLimit,
+			LivenessProbe: &ProbeSpec{Type: ProbeTypeHTTP, Path: "/healthz", Port: 8080, PeriodSeconds: 5},
+			EnvFrom:       []EnvFromSource{{Kind: EnvFromSecret, Name: "foo-secret", Prefix: "FOO_"}},
+			VolumeMounts:  []VolumeMountSpec{{ClaimName: "foo-data", MountPath: "/data"}},
+		},
+		Containers: []ContainerSpec{{
+			Name:             "foo-sidecar",
+			ContainerImage:   "sidecar-image",
+			ContainerCommand: []string{"sidecar", "--verbose"},
+			ContainerOptions: ContainerOptions{
+				ReadinessProbe: &ProbeSpec{Type: ProbeTypeExec, Command: []string{"true"}},
+				VolumeMounts:   []VolumeMountSpec{{ClaimName: "foo-data", MountPath: "/data", ReadOnly: true}},
+			},
+		}},
+		InitContainers: []ContainerSpec{{
+			Name:             "foo-init",
+			ContainerImage:   "init-image",
+			ContainerOptions: ContainerOptions{EnvFrom: []EnvFromSource{{Kind: EnvFromConfigMap, Name: "foo-config"}}},
+		}},
+		PersistentVolumeClaims: []PersistentVolumeClaimSpec{
+			{Name: "foo-data", Size: resource.MustParse("1Gi"), StorageClassName: &storageClass},
+		},
+		Ingress:      &IngressSpec{Host: "foo.example.com"},
+		NodeSelector: map[string]string{"disk": "ssd"},
+	}
+	testClient := fake.NewSimpleClientset()
+
+	if err := DeployApp(spec, testClient); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	actions := testClient.Actions()
+	if len(actions) != 4 {
+		t.Fatalf("Expected 4 create actions but got %d", len(actions))
+	}
+
+	claim := actions[1].(core.CreateActionImpl).GetObject().(*api.PersistentVolumeClaim)
+	expectedClaim := api.PersistentVolumeClaimSpec{
+		AccessModes:      []api.PersistentVolumeAccessMode{api.ReadWriteOnce},
+		StorageClassName: &storageClass,
+		Resources: api.ResourceRequirements{
+			Requests: map[api.ResourceName]resource.Quantity{api.ResourceStorage: resource.MustParse("1Gi")},
+		},
+	}
+	if claim.Name != "foo-data" || !reflect.DeepEqual(claim.Spec, expectedClaim) {
+		t.Errorf("Expected claim foo-data with spec %#v but got %#v", expectedClaim, claim)
+	}
+
+	podSpec := actions[0].(core.CreateActionImpl).GetObject().(*apps.Deployment).Spec.Template.Spec
+	expectedVolumes := []api.Volume{{
+		Name: "volume-0",
+		VolumeSource: api.VolumeSource{
+			PersistentVolumeClaim: &api.PersistentVolumeClaimVolumeSource{ClaimName: "foo-data"},
+		},
+	}}
+	if !reflect.DeepEqual(podSpec.Volumes, expectedVolumes) {
+		t.Errorf("Expected volumes %#v but got %#v", expectedVolumes, podSpec.Volumes)
+	}
+	if !reflect.DeepEqual(podSpec.NodeSelector, spec.NodeSelector) {
+		t.Errorf("Expected node selector %#v but got %#v", spec.NodeSelector, podSpec.NodeSelector)
+	}
+	if len(podSpec.Containers) != 2 || len(podSpec.InitContainers) != 1 {
+		t.Fatalf("Expected 2 containers and 1 init container but got %#v and %#v", podSpec.Containers,
+			podSpec.InitContainers)
+	}
+
+	main := podSpec.Containers[0]
+	expectedLimits := api.ResourceList{api.ResourceCPU: cpuLimit}
+	if !reflect.DeepEqual(main.Resources.Limits, expectedLimits) {
+		t.Errorf("Expected limits %#v but got %#v", expectedLimits, main.Resources.Limits)
+	}
+	expectedProbe := &api.Probe{
+		Handler:       api.Handler{HTTPGet: &api.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)}},
+		PeriodSeconds: 5,
+	}
+	if !reflect.DeepEqual(main.LivenessProbe, expectedProbe) {
+		t.Errorf("Expected liveness probe %#v but got %#v", expectedProbe, main.LivenessProbe)
+	}
+	expectedEnvFrom := []api.EnvFromSource{{
+		Prefix:    "FOO_",
+		SecretRef: &api.SecretEnvSource{LocalObjectReference: api.LocalObjectReference{Name: "foo-secret"}},
+	}}
+	if !reflect.DeepEqual(main.EnvFrom, expectedEnvFrom) {
+		t.Errorf("Expected env from %#v but got %#v", expectedEnvFrom, main.EnvFrom)
+	}
+
+	sidecar := podSpec.Containers[1]
+	if sidecar.Name != "foo-sidecar" || sidecar.Image != "sidecar-image" ||
+		!reflect.DeepEqual(sidecar.Command, []string{"sidecar", "--verbose"}) ||
+		sidecar.ReadinessProbe.Exec == nil || !sidecar.VolumeMounts[0].ReadOnly ||
+		sidecar.VolumeMounts[0].Name != "volume-0" {
+		t.Errorf("Unexpected sidecar container %#v", sidecar)
+	}
+
+	initContainer := podSpec.InitContainers[0]
+	if initContainer.Name != "foo-init" || initContainer.EnvFrom[0].ConfigMapRef == nil {
+		t.Errorf("Unexpected init container %#v", initContainer)
+	}
+
+	ingress := actions[3].(core.CreateActionImpl).GetObject().(*extensions.Ingress)
+	rule := ingress.Spec.Rules[0]
+	backend := rule.HTTP.Paths[0].Backend
+	if rule.Host != "foo.example.com" || rule.HTTP.Paths[0].Path != "/" || backend.ServiceName != "foo-name" ||
+		backend.ServicePort != intstr.FromInt(80) {
+		t.Errorf("Unexpected ingress rule %#v", rule)
+	}
+}
+
+func TestDeployAppRejectedDeploymentCreatesNoClaims(t *testing.T) {
+	spec := &AppDeploymentSpec{
+		Namespace:              "foo-namespace",
+		Name:                   "foo-name",
+		ContainerImage:         "foo-image",
+		PersistentVolumeClaims: []PersistentVolumeClaimSpec{{Name: "foo-data", Size: resource.MustParse("1Gi")}},
+	}
+	testClient := fake.NewSimpleClientset()
+	testClient.PrependReactor("create", "deployments", func(action core.Action) (bool, runtime.Object, error) {
+		return true, nil, k8serrors.NewForbidden(apps.Resource("deployments"), spec.Name, nil)
+	})
+
+	if err := DeployApp(spec, testClient); err == nil {
+		t.Fatal("Expected error but got nil")
+	}
+
+	for _, action := range testClient.Actions() {
+		if action.GetResource().Resource == "persistentvolumeclaims" {
+			t.Errorf("Expected no claim to be created but got %#v", action)
+		}
+	}
+}
+
 func TestGetAvailableProtocols(t *testing.T) {
 	expected := &Protocols{Protocols: []api.Protocol{"TCP", "UDP"}}
 
@@ -186,3 +336,152 @@ func TestGetAvailableProtocols(t *testing.T) {
 			expected, actual)
 	}
 }
+
+func newConfigMap(name, value string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "ns"},
+		"data":       map[string]interface{}{"key": value},
+	}}
+}
+
+func TestDeployObjectsFromFile(t *testing.T) {
+	discoveryClient := testutil.NewDiscoveryClient(&metaV1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metaV1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+			{Name: "namespaces", Kind: "Namespace", Namespaced: false},
+		},
+	})
+	dynamicClient := testutil.NewApplyClient(newConfigMap("unchanged", "value"), newConfigMap("configured", "old"))
+
+	spec := &AppDeploymentFromFileSpec{
+		Name:      "file",
+		Namespace: "ns",
+		Content: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unchanged
+data:
+  key: value
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: configured
+data:
+  key: new
+---
+apiVersion: example.com/v1
+kind: Unknown
+metadata:
+  name: unknown
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: created
+---
+`,
+	}
+
+	actual, err := DeployObjectsFromFile(discoveryClient, dynamicClient, spec)
+	if err != nil {
+		t.Fatalf("DeployObjectsFromFile(): unexpected error: %s", err.Error())
+	}
+
+	results := make([]DeployResult, 0)
+	for _, object := range actual.Objects {
+		results = append(results, object.Result)
+	}
+	expected := []DeployResult{DeployResultUnchanged, DeployResultConfigured, DeployResultFailed, DeployResultCreated}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("DeployObjectsFromFile() returned results %v, expected %v", results, expected)
+	}
+
+	if actual.Objects[0].Namespace != "ns" || actual.Objects[3].Namespace != "" {
+		t.Errorf("DeployObjectsFromFile() returned namespaces %s and %s, expected ns and none",
+			actual.Objects[0].Namespace, actual.Objects[3].Namespace)
+	}
+
+	if len(actual.Objects[2].Reason) == 0 || len(actual.Error) == 0 {
+		t.Errorf("DeployObjectsFromFile() returned no reason of the failure")
+	}
+
+	if len(actual.Objects[1].Diff) != 0 {
+		t.Errorf("DeployObjectsFromFile() returned diff outside of dry run mode")
+	}
+
+	configured, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).Namespace("ns").Get("configured", metaV1.GetOptions{})
+	if err != nil || !reflect.DeepEqual(configured.Object["data"], map[string]interface{}{"key": "new"}) {
+		t.Errorf("DeployObjectsFromFile() did not update config map, got %v", configured)
+	}
+}
+
+func TestDeployObjectsFromFileFailed(t *testing.T) {
+	discoveryClient := testutil.NewDiscoveryClient(&metaV1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metaV1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}},
+	})
+	configMap := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: created\n"
+	unknown := "apiVersion: example.com/v1\nkind: Unknown\nmetadata:\n  name: unknown\n"
+
+	cases := []struct {
+		info           string
+		content        string
+		validate       bool
+		expectedError  bool
+		expectedStatus int
+	}{
+		{"all objects failed", unknown, false, true, 0},
+		{"partial failure", configMap + "---\n" + unknown, false, false, http.StatusMultiStatus},
+		{"partial failure with validation", configMap + "---\n" + unknown, true, true, 0},
+		{"success with validation", configMap, true, false, http.StatusCreated},
+	}
+
+	for _, c := range cases {
+		spec := &AppDeploymentFromFileSpec{Namespace: "ns", Content: c.content, Validate: c.validate}
+		actual, err := DeployObjectsFromFile(discoveryClient, testutil.NewApplyClient(), spec)
+		if c.expectedError {
+			if err == nil {
+				t.Errorf("DeployObjectsFromFile() with %s: expected error, got %#v", c.info, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("DeployObjectsFromFile() with %s: unexpected error: %s", c.info, err.Error())
+			continue
+		}
+		if status := actual.Status(http.StatusCreated); status != c.expectedStatus {
+			t.Errorf("DeployObjectsFromFile() with %s returned status %d, expected %d", c.info, status,
+				c.expectedStatus)
+		}
+	}
+}
+
+func TestDeployObjectsFromFileDryRun(t *testing.T) {
+	discoveryClient := testutil.NewDiscoveryClient(&metaV1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metaV1.APIResource{{Name: "configmaps", Kind: "ConfigMap", Namespaced: true}},
+	})
+	dynamicClient := testutil.NewApplyClient(newConfigMap("configured", "old"))
+
+	spec := &AppDeploymentFromFileSpec{
+		Namespace: "ns",
+		DryRun:    true,
+		Content:   `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "configured"}, "data": {"key": "new"}}`,
+	}
+
+	actual, err := DeployObjectsFromFile(discoveryClient, dynamicClient, spec)
+	if err != nil {
+		t.Fatalf("DeployObjectsFromFile(): unexpected error: %s", err.Error())
+	}
+
+	expected := "--- live\n+++ deployed\n@@ -1,6 +1,6 @@\n apiVersion: v1\n data:\n-  key: old\n+  key: new\n" +
+		" kind: ConfigMap\n metadata:\n   name: configured\n"
+	if len(actual.Objects) != 1 || actual.Objects[0].Diff != expected {
+		t.Errorf("DeployObjectsFromFile() returned %#v, expected diff:\n%s", actual.Objects, expected)
+	}
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	pdb "github.com/kubernetes/dashboard/src/app/backend/resource/poddisruptionbudget"
 	apps "k8s.io/api/apps/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -74,6 +75,9 @@ type DeploymentDetail struct {
 	// Optional field that specifies the number of old Replica Sets to retain to allow rollback.
 	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit"`
 
+	// Pod disruption budgets that cover pods of the deployment.
+	PodDisruptionBudgets []pdb.PodDisruptionBudget `json:"podDisruptionBudgets"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -124,6 +128,12 @@ func GetDeploymentDetail(client client.Interface, namespace string, deploymentNa
 		return nil, criticalError
 	}
 
+	pdbs, err := pdb.GetMatchingPodDisruptionBudgets(client, namespace, deployment.Spec.Template.Labels)
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
 	// Extra Info
 	var rollingUpdateStrategy *RollingUpdateStrategy
 	if deployment.Spec.Strategy.RollingUpdate != nil {
@@ -142,6 +152,7 @@ func GetDeploymentDetail(client client.Interface, namespace string, deploymentNa
 		MinReadySeconds:       deployment.Spec.MinReadySeconds,
 		RollingUpdateStrategy: rollingUpdateStrategy,
 		RevisionHistoryLimit:  deployment.Spec.RevisionHistoryLimit,
+		PodDisruptionBudgets:  pdbs,
 		Errors:                nonCriticalErrors,
 	}, nil
 }
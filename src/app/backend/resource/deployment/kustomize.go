@@ -0,0 +1,290 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/kustomize/k8sdeps"
+	"sigs.k8s.io/kustomize/pkg/constants"
+	"sigs.k8s.io/kustomize/pkg/fs"
+	"sigs.k8s.io/kustomize/pkg/git"
+	"sigs.k8s.io/kustomize/pkg/loader"
+	"sigs.k8s.io/kustomize/pkg/target"
+	"sigs.k8s.io/kustomize/pkg/types"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// maxKustomizationArchiveSize is the maximum total size of extracted files of the kustomization archive.
+const maxKustomizationArchiveSize = 10 * 1024 * 1024
+
+// AppDeploymentFromKustomizationSpec is a specification for deployment from archive with kustomization
+type AppDeploymentFromKustomizationSpec struct {
+	// Name of the archive file
+	Name string `json:"name"`
+
+	// Namespace that objects should be deployed in
+	Namespace string `json:"namespace"`
+
+	// Content of tar, gzipped tar or zip archive
+	Archive []byte `json:"archive"`
+
+	// Path of the kustomization directory inside of the archive. If empty, the directory of the top-most
+	// kustomization file is used.
+	Path string `json:"path"`
+
+	// Whether only preview the changes without persisting them
+	DryRun bool `json:"dryRun"`
+}
+
+// DeployAppFromKustomization builds kustomization from the given archive and deploys rendered objects the same way
+// as DeployAppFromFile does.
+func DeployAppFromKustomization(cfg *rest.Config, spec *AppDeploymentFromKustomizationSpec) (
+	*AppDeploymentFromFileResponse, error) {
+	fileSpec, err := RenderKustomization(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeployAppFromFile(cfg, fileSpec)
+}
+
+// RenderKustomization builds kustomization from the given archive in memory. Returned spec contains rendered
+// manifests, which can be reviewed and deployed with DeployAppFromFile. Remote bases are rejected, because kustomize
+// would clone them from Git.
+func RenderKustomization(spec *AppDeploymentFromKustomizationSpec) (*AppDeploymentFromFileSpec, error) {
+	fSys := fs.MakeFakeFS()
+	files, err := extractArchive(spec.Archive, fSys)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := getKustomizationRoot(files, spec.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkRemoteBases(files, fSys); err != nil {
+		return nil, err
+	}
+
+	factory := k8sdeps.NewFactory()
+	ldr, err := loader.NewLoader(root, fSys)
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	defer ldr.Cleanup()
+
+	kustTarget, err := target.NewKustTarget(ldr, factory.ResmapF, factory.TransformerF)
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+
+	resources, err := kustTarget.MakeCustomizedResMap()
+	if err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+
+	content, err := resources.EncodeAsYaml()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AppDeploymentFromFileSpec{
+		Name:      spec.Name,
+		Namespace: spec.Namespace,
+		Content:   string(content),
+		DryRun:    spec.DryRun,
+	}, nil
+}
+
+// extractArchive extracts tar, gzipped tar or zip archive into the file system. Format is detected from the
+// content. Paths of all extracted files are returned.
+func extractArchive(archive []byte, fSys fs.FileSystem) ([]string, error) {
+	extractor := &archiveExtractor{fSys: fSys, remaining: maxKustomizationArchiveSize, files: make([]string, 0)}
+
+	switch {
+	case bytes.HasPrefix(archive, []byte("PK\x03\x04")):
+		err := extractor.extractZip(archive)
+		return extractor.files, err
+	case bytes.HasPrefix(archive, []byte{0x1f, 0x8b}):
+		reader, err := gzip.NewReader(bytes.NewReader(archive))
+		if err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("Invalid gzip archive: %s", err.Error()))
+		}
+		err = extractor.extractTar(reader)
+		return extractor.files, err
+	default:
+		err := extractor.extractTar(bytes.NewReader(archive))
+		return extractor.files, err
+	}
+}
+
+type archiveExtractor struct {
+	fSys      fs.FileSystem
+	remaining int64
+	files     []string
+}
+
+func (extractor *archiveExtractor) extractTar(reader io.Reader) error {
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.NewBadRequest(fmt.Sprintf("Invalid tar archive: %s", err.Error()))
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := extractor.writeFile(header.Name, tarReader); err != nil {
+			return err
+		}
+	}
+}
+
+func (extractor *archiveExtractor) extractZip(archive []byte) error {
+	zipReader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid zip archive: %s", err.Error()))
+	}
+
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return errors.NewBadRequest(fmt.Sprintf("Invalid zip archive: %s", err.Error()))
+		}
+		err = extractor.writeFile(file.Name, reader)
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeFile writes the file to the file system. File names are cleaned, so that all files stay inside of its root.
+func (extractor *archiveExtractor) writeFile(name string, reader io.Reader) error {
+	content, err := ioutil.ReadAll(io.LimitReader(reader, extractor.remaining+1))
+	if err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid archive: %s", err.Error()))
+	}
+
+	extractor.remaining -= int64(len(content))
+	if extractor.remaining < 0 {
+		return errors.NewBadRequest(fmt.Sprintf("Extracted archive exceeds maximum size of %d bytes",
+			maxKustomizationArchiveSize))
+	}
+
+	name = path.Clean("/" + name)
+	extractor.files = append(extractor.files, name)
+	return extractor.fSys.WriteFile(name, content)
+}
+
+// getKustomizationRoot returns the kustomization directory. If path is not given, directory of the top-most
+// kustomization file is used. It has to be unique, e.g. archive with several overlays requires the path to be set.
+func getKustomizationRoot(files []string, kustomizationPath string) (string, error) {
+	if len(kustomizationPath) > 0 {
+		return path.Clean("/" + kustomizationPath), nil
+	}
+
+	roots := make([]string, 0)
+	for _, file := range files {
+		if !isKustomizationFile(file) {
+			continue
+		}
+
+		root := path.Dir(file)
+		switch {
+		case len(roots) == 0 || depth(root) == depth(roots[0]):
+			roots = append(roots, root)
+		case depth(root) < depth(roots[0]):
+			roots = []string{root}
+		}
+	}
+
+	if len(roots) == 0 {
+		return "", errors.NewBadRequest("Archive does not contain kustomization file")
+	}
+	if len(roots) > 1 {
+		return "", errors.NewBadRequest(fmt.Sprintf("Archive contains several kustomizations: %s, choose one of them",
+			strings.Join(roots, ", ")))
+	}
+
+	return roots[0], nil
+}
+
+// checkRemoteBases returns an error if any kustomization file refers to a base or resource in a Git repository.
+func checkRemoteBases(files []string, fSys fs.FileSystem) error {
+	for _, file := range files {
+		if !isKustomizationFile(file) {
+			continue
+		}
+
+		content, err := fSys.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		kustomization := new(types.Kustomization)
+		if err := yaml.Unmarshal(content, kustomization); err != nil {
+			return errors.NewBadRequest(fmt.Sprintf("Invalid kustomization file %s: %s", file, err.Error()))
+		}
+
+		for _, entry := range append(kustomization.Bases, kustomization.Resources...) {
+			if _, err := git.NewRepoSpecFromUrl(entry); err == nil {
+				return errors.NewBadRequest(fmt.Sprintf("Remote base %s in %s is not supported", entry, file))
+			}
+		}
+	}
+
+	return nil
+}
+
+func isKustomizationFile(file string) bool {
+	for _, name := range constants.KustomizationFileNames {
+		if path.Base(file) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func depth(dir string) int {
+	if dir == "/" {
+		return 0
+	}
+	return strings.Count(dir, "/")
+}
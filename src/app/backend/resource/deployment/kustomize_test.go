@@ -0,0 +1,167 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deployment
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+)
+
+var kustomizationFiles = map[string]string{
+	"app/base/kustomization.yaml":         "resources:\n- configmap.yaml\n",
+	"app/base/configmap.yaml":             "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\ndata:\n  key: value\n",
+	"app/overlays/dev/kustomization.yaml": "namePrefix: dev-\nbases:\n- ../../base\n",
+	"app/overlays/prod/kustomization.yaml": "namePrefix: prod-\nbases:\n- ../../base\n" +
+		"configMapGenerator:\n- name: generated\n  literals:\n  - env=prod\n",
+}
+
+func newTarGzArchive(t *testing.T, files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tarWriter.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tarWriter.Close()
+	gzipWriter.Close()
+	return buffer.Bytes()
+}
+
+func newZipArchive(t *testing.T, files map[string]string) []byte {
+	buffer := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buffer)
+	for name, content := range files {
+		writer, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := writer.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	zipWriter.Close()
+	return buffer.Bytes()
+}
+
+func TestRenderKustomization(t *testing.T) {
+	archives := map[string][]byte{
+		"tar.gz": newTarGzArchive(t, kustomizationFiles),
+		"zip":    newZipArchive(t, kustomizationFiles),
+	}
+
+	for format, archive := range archives {
+		spec := &AppDeploymentFromKustomizationSpec{
+			Name:      "app." + format,
+			Namespace: "ns",
+			Archive:   archive,
+			Path:      "app/overlays/prod",
+			DryRun:    true,
+		}
+
+		actual, err := RenderKustomization(spec)
+		if err != nil {
+			t.Fatalf("RenderKustomization() for %s: unexpected error: %s", format, err.Error())
+		}
+
+		if actual.Name != spec.Name || actual.Namespace != "ns" || !actual.DryRun {
+			t.Errorf("RenderKustomization() for %s returned spec %#v", format, actual)
+		}
+
+		for _, expected := range []string{"name: prod-config", "name: prod-generated-", "env: prod"} {
+			if !strings.Contains(actual.Content, expected) {
+				t.Errorf("RenderKustomization() for %s returned manifests without %q:\n%s", format, expected,
+					actual.Content)
+			}
+		}
+	}
+}
+
+func TestRenderKustomizationRoot(t *testing.T) {
+	cases := []struct {
+		info          string
+		path          string
+		expected      string
+		expectedError string
+	}{
+		{"top-most kustomization", "", "name: config", ""},
+		{"explicit path", "/app/overlays/dev/", "name: dev-config", ""},
+		{"missing kustomization", "app/overlays", "", "app/overlays"},
+	}
+
+	// Without the base kustomization, both overlays are the top-most ones.
+	ambiguous := map[string]string{}
+	for name, content := range kustomizationFiles {
+		if name != "app/base/kustomization.yaml" {
+			ambiguous[name] = content
+		}
+	}
+
+	archive := newTarGzArchive(t, kustomizationFiles)
+	for _, c := range cases {
+		actual, err := RenderKustomization(&AppDeploymentFromKustomizationSpec{Archive: archive, Path: c.path})
+		if len(c.expectedError) > 0 {
+			if err == nil || !strings.Contains(err.Error(), c.expectedError) {
+				t.Errorf("RenderKustomization() for %s: expected error containing %q, got %v", c.info,
+					c.expectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("RenderKustomization() for %s: unexpected error: %s", c.info, err.Error())
+		}
+		if !strings.Contains(actual.Content, c.expected) {
+			t.Errorf("RenderKustomization() for %s returned manifests without %q:\n%s", c.info, c.expected,
+				actual.Content)
+		}
+	}
+
+	_, err := RenderKustomization(&AppDeploymentFromKustomizationSpec{Archive: newZipArchive(t, ambiguous)})
+	if err == nil || !strings.Contains(err.Error(), "several kustomizations") {
+		t.Errorf("RenderKustomization(): expected error for several kustomizations, got %v", err)
+	}
+}
+
+func TestRenderKustomizationRemoteBase(t *testing.T) {
+	cases := []struct {
+		info          string
+		kustomization string
+	}{
+		{"remote base", "bases:\n- github.com/kubernetes-sigs/kustomize//examples/helloWorld?ref=v2.0.3\n"},
+		{"remote resource", "resources:\n- https://github.com/kubernetes-sigs/kustomize//examples/helloWorld\n"},
+	}
+
+	for _, c := range cases {
+		files := map[string]string{}
+		for name, content := range kustomizationFiles {
+			files[name] = content
+		}
+		files["app/overlays/dev/kustomization.yaml"] = c.kustomization
+
+		_, err := RenderKustomization(&AppDeploymentFromKustomizationSpec{Archive: newTarGzArchive(t, files)})
+		if err == nil || !strings.Contains(err.Error(), "Remote base") {
+			t.Errorf("RenderKustomization() with %s: expected remote base error, got %v", c.info, err)
+		}
+	}
+}
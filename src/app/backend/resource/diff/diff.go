@@ -0,0 +1,48 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// contextLines is the number of unchanged lines shown around every change in the unified diff.
+const contextLines = 3
+
+// Unified returns the unified diff of two texts. Empty string is returned if the texts are equal.
+func Unified(fromName, toName, from, to string) string {
+	// Error is returned only when writing to the underlying buffer fails, which never happens for a string diff.
+	result, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(from),
+		B:        splitLines(to),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  contextLines,
+	})
+	return result
+}
+
+// splitLines splits the text into lines keeping the line ends, as expected by difflib. Unlike difflib.SplitLines it
+// returns no lines for empty text.
+func splitLines(text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(strings.TrimSuffix(text, "\n"), "\n")
+	lines[len(lines)-1] += "\n"
+	return lines
+}
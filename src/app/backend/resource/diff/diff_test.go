@@ -0,0 +1,103 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestUnified(t *testing.T) {
+	cases := []struct {
+		info     string
+		from, to string
+		expected string
+	}{
+		{"equal texts", "a\nb\n", "a\nb\n", ""},
+		{
+			"changed line",
+			"a\nb\nc\n", "a\nx\nc\n",
+			"--- from\n+++ to\n@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+		{
+			"added to empty",
+			"", "a\nb\n",
+			"--- from\n+++ to\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			"distant changes in separate hunks",
+			"1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n", "x\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ny\n",
+			"--- from\n+++ to\n@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+y\n",
+		},
+		{
+			"close changes in one hunk",
+			"1\n2\n3\n4\n5\n", "x\n2\n3\n4\ny\n",
+			"--- from\n+++ to\n@@ -1,5 +1,5 @@\n-1\n+x\n 2\n 3\n 4\n-5\n+y\n",
+		},
+	}
+
+	for _, c := range cases {
+		actual := Unified("from", "to", c.from, c.to)
+		if actual != c.expected {
+			t.Errorf("Unified() for %s:\n%s\nexpected:\n%s", c.info, actual, c.expected)
+		}
+	}
+}
+
+func TestUnifiedLargeTexts(t *testing.T) {
+	from, to := &strings.Builder{}, &strings.Builder{}
+	for i := 0; i < 50000; i++ {
+		fmt.Fprintf(from, "from %d\n", i)
+		fmt.Fprintf(to, "to %d\n", i)
+	}
+
+	actual := Unified("from", "to", from.String(), to.String())
+	if !strings.HasPrefix(actual, "--- from\n+++ to\n@@ -1,50000 +1,50000 @@\n-from 0\n") {
+		t.Errorf("Unified() for large texts returned diff starting with:\n%s", actual[:100])
+	}
+}
+
+func TestNewDryRunResult(t *testing.T) {
+	live := &runtime.Unknown{Raw: []byte(`{"kind":"ConfigMap","metadata":{"name":"a","resourceVersion":"1"},` +
+		`"data":{"key":"old"}}`)}
+	result := &runtime.Unknown{Raw: []byte(`{"kind":"ConfigMap","metadata":{"name":"a","resourceVersion":"2",` +
+		`"managedFields":[{"manager":"dashboard"}]},"data":{"key":"new"}}`)}
+
+	actual, err := NewDryRunResult(live, result)
+	if err != nil {
+		t.Fatalf("NewDryRunResult(): unexpected error: %s", err.Error())
+	}
+
+	if actual.Object != result {
+		t.Errorf("NewDryRunResult() returned object %v, expected %v", actual.Object, result)
+	}
+
+	expected := "--- live\n+++ result\n@@ -1,5 +1,5 @@\n data:\n-  key: old\n+  key: new\n kind: ConfigMap\n" +
+		" metadata:\n   name: a\n"
+	if actual.Diff != expected {
+		t.Errorf("NewDryRunResult() returned diff:\n%s\nexpected:\n%s", actual.Diff, expected)
+	}
+
+	created, err := NewDryRunResult(nil, result)
+	if err != nil {
+		t.Fatalf("NewDryRunResult(): unexpected error: %s", err.Error())
+	}
+	if !strings.HasPrefix(created.Diff, "--- live\n+++ result\n@@ -0,0 +1,5 @@\n+data:\n") {
+		t.Errorf("NewDryRunResult() for new object returned diff:\n%s", created.Diff)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// volatileMetadataFields are metadata fields changed by the server on every write. They are left out of object
+// diffs, as they do not describe the change made by the user.
+var volatileMetadataFields = []string{"managedFields", "resourceVersion", "generation"}
+
+// DryRunResult is the result of a write executed in dry run mode.
+type DryRunResult struct {
+	// Object as it would be persisted.
+	Object *runtime.Unknown `json:"object"`
+
+	// Unified diff between normalized YAML of the live object and the object as it would be persisted. Empty if
+	// nothing would change.
+	Diff string `json:"diff"`
+}
+
+// NewDryRunResult creates dry run result from the live object and the object returned by the dry run request, both
+// as returned by the resource verber. Live object is nil if it does not exist yet.
+func NewDryRunResult(live, result runtime.Object) (*DryRunResult, error) {
+	unknown, ok := result.(*runtime.Unknown)
+	if !ok {
+		return nil, errors.NewUnexpectedObject(result)
+	}
+
+	var liveRaw []byte
+	if live != nil {
+		liveUnknown, ok := live.(*runtime.Unknown)
+		if !ok {
+			return nil, errors.NewUnexpectedObject(live)
+		}
+		liveRaw = liveUnknown.Raw
+	}
+
+	diff, err := Objects("live", "result", liveRaw, unknown.Raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DryRunResult{Object: unknown, Diff: diff}, nil
+}
+
+// Objects returns the unified diff of normalized YAML representations of two objects, given as JSON or YAML. Empty
+// object is treated as nonexistent.
+func Objects(fromName, toName string, from, to []byte) (string, error) {
+	fromYAML, err := ToNormalizedYAML(from)
+	if err != nil {
+		return "", err
+	}
+
+	toYAML, err := ToNormalizedYAML(to)
+	if err != nil {
+		return "", err
+	}
+
+	return Unified(fromName, toName, string(fromYAML), string(toYAML)), nil
+}
+
+// ToNormalizedYAML converts object given as JSON or YAML into YAML with sorted keys and without volatile metadata
+// fields.
+func ToNormalizedYAML(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	object := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &object); err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+
+	for _, field := range volatileMetadataFields {
+		unstructured.RemoveNestedField(object, "metadata", field)
+	}
+
+	return yaml.Marshal(object)
+}
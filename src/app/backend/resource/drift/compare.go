@@ -0,0 +1,140 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// quantityFields are fields whose values are resource quantities. The server converts quantities to canonical form,
+// e.g. 0.5 to 500m, so they are compared by value.
+var quantityFields = map[string]bool{"limits": true, "requests": true, "hard": true, "capacity": true}
+
+// compareFields walks the desired value and appends every field whose live value differs. Fields that exist only in
+// the live object are not compared, as they are usually defaulted by the server.
+func compareFields(path string, desired, live interface{}, quantities bool, result []FieldDrift) []FieldDrift {
+	if desired == nil {
+		return result
+	}
+
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return append(result, FieldDrift{Path: path, Desired: desired, Live: live})
+		}
+
+		for _, key := range sortedKeys(desiredValue) {
+			result = compareFields(joinPath(path, key), desiredValue[key], liveMap[key],
+				quantities || quantityFields[key], result)
+		}
+		return result
+	case []interface{}:
+		liveList, ok := live.([]interface{})
+		if !ok {
+			return append(result, FieldDrift{Path: path, Desired: desired, Live: live})
+		}
+		return compareLists(path, desiredValue, liveList, result)
+	default:
+		if !equalValues(desired, live, quantities) {
+			return append(result, FieldDrift{Path: path, Desired: desired, Live: live})
+		}
+		return result
+	}
+}
+
+// compareLists compares list items by their names if all desired items are named, e.g. containers or volumes.
+// Other lists are compared by index and are reported as a whole if their length differs.
+func compareLists(path string, desired, live []interface{}, result []FieldDrift) []FieldDrift {
+	if !allNamed(desired) || !allNamed(live) {
+		if len(desired) != len(live) {
+			return append(result, FieldDrift{Path: path, Desired: desired, Live: live})
+		}
+
+		for i := range desired {
+			result = compareFields(fmt.Sprintf("%s[%d]", path, i), desired[i], live[i], false, result)
+		}
+		return result
+	}
+
+	liveItems := make(map[interface{}]interface{})
+	for _, item := range live {
+		liveItems[item.(map[string]interface{})["name"]] = item
+	}
+
+	for _, item := range desired {
+		name := item.(map[string]interface{})["name"]
+		result = compareFields(fmt.Sprintf("%s[name=%v]", path, name), item, liveItems[name], false, result)
+	}
+	return result
+}
+
+func allNamed(list []interface{}) bool {
+	for _, item := range list {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := object["name"].(string); !ok {
+			return false
+		}
+	}
+	return len(list) > 0
+}
+
+func equalValues(desired, live interface{}, quantities bool) bool {
+	if reflect.DeepEqual(desired, live) {
+		return true
+	}
+	if !quantities || live == nil {
+		return false
+	}
+
+	desiredQuantity, err := resource.ParseQuantity(fmt.Sprint(desired))
+	if err != nil {
+		return false
+	}
+	liveQuantity, err := resource.ParseQuantity(fmt.Sprint(live))
+	if err != nil {
+		return false
+	}
+	return desiredQuantity.Cmp(liveQuantity) == 0
+}
+
+func joinPath(path, field string) string {
+	if len(path) == 0 {
+		return field
+	}
+	return path + "." + field
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// isPrefixPath returns true if the field at the given path contains the other field or is the same field.
+func isPrefixPath(path, other string) bool {
+	return path == other || strings.HasPrefix(other, path+".") || strings.HasPrefix(other, path+"[")
+}
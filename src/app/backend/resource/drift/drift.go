@@ -0,0 +1,210 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DesiredStateSource is the source of the desired state of an object.
+type DesiredStateSource string
+
+const (
+	// DesiredStateSourceNone means that the desired state of the object is not known, so drift cannot be detected.
+	DesiredStateSourceNone DesiredStateSource = ""
+
+	// DesiredStateSourceLastApplied means that the desired state is stored in the last applied configuration
+	// annotation set by kubectl apply.
+	DesiredStateSourceLastApplied DesiredStateSource = "lastAppliedConfiguration"
+
+	// DesiredStateSourceManagedFields means that the desired state is given by fields owned by server-side apply
+	// managers.
+	DesiredStateSourceManagedFields DesiredStateSource = "managedFields"
+)
+
+// comparedMetadataFields are metadata fields that are part of the desired state. Other metadata fields are set by
+// the server.
+var comparedMetadataFields = []string{"metadata.labels", "metadata.annotations"}
+
+// FieldDrift is a field whose live value differs from the desired one.
+type FieldDrift struct {
+	// Path of the field, e.g. spec.template.spec.containers[name=web].image.
+	Path string `json:"path"`
+
+	// Desired value of the field. Nil if the field should not exist or if the desired value is not known.
+	Desired interface{} `json:"desired"`
+
+	// Live value of the field. Nil if the field does not exist.
+	Live interface{} `json:"live"`
+
+	// Field manager that changed the field, if it is known.
+	Manager string `json:"manager,omitempty"`
+}
+
+// ObjectDrift describes how the live object differs from its desired state.
+type ObjectDrift struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+
+	Source DesiredStateSource `json:"source"`
+
+	// Whether the live object differs from its desired state, e.g. because it was edited by hand.
+	Drifted bool `json:"drifted"`
+
+	Fields []FieldDrift `json:"fields"`
+}
+
+// GetResourceDrift returns drift of the object, as returned by the resource verber.
+func GetResourceDrift(object runtime.Object) (*ObjectDrift, error) {
+	unknown, ok := object.(*runtime.Unknown)
+	if !ok {
+		return nil, errors.NewUnexpectedObject(object)
+	}
+
+	live := &unstructured.Unstructured{}
+	if err := live.UnmarshalJSON(unknown.Raw); err != nil {
+		return nil, err
+	}
+
+	return GetObjectDrift(live)
+}
+
+// GetObjectDrift compares the live object with its desired state. The desired state is taken from the last applied
+// configuration annotation if it exists. Otherwise, if the object was applied server-side, fields changed by
+// updates made after the latest apply are reported.
+func GetObjectDrift(object *unstructured.Unstructured) (*ObjectDrift, error) {
+	result := &ObjectDrift{
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Namespace:  object.GetNamespace(),
+		Name:       object.GetName(),
+		Fields:     make([]FieldDrift, 0),
+	}
+
+	// Live object is converted the same way as the annotation, so that numbers have the same type.
+	live, err := toJSONMap(object.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	lastApplied, hasLastApplied := object.GetAnnotations()[v1.LastAppliedConfigAnnotation]
+	switch {
+	case hasLastApplied:
+		result.Source = DesiredStateSourceLastApplied
+		result.Fields, err = getLastAppliedDrift(object, live, lastApplied)
+	case len(getAppliedEntries(object.GetManagedFields())) > 0:
+		result.Source = DesiredStateSourceManagedFields
+		result.Fields, err = getUpdatedFields(live, object.GetManagedFields(), true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Drifted = len(result.Fields) > 0
+	return result, nil
+}
+
+// getLastAppliedDrift compares the live object with the last applied configuration. Drifted fields are attributed to
+// managers that updated them, if managed fields are tracked.
+func getLastAppliedDrift(object *unstructured.Unstructured, live map[string]interface{},
+	lastApplied string) ([]FieldDrift, error) {
+	desired := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(lastApplied), &desired); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %s", v1.LastAppliedConfigAnnotation, err.Error())
+	}
+
+	updated, err := getUpdatedFields(live, object.GetManagedFields(), false)
+	if err != nil {
+		return nil, err
+	}
+
+	result := compareObjects(desired, live)
+	for i := range result {
+		for _, updatedField := range updated {
+			if isPrefixPath(updatedField.Path, result[i].Path) || isPrefixPath(result[i].Path, updatedField.Path) {
+				result[i].Manager = updatedField.Manager
+			}
+		}
+	}
+	return result, nil
+}
+
+// CompareObjects compares fields set in the desired object with the live object, e.g. a manifest with the object
+// created from it. Fields that are set only in the live object are not compared.
+func CompareObjects(desired, live *unstructured.Unstructured) ([]FieldDrift, error) {
+	desiredContent, err := toJSONMap(desired.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	liveContent, err := toJSONMap(live.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareObjects(desiredContent, liveContent), nil
+}
+
+func compareObjects(desired, live map[string]interface{}) []FieldDrift {
+	result := make([]FieldDrift, 0)
+	for _, field := range compareFields("", desired, live, false, make([]FieldDrift, 0)) {
+		if isComparedPath(field.Path) {
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// toJSONMap converts object content to the form returned by JSON decoding, e.g. with all numbers as float64.
+func toJSONMap(content map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// isComparedPath returns true if the field is a part of the desired state of objects. Status, type and metadata set
+// by the server are not compared.
+func isComparedPath(path string) bool {
+	if path == "apiVersion" || path == "kind" || isPrefixPath("status", path) ||
+		isPrefixPath("metadata.annotations."+v1.LastAppliedConfigAnnotation, path) {
+		return false
+	}
+
+	if !strings.HasPrefix(path, "metadata") {
+		return true
+	}
+	for _, field := range comparedMetadataFields {
+		if isPrefixPath(field, path) {
+			return true
+		}
+	}
+	return false
+}
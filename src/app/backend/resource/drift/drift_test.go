@@ -0,0 +1,302 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+func toUnstructured(t *testing.T, data string) *unstructured.Unstructured {
+	json, err := yaml.YAMLToJSON([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	object := &unstructured.Unstructured{}
+	if err := object.UnmarshalJSON(json); err != nil {
+		t.Fatal(err)
+	}
+	return object
+}
+
+const lastAppliedDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: ns
+  labels:
+    app: web
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: |
+      {"apiVersion":"apps/v1","kind":"Deployment","metadata":{"annotations":{},"labels":{"app":"web","team":"a"},
+      "name":"web","namespace":"ns"},"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"web",
+      "image":"nginx:1.16","args":["--port","80"],"resources":{"limits":{"cpu":0.5,"memory":"1Gi"}}}]}}}}
+  managedFields:
+  - manager: kubectl
+    operation: Update
+    apiVersion: apps/v1
+    time: "2019-10-01T10:00:00Z"
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:spec:
+        f:replicas: {}
+  - manager: dashboard
+    operation: Update
+    apiVersion: apps/v1
+    time: "2019-10-01T11:00:00Z"
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:spec:
+        f:template:
+          f:spec:
+            f:containers:
+              k:{"name":"web"}:
+                .: {}
+                f:image: {}
+spec:
+  replicas: 2
+  progressDeadlineSeconds: 600
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.17
+        args: ["--port", "80"]
+        resources:
+          limits:
+            cpu: 500m
+            memory: 1Gi
+status:
+  replicas: 2
+`
+
+const managedFieldsDeployment = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: ns
+  managedFields:
+  - manager: kubectl-edit
+    operation: Update
+    apiVersion: apps/v1
+    time: "2019-10-01T09:00:00Z"
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:spec:
+        f:paused: {}
+  - manager: dashboard
+    operation: Apply
+    apiVersion: apps/v1
+    time: "2019-10-01T10:00:00Z"
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:spec:
+        f:template:
+          f:spec:
+            f:containers:
+              k:{"name":"web"}:
+                .: {}
+                f:image: {}
+  - manager: kubectl-edit
+    operation: Update
+    apiVersion: apps/v1
+    time: "2019-10-01T11:00:00Z"
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:metadata:
+        f:labels:
+          f:edited: {}
+      f:spec:
+        f:replicas: {}
+  - manager: kube-controller-manager
+    operation: Update
+    apiVersion: apps/v1
+    time: "2019-10-01T12:00:00Z"
+    fieldsType: FieldsV1
+    fieldsV1:
+      f:metadata:
+        f:annotations:
+          f:deployment.kubernetes.io/revision: {}
+      f:status:
+        f:replicas: {}
+spec:
+  replicas: 3
+  paused: false
+`
+
+func TestGetObjectDrift(t *testing.T) {
+	cases := []struct {
+		info     string
+		object   string
+		source   DesiredStateSource
+		expected []FieldDrift
+	}{
+		{
+			"last applied configuration",
+			lastAppliedDeployment,
+			DesiredStateSourceLastApplied,
+			[]FieldDrift{
+				{Path: "metadata.labels.team", Desired: "a"},
+				{
+					Path:    "spec.template.spec.containers[name=web].image",
+					Desired: "nginx:1.16",
+					Live:    "nginx:1.17",
+					Manager: "dashboard",
+				},
+			},
+		},
+		{
+			"managed fields",
+			managedFieldsDeployment,
+			DesiredStateSourceManagedFields,
+			[]FieldDrift{
+				{Path: "metadata.labels.edited", Manager: "kubectl-edit"},
+				{Path: "spec.replicas", Live: float64(3), Manager: "kubectl-edit"},
+			},
+		},
+		{
+			"untracked",
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n",
+			DesiredStateSourceNone,
+			[]FieldDrift{},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := GetObjectDrift(toUnstructured(t, c.object))
+		if err != nil {
+			t.Fatalf("GetObjectDrift() for %s: unexpected error: %s", c.info, err.Error())
+		}
+
+		if actual.Source != c.source || actual.Drifted != (len(c.expected) > 0) {
+			t.Errorf("GetObjectDrift() for %s returned source %q and drifted %t", c.info, actual.Source,
+				actual.Drifted)
+		}
+		if !reflect.DeepEqual(actual.Fields, c.expected) {
+			t.Errorf("GetObjectDrift() for %s returned fields:\n%#v\nexpected:\n%#v", c.info, actual.Fields,
+				c.expected)
+		}
+	}
+}
+
+func TestGetResourceDrift(t *testing.T) {
+	object := toUnstructured(t, lastAppliedDeployment)
+	data, err := object.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := GetResourceDrift(&runtime.Unknown{Raw: data})
+	if err != nil {
+		t.Fatalf("GetResourceDrift(): unexpected error: %s", err.Error())
+	}
+	if actual.Kind != "Deployment" || actual.Namespace != "ns" || actual.Name != "web" || !actual.Drifted {
+		t.Errorf("GetResourceDrift() == %v, expected drifted deployment ns/web", actual)
+	}
+}
+
+func TestCompareFields(t *testing.T) {
+	cases := []struct {
+		info          string
+		desired, live interface{}
+		expectedPaths []string
+	}{
+		{
+			"equal",
+			map[string]interface{}{"a": "b", "c": []interface{}{"d"}},
+			map[string]interface{}{"a": "b", "c": []interface{}{"d"}, "e": "defaulted"},
+			[]string{},
+		},
+		{
+			"changed list length",
+			map[string]interface{}{"args": []interface{}{"a", "b"}},
+			map[string]interface{}{"args": []interface{}{"a"}},
+			[]string{"args"},
+		},
+		{
+			"changed list item",
+			map[string]interface{}{"rules": []interface{}{map[string]interface{}{"host": "a"}}},
+			map[string]interface{}{"rules": []interface{}{map[string]interface{}{"host": "b"}}},
+			[]string{"rules[0].host"},
+		},
+		{
+			"removed named item",
+			map[string]interface{}{"volumes": []interface{}{map[string]interface{}{"name": "a"},
+				map[string]interface{}{"name": "b"}}},
+			map[string]interface{}{"volumes": []interface{}{map[string]interface{}{"name": "b"}}},
+			[]string{"volumes[name=a]"},
+		},
+		{
+			"changed type",
+			map[string]interface{}{"a": map[string]interface{}{"b": "c"}},
+			map[string]interface{}{"a": "b"},
+			[]string{"a"},
+		},
+		{
+			"quantities",
+			map[string]interface{}{"requests": map[string]interface{}{"cpu": "0.1", "memory": "1Gi"}},
+			map[string]interface{}{"requests": map[string]interface{}{"cpu": "100m", "memory": "1G"}},
+			[]string{"requests.memory"},
+		},
+	}
+
+	for _, c := range cases {
+		paths := make([]string, 0)
+		for _, field := range compareFields("", c.desired, c.live, false, make([]FieldDrift, 0)) {
+			paths = append(paths, field.Path)
+		}
+		if !reflect.DeepEqual(paths, c.expectedPaths) {
+			t.Errorf("compareFields() for %s returned %v, expected %v", c.info, paths, c.expectedPaths)
+		}
+	}
+}
+
+func TestWalkFields(t *testing.T) {
+	fields := map[string]interface{}{
+		"f:spec": map[string]interface{}{
+			"f:ports": map[string]interface{}{
+				`k:{"port":80,"protocol":"TCP"}`: map[string]interface{}{".": map[string]interface{}{},
+					"f:targetPort": map[string]interface{}{}},
+			},
+			"f:finalizers": map[string]interface{}{`v:"a"`: map[string]interface{}{}},
+			"f:args":       map[string]interface{}{"i:1": map[string]interface{}{}},
+		},
+	}
+	live := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"ports": []interface{}{
+				map[string]interface{}{"port": float64(80), "protocol": "TCP", "targetPort": float64(8080)},
+			},
+			"finalizers": []interface{}{"a"},
+			"args":       []interface{}{"a", "b"},
+		},
+	}
+
+	expected := []FieldDrift{
+		{Path: "spec.args[1]", Live: "b"},
+		{Path: `spec.finalizers["a"]`, Live: "a"},
+		{Path: "spec.ports[port=80,protocol=TCP].targetPort", Live: float64(8080)},
+	}
+	if actual := walkFields("", fields, live, make([]FieldDrift, 0)); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("walkFields() == %#v, expected %#v", actual, expected)
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ignoredManagers are managers of cluster components, which update objects as part of their normal operation.
+// Their changes are not considered to be drift.
+var ignoredManagers = map[string]bool{
+	"kube-controller-manager": true,
+	"kube-scheduler":          true,
+	"kubelet":                 true,
+}
+
+// getAppliedEntries returns managed fields entries of server-side apply operations. Applied fields describe the
+// desired state of the object.
+func getAppliedEntries(entries []metaV1.ManagedFieldsEntry) []metaV1.ManagedFieldsEntry {
+	result := make([]metaV1.ManagedFieldsEntry, 0)
+	for _, entry := range entries {
+		if entry.Operation == metaV1.ManagedFieldsOperationApply {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// getUpdatedFields returns fields owned by update operations, which were not made by cluster components. If
+// afterApply is set, only updates made after the latest apply are taken into account, as earlier updates of
+// applied fields would be overwritten by the apply.
+func getUpdatedFields(live map[string]interface{}, entries []metaV1.ManagedFieldsEntry,
+	afterApply bool) ([]FieldDrift, error) {
+	var lastApply *metaV1.Time
+	for _, entry := range getAppliedEntries(entries) {
+		if entry.Time != nil && (lastApply == nil || lastApply.Before(entry.Time)) {
+			lastApply = entry.Time
+		}
+	}
+
+	result := make([]FieldDrift, 0)
+	for _, entry := range entries {
+		if entry.Operation != metaV1.ManagedFieldsOperationUpdate || ignoredManagers[entry.Manager] ||
+			entry.FieldsV1 == nil {
+			continue
+		}
+		if afterApply && lastApply != nil && entry.Time != nil && !lastApply.Before(entry.Time) {
+			continue
+		}
+
+		fields := map[string]interface{}{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			return nil, fmt.Errorf("invalid managed fields of %s: %s", entry.Manager, err.Error())
+		}
+
+		for _, field := range walkFields("", fields, live, make([]FieldDrift, 0)) {
+			if isComparedPath(field.Path) {
+				field.Manager = entry.Manager
+				result = append(result, field)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// walkFields walks the set of fields in the format of managed fields and returns the leaf fields together with
+// their live values. Keys of the set are prefixed with the type of path element, f: for fields, k: for list items
+// identified by keys, v: for list items identified by value and i: for list items identified by index.
+func walkFields(path string, fields map[string]interface{}, live interface{}, result []FieldDrift) []FieldDrift {
+	for _, key := range sortedKeys(fields) {
+		if key == "." || len(key) < 2 {
+			continue
+		}
+
+		var childPath string
+		var childLive interface{}
+		value := key[2:]
+		switch key[:2] {
+		case "f:":
+			childPath = joinPath(path, value)
+			if object, ok := live.(map[string]interface{}); ok {
+				childLive = object[value]
+			}
+		case "k:":
+			keys := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(value), &keys); err != nil {
+				continue
+			}
+			childPath = path + "[" + formatKeys(keys) + "]"
+			childLive = findItem(live, func(item interface{}) bool {
+				object, ok := item.(map[string]interface{})
+				if !ok {
+					return false
+				}
+				for name, keyValue := range keys {
+					if !reflect.DeepEqual(object[name], keyValue) {
+						return false
+					}
+				}
+				return true
+			})
+		case "v:":
+			var itemValue interface{}
+			if err := json.Unmarshal([]byte(value), &itemValue); err != nil {
+				continue
+			}
+			childPath = path + "[" + value + "]"
+			childLive = findItem(live, func(item interface{}) bool { return reflect.DeepEqual(item, itemValue) })
+		case "i:":
+			index, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			childPath = fmt.Sprintf("%s[%d]", path, index)
+			if list, ok := live.([]interface{}); ok && index < len(list) {
+				childLive = list[index]
+			}
+		default:
+			continue
+		}
+
+		children, _ := fields[key].(map[string]interface{})
+		if isLeaf(children) {
+			result = append(result, FieldDrift{Path: childPath, Live: childLive})
+		} else {
+			result = walkFields(childPath, children, childLive, result)
+		}
+	}
+	return result
+}
+
+// isLeaf returns true if the set does not contain any children, except of the element itself.
+func isLeaf(fields map[string]interface{}) bool {
+	for key := range fields {
+		if key != "." {
+			return false
+		}
+	}
+	return true
+}
+
+func findItem(live interface{}, matches func(interface{}) bool) interface{} {
+	list, _ := live.([]interface{})
+	for _, item := range list {
+		if matches(item) {
+			return item
+		}
+	}
+	return nil
+}
+
+// formatKeys formats keys of a list item the same way as names of items are formatted in compared paths, e.g.
+// name=web or containerPort=80,protocol=TCP.
+func formatKeys(keys map[string]interface{}) string {
+	parts := make([]string, 0, len(keys))
+	for _, name := range sortedKeys(keys) {
+		parts = append(parts, fmt.Sprintf("%s=%v", name, keys[name]))
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,97 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"log"
+	"sort"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/export"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// DriftReport lists drifted objects of a namespace.
+type DriftReport struct {
+	Namespace string `json:"namespace"`
+
+	// Number of objects with known desired state, i.e. the ones that were checked for drift.
+	TrackedObjects int `json:"trackedObjects"`
+
+	// Objects that differ from their desired state, sorted by kind and name.
+	Objects []ObjectDrift `json:"objects"`
+
+	// List of non-critical errors, that occurred during resource retrieval. Objects of resources that could not be
+	// listed are missing from the report.
+	Errors []error `json:"errors"`
+}
+
+// GetDriftReport checks all objects of the namespace for drift.
+func GetDriftReport(cfg *rest.Config, namespace string) (*DriftReport, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return getDriftReport(discoveryClient, dynamicClient, namespace)
+}
+
+func getDriftReport(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface,
+	namespace string) (*DriftReport, error) {
+	log.Printf("Checking drift of objects in %s namespace\n", namespace)
+	report := &DriftReport{Namespace: namespace, Objects: make([]ObjectDrift, 0)}
+
+	query, err := export.NewExportQuery("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	objects, nonCriticalErrors, err := export.ListObjects(discoveryClient, dynamicClient, namespace,
+		[]string{"list"}, query)
+	if err != nil {
+		return nil, err
+	}
+	report.Errors = nonCriticalErrors
+
+	for i := range objects {
+		objectDrift, err := GetObjectDrift(&objects[i])
+		if err != nil {
+			report.Errors = append(report.Errors, err)
+			continue
+		}
+
+		if objectDrift.Source != DesiredStateSourceNone {
+			report.TrackedObjects++
+		}
+		if objectDrift.Drifted {
+			report.Objects = append(report.Objects, *objectDrift)
+		}
+	}
+
+	sort.SliceStable(report.Objects, func(i, j int) bool {
+		if report.Objects[i].Kind != report.Objects[j].Kind {
+			return report.Objects[i].Kind < report.Objects[j].Kind
+		}
+		return report.Objects[i].Name < report.Objects[j].Name
+	})
+
+	return report, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drift
+
+import (
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetDriftReport(t *testing.T) {
+	unchanged := toUnstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: applied
+  namespace: ns
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"v1","kind":"ConfigMap","data":{"a":"b"}}'
+data:
+  a: b
+`)
+	untracked := toUnstructured(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: untracked\n  namespace: ns\n")
+
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metaV1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metaV1.Verbs{"list"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: metaV1.Verbs{"list"}},
+				{Name: "deployments/scale", Kind: "Scale", Namespaced: true, Verbs: metaV1.Verbs{"get"}},
+			},
+		},
+	}
+	dynamicClient := fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(),
+		toUnstructured(t, lastAppliedDeployment), unchanged, untracked)
+
+	report, err := getDriftReport(client.Discovery(), dynamicClient, "ns")
+	if err != nil {
+		t.Fatalf("getDriftReport(): unexpected error: %s", err.Error())
+	}
+
+	if report.TrackedObjects != 2 || len(report.Objects) != 1 || report.Objects[0].Name != "web" {
+		t.Errorf("getDriftReport() == %v, expected 2 tracked objects and drifted web deployment", report)
+	}
+}
@@ -0,0 +1,307 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"reflect"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// serverMetadataFields are metadata fields set by the server, which are specific to the cluster the object has been
+// read from.
+var serverMetadataFields = []string{"managedFields", "resourceVersion", "uid", "creationTimestamp", "selfLink",
+	"generation", "deletionTimestamp", "deletionGracePeriodSeconds", "ownerReferences"}
+
+// serverAnnotations are annotations set by the server and by controllers.
+var serverAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+	"pv.kubernetes.io/bind-completed",
+	"pv.kubernetes.io/bound-by-controller",
+	"volume.beta.kubernetes.io/storage-provisioner",
+}
+
+// defaultedField is a field with the value set by the server if the field is not specified.
+type defaultedField struct {
+	path  []string
+	value interface{}
+}
+
+// podTemplatePaths are paths of pod templates in resources having them.
+var podTemplatePaths = map[string][]string{
+	"Deployment":            {"spec", "template"},
+	"StatefulSet":           {"spec", "template"},
+	"DaemonSet":             {"spec", "template"},
+	"ReplicaSet":            {"spec", "template"},
+	"ReplicationController": {"spec", "template"},
+	"Job":                   {"spec", "template"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template"},
+}
+
+// defaultedFields are fields defaulted by the server, by kind.
+var defaultedFields = map[string][]defaultedField{
+	"Deployment": {
+		{[]string{"spec", "progressDeadlineSeconds"}, int64(600)},
+		{[]string{"spec", "revisionHistoryLimit"}, int64(10)},
+		{[]string{"spec", "strategy"}, map[string]interface{}{
+			"type":          "RollingUpdate",
+			"rollingUpdate": map[string]interface{}{"maxSurge": "25%", "maxUnavailable": "25%"},
+		}},
+	},
+	"StatefulSet": {
+		{[]string{"spec", "podManagementPolicy"}, "OrderedReady"},
+		{[]string{"spec", "revisionHistoryLimit"}, int64(10)},
+		{[]string{"spec", "updateStrategy"}, map[string]interface{}{
+			"type":          "RollingUpdate",
+			"rollingUpdate": map[string]interface{}{"partition": int64(0)},
+		}},
+	},
+	"DaemonSet": {
+		{[]string{"spec", "revisionHistoryLimit"}, int64(10)},
+		{[]string{"spec", "updateStrategy"}, map[string]interface{}{
+			"type":          "RollingUpdate",
+			"rollingUpdate": map[string]interface{}{"maxUnavailable": int64(1)},
+		}},
+	},
+	"Job": {
+		{[]string{"spec", "backoffLimit"}, int64(6)},
+	},
+	"CronJob": {
+		{[]string{"spec", "concurrencyPolicy"}, "Allow"},
+		{[]string{"spec", "failedJobsHistoryLimit"}, int64(1)},
+		{[]string{"spec", "successfulJobsHistoryLimit"}, int64(3)},
+		{[]string{"spec", "suspend"}, false},
+	},
+	"Service": {
+		{[]string{"spec", "sessionAffinity"}, "None"},
+		{[]string{"spec", "type"}, "ClusterIP"},
+	},
+	"PersistentVolumeClaim": {
+		{[]string{"spec", "volumeMode"}, "Filesystem"},
+	},
+}
+
+// defaultedPodSpecFields are fields of pod spec defaulted by the server.
+var defaultedPodSpecFields = []defaultedField{
+	{[]string{"dnsPolicy"}, "ClusterFirst"},
+	{[]string{"restartPolicy"}, "Always"},
+	{[]string{"schedulerName"}, "default-scheduler"},
+	{[]string{"securityContext"}, map[string]interface{}{}},
+	{[]string{"terminationGracePeriodSeconds"}, int64(30)},
+}
+
+// defaultedContainerFields are fields of containers defaulted by the server.
+var defaultedContainerFields = []defaultedField{
+	{[]string{"terminationMessagePath"}, "/dev/termination-log"},
+	{[]string{"terminationMessagePolicy"}, "File"},
+	{[]string{"resources"}, map[string]interface{}{}},
+}
+
+// Clean returns a copy of the object without status, server set metadata and fields defaulted by the server, so
+// that it can be applied to another cluster.
+func Clean(object *unstructured.Unstructured) *unstructured.Unstructured {
+	result := object.DeepCopy()
+	content := result.Object
+
+	delete(content, "status")
+	for _, field := range serverMetadataFields {
+		unstructured.RemoveNestedField(content, "metadata", field)
+	}
+	cleanAnnotations(content, "metadata")
+
+	kind := result.GetKind()
+	for _, field := range defaultedFields[kind] {
+		removeDefault(content, field)
+	}
+
+	switch kind {
+	case "Pod":
+		unstructured.RemoveNestedField(content, "spec", "nodeName")
+		cleanPodSpec(content, "spec")
+	case "Service":
+		cleanService(content)
+	case "PersistentVolumeClaim":
+		unstructured.RemoveNestedField(content, "spec", "volumeName")
+	case "ServiceAccount":
+		cleanServiceAccount(content, result.GetName())
+	case "Job":
+		removeDefaultJobCompletions(content)
+		cleanJob(content, []string{"spec"})
+	case "CronJob":
+		cleanJob(content, []string{"spec", "jobTemplate", "spec"})
+	}
+
+	if path, ok := podTemplatePaths[kind]; ok {
+		unstructured.RemoveNestedField(content, append(path, "metadata", "creationTimestamp")...)
+		removeEmpty(content, append(path, "metadata")...)
+		cleanPodSpec(content, append(path, "spec")...)
+	}
+
+	return result
+}
+
+// cleanAnnotations removes annotations set by the server from metadata at the given path. Empty annotations are
+// removed completely.
+func cleanAnnotations(content map[string]interface{}, path ...string) {
+	annotationsPath := append(path, "annotations")
+	annotations, found, _ := unstructured.NestedStringMap(content, annotationsPath...)
+	if !found {
+		return
+	}
+
+	for _, annotation := range serverAnnotations {
+		delete(annotations, annotation)
+	}
+
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(content, annotationsPath...)
+		return
+	}
+	unstructured.SetNestedStringMap(content, annotations, annotationsPath...)
+}
+
+func cleanPodSpec(content map[string]interface{}, path ...string) {
+	podSpec, found, _ := unstructured.NestedMap(content, path...)
+	if !found {
+		return
+	}
+
+	for _, field := range defaultedPodSpecFields {
+		removeDefault(podSpec, field)
+	}
+	// Deprecated alias of service account name, set by the server.
+	delete(podSpec, "serviceAccount")
+
+	for _, containers := range []string{"initContainers", "containers"} {
+		forEachItem(podSpec, []string{containers}, func(container map[string]interface{}) {
+			for _, field := range defaultedContainerFields {
+				removeDefault(container, field)
+			}
+			forEachItem(container, []string{"ports"}, func(port map[string]interface{}) {
+				removeDefault(port, defaultedField{[]string{"protocol"}, "TCP"})
+			})
+		})
+	}
+
+	forEachItem(podSpec, []string{"volumes"}, func(volume map[string]interface{}) {
+		removeDefault(volume, defaultedField{[]string{"secret", "defaultMode"}, int64(420)})
+		removeDefault(volume, defaultedField{[]string{"configMap", "defaultMode"}, int64(420)})
+	})
+
+	unstructured.SetNestedMap(content, podSpec, path...)
+}
+
+// cleanService removes cluster IP allocated by the cluster and defaulted fields of ports.
+func cleanService(content map[string]interface{}) {
+	if clusterIP, _, _ := unstructured.NestedString(content, "spec", "clusterIP"); clusterIP != "None" {
+		unstructured.RemoveNestedField(content, "spec", "clusterIP")
+	}
+
+	forEachItem(content, []string{"spec", "ports"}, func(port map[string]interface{}) {
+		removeDefault(port, defaultedField{[]string{"protocol"}, "TCP"})
+		if reflect.DeepEqual(port["targetPort"], port["port"]) {
+			delete(port, "targetPort")
+		}
+	})
+}
+
+// cleanServiceAccount removes references to token secrets generated for the service account.
+func cleanServiceAccount(content map[string]interface{}, name string) {
+	secrets, found, _ := unstructured.NestedSlice(content, "secrets")
+	if !found {
+		return
+	}
+
+	result := make([]interface{}, 0)
+	for _, secret := range secrets {
+		reference, _ := secret.(map[string]interface{})
+		if secretName, ok := reference["name"].(string); ok && strings.HasPrefix(secretName, name+"-token-") {
+			continue
+		}
+		result = append(result, secret)
+	}
+
+	if len(result) == 0 {
+		unstructured.RemoveNestedField(content, "secrets")
+		return
+	}
+	unstructured.SetNestedSlice(content, result, "secrets")
+}
+
+// cleanJob removes selector and labels generated for the job, unless the selector is set manually.
+func cleanJob(content map[string]interface{}, path []string) {
+	if manualSelector, _, _ := unstructured.NestedBool(content, append(path, "manualSelector")...); manualSelector {
+		return
+	}
+
+	unstructured.RemoveNestedField(content, append(path, "selector")...)
+	labelsPath := append(path, "template", "metadata", "labels")
+	labels, found, _ := unstructured.NestedStringMap(content, labelsPath...)
+	if !found {
+		return
+	}
+
+	delete(labels, "controller-uid")
+	delete(labels, "job-name")
+	if len(labels) == 0 {
+		unstructured.RemoveNestedField(content, labelsPath...)
+		return
+	}
+	unstructured.SetNestedStringMap(content, labels, labelsPath...)
+}
+
+// removeDefaultJobCompletions removes completions and parallelism of the job if both are 1. Server sets both only if
+// none of them is specified, a single one of them set to 1 has to be kept.
+func removeDefaultJobCompletions(content map[string]interface{}) {
+	completions, _, _ := unstructured.NestedInt64(content, "spec", "completions")
+	parallelism, _, _ := unstructured.NestedInt64(content, "spec", "parallelism")
+	if completions == 1 && parallelism == 1 {
+		unstructured.RemoveNestedField(content, "spec", "completions")
+		unstructured.RemoveNestedField(content, "spec", "parallelism")
+	}
+}
+
+// removeDefault removes the field if it has the default value.
+func removeDefault(content map[string]interface{}, field defaultedField) {
+	value, found, err := unstructured.NestedFieldNoCopy(content, field.path...)
+	if found && err == nil && reflect.DeepEqual(value, field.value) {
+		unstructured.RemoveNestedField(content, field.path...)
+	}
+}
+
+// removeEmpty removes the field if it is an empty object.
+func removeEmpty(content map[string]interface{}, path ...string) {
+	value, found, err := unstructured.NestedMap(content, path...)
+	if found && err == nil && len(value) == 0 {
+		unstructured.RemoveNestedField(content, path...)
+	}
+}
+
+// forEachItem calls the function for every object in the list at the given path.
+func forEachItem(content map[string]interface{}, path []string, function func(map[string]interface{})) {
+	items, found, _ := unstructured.NestedFieldNoCopy(content, path...)
+	list, ok := items.([]interface{})
+	if !found || !ok {
+		return
+	}
+
+	for _, item := range list {
+		if object, ok := item.(map[string]interface{}); ok {
+			function(object)
+		}
+	}
+}
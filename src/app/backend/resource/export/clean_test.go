@@ -0,0 +1,280 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func toUnstructured(t *testing.T, data string) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	json, err := yaml.YAMLToJSON([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := object.UnmarshalJSON(json); err != nil {
+		t.Fatal(err)
+	}
+	return object
+}
+
+func TestClean(t *testing.T) {
+	cases := []struct {
+		info             string
+		object, expected string
+	}{
+		{
+			"deployment",
+			`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: ns
+  uid: uid
+  resourceVersion: "1"
+  generation: 2
+  creationTimestamp: "2019-10-01T10:00:00Z"
+  annotations:
+    deployment.kubernetes.io/revision: "2"
+  managedFields:
+  - manager: kubectl
+spec:
+  replicas: 2
+  progressDeadlineSeconds: 600
+  revisionHistoryLimit: 10
+  strategy:
+    type: RollingUpdate
+    rollingUpdate:
+      maxSurge: 25%
+      maxUnavailable: 25%
+  template:
+    metadata:
+      creationTimestamp: null
+      labels:
+        app: web
+    spec:
+      dnsPolicy: ClusterFirst
+      restartPolicy: Always
+      schedulerName: default-scheduler
+      securityContext: {}
+      serviceAccount: web
+      serviceAccountName: web
+      terminationGracePeriodSeconds: 60
+      containers:
+      - name: web
+        image: nginx
+        resources: {}
+        terminationMessagePath: /dev/termination-log
+        terminationMessagePolicy: File
+        ports:
+        - containerPort: 80
+          protocol: TCP
+      volumes:
+      - name: config
+        configMap:
+          name: config
+          defaultMode: 420
+      - name: cache
+        emptyDir: {}
+status:
+  replicas: 2
+`,
+			`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: ns
+spec:
+  replicas: 2
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      serviceAccountName: web
+      terminationGracePeriodSeconds: 60
+      containers:
+      - name: web
+        image: nginx
+        ports:
+        - containerPort: 80
+      volumes:
+      - name: config
+        configMap:
+          name: config
+      - name: cache
+        emptyDir: {}
+`,
+		},
+		{
+			"service",
+			`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: "{}"
+    team: web
+spec:
+  clusterIP: 10.0.0.1
+  type: ClusterIP
+  sessionAffinity: None
+  ports:
+  - port: 80
+    targetPort: 80
+    protocol: TCP
+  - port: 443
+    targetPort: 8443
+    protocol: UDP
+`,
+			`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  annotations:
+    team: web
+spec:
+  ports:
+  - port: 80
+  - port: 443
+    targetPort: 8443
+    protocol: UDP
+`,
+		},
+		{
+			"headless service",
+			"apiVersion: v1\nkind: Service\nmetadata:\n  name: db\nspec:\n  clusterIP: None\n",
+			"apiVersion: v1\nkind: Service\nmetadata:\n  name: db\nspec:\n  clusterIP: None\n",
+		},
+		{
+			"job",
+			`
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+spec:
+  backoffLimit: 6
+  completions: 1
+  parallelism: 1
+  selector:
+    matchLabels:
+      controller-uid: uid
+  template:
+    metadata:
+      labels:
+        controller-uid: uid
+        job-name: migrate
+    spec:
+      restartPolicy: Never
+      containers:
+      - name: migrate
+        image: migrate
+`,
+			`
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+spec:
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+      - name: migrate
+        image: migrate
+`,
+		},
+		{
+			"job with single default",
+			`
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  resourceVersion: "42"
+spec:
+  completions: 5
+  parallelism: 1
+  manualSelector: true
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+      - name: migrate
+        image: migrate
+`,
+			`
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+spec:
+  completions: 5
+  parallelism: 1
+  manualSelector: true
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+      - name: migrate
+        image: migrate
+`,
+		},
+		{
+			"service account",
+			`
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: web
+secrets:
+- name: web-token-abcde
+imagePullSecrets:
+- name: registry
+`,
+			`
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: web
+imagePullSecrets:
+- name: registry
+`,
+		},
+	}
+
+	for _, c := range cases {
+		object := toUnstructured(t, c.object)
+		actual := Clean(object)
+		expected := toUnstructured(t, c.expected)
+		if !reflect.DeepEqual(actual.Object, expected.Object) {
+			actualYAML, _ := yaml.Marshal(actual.Object)
+			t.Errorf("Clean() for %s returned:\n%s\nexpected:\n%s", c.info, actualYAML, c.expected)
+		}
+
+		if reflect.DeepEqual(object, actual) && c.info != "headless service" {
+			t.Errorf("Clean() for %s modified the original object", c.info)
+		}
+	}
+}
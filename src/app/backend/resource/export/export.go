@@ -0,0 +1,311 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// Format is the format of the export.
+type Format string
+
+const (
+	// FormatYAML is a single multi-document YAML file.
+	FormatYAML Format = "yaml"
+
+	// FormatTar is a tar archive with one YAML file per object.
+	FormatTar Format = "tar"
+)
+
+// ParseFormat parses the format of the export. YAML is used by default.
+func ParseFormat(format string) (Format, error) {
+	switch Format(strings.ToLower(format)) {
+	case "", FormatYAML:
+		return FormatYAML, nil
+	case FormatTar:
+		return FormatTar, nil
+	default:
+		return "", errors.NewBadRequest(fmt.Sprintf("Invalid export format: %s", format))
+	}
+}
+
+// ignoredResources are resources managed by the cluster, which are not exported unless requested explicitly.
+var ignoredResources = map[string]bool{
+	"events":              true,
+	"endpoints":           true,
+	"endpointslices":      true,
+	"controllerrevisions": true,
+	"leases":              true,
+}
+
+// ExportQuery selects objects to export.
+type ExportQuery struct {
+	// Kinds of exported objects, given as kinds, resource names or short names, e.g. deployment, services or cm. If
+	// empty, all objects except of those managed by the cluster or by controllers are exported.
+	Kinds []string
+
+	// Label selector of exported objects.
+	LabelSelector string
+}
+
+// NewExportQuery creates export query from comma separated kinds and label selector.
+func NewExportQuery(kinds, labelSelector string) (*ExportQuery, error) {
+	if _, err := labels.Parse(labelSelector); err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Invalid label selector: %s", err.Error()))
+	}
+
+	query := &ExportQuery{LabelSelector: labelSelector, Kinds: make([]string, 0)}
+	for _, kind := range strings.Split(kinds, ",") {
+		if kind = strings.TrimSpace(kind); len(kind) > 0 {
+			query.Kinds = append(query.Kinds, strings.ToLower(kind))
+		}
+	}
+
+	return query, nil
+}
+
+// matches returns true if the resource is selected by the query.
+func (query *ExportQuery) matches(resource metaV1.APIResource) bool {
+	if len(query.Kinds) == 0 {
+		return !ignoredResources[resource.Name]
+	}
+
+	names := append([]string{resource.Kind, resource.Name, resource.SingularName}, resource.ShortNames...)
+	for _, kind := range query.Kinds {
+		for _, name := range names {
+			if strings.ToLower(name) == kind {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Export is a set of cleaned objects from a namespace, ready to be applied to another cluster.
+type Export struct {
+	Namespace string
+	Objects   []unstructured.Unstructured
+
+	// List of non-critical errors, that occurred during resource retrieval. Objects of resources that could not be
+	// listed are missing from the export.
+	Errors []error
+}
+
+// GetExport returns cleaned objects from the given namespace selected by the query.
+func GetExport(cfg *rest.Config, namespace string, query *ExportQuery) (*Export, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetObjects(discoveryClient, dynamicClient, namespace, query)
+}
+
+// GetObjects returns cleaned objects from the given namespace selected by the query, using the given clients.
+func GetObjects(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface, namespace string,
+	query *ExportQuery) (*Export, error) {
+	log.Printf("Exporting objects from %s namespace\n", namespace)
+	objects, nonCriticalErrors, err := ListObjects(discoveryClient, dynamicClient, namespace,
+		[]string{"list", "create"}, query)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &Export{Namespace: namespace, Objects: make([]unstructured.Unstructured, 0), Errors: nonCriticalErrors}
+	for i := range objects {
+		if len(query.Kinds) == 0 && IsGenerated(&objects[i]) {
+			continue
+		}
+		export.Objects = append(export.Objects, *Clean(&objects[i]))
+	}
+
+	sort.SliceStable(export.Objects, func(i, j int) bool {
+		return getFileName(&export.Objects[i]) < getFileName(&export.Objects[j])
+	})
+
+	return export, nil
+}
+
+// ListObjects returns raw objects of all namespaced resources of the namespace, which support given verbs and are
+// selected by the query. Objects served by multiple API groups are returned only once. Non-critical errors of
+// resources that could not be discovered or listed are returned separately.
+func ListObjects(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface, namespace string,
+	verbs []string, query *ExportQuery) ([]unstructured.Unstructured, []error, error) {
+	objects := make([]unstructured.Unstructured, 0)
+	nonCriticalErrors := make([]error, 0)
+
+	resourceLists, err := discovery.ServerPreferredNamespacedResources(discoveryClient)
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, nil, err
+		}
+		nonCriticalErrors = append(nonCriticalErrors, err)
+	}
+	resourceLists = discovery.FilteredBy(discovery.SupportsAllVerbs{Verbs: verbs}, resourceLists)
+
+	// The same object can be served by multiple API groups, e.g. deployments by apps and extensions.
+	seen := make(map[types.UID]bool)
+	for _, resourceList := range resourceLists {
+		groupVersion, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			nonCriticalErrors = append(nonCriticalErrors, err)
+			continue
+		}
+
+		for _, resource := range resourceList.APIResources {
+			if strings.Contains(resource.Name, "/") || !query.matches(resource) {
+				continue
+			}
+
+			list, err := dynamicClient.Resource(groupVersion.WithResource(resource.Name)).Namespace(namespace).
+				List(metaV1.ListOptions{LabelSelector: query.LabelSelector})
+			var criticalError error
+			nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+			if criticalError != nil {
+				return nil, nil, criticalError
+			}
+			if err != nil {
+				continue
+			}
+
+			for _, item := range list.Items {
+				uid := item.GetUID()
+				if len(uid) > 0 && seen[uid] {
+					continue
+				}
+				seen[uid] = true
+				objects = append(objects, item)
+			}
+		}
+	}
+
+	return objects, nonCriticalErrors, nil
+}
+
+// IsGenerated returns true for objects created by controllers or by the cluster, which are recreated automatically
+// and should not be exported, e.g. pods of deployments or service account tokens.
+func IsGenerated(object *unstructured.Unstructured) bool {
+	if metaV1.GetControllerOf(object) != nil {
+		return true
+	}
+
+	if object.GetKind() == "Secret" {
+		secretType, _, _ := unstructured.NestedString(object.Object, "type")
+		return secretType == string(v1.SecretTypeServiceAccountToken)
+	}
+
+	return false
+}
+
+// getFileName returns the name of the file of the object in tar export, e.g. deployment.apps/name.yaml.
+func getFileName(object *unstructured.Unstructured) string {
+	gvk := object.GroupVersionKind()
+	dir := strings.ToLower(gvk.Kind)
+	if len(gvk.Group) > 0 {
+		dir += "." + gvk.Group
+	}
+	return fmt.Sprintf("%s/%s.yaml", dir, object.GetName())
+}
+
+// WriteYAML writes the export as a multi-document YAML. Errors are written as comments at the beginning.
+func (export *Export) WriteYAML(writer io.Writer) error {
+	for _, err := range export.Errors {
+		if _, err := fmt.Fprintf(writer, "# Export error: %s\n", err.Error()); err != nil {
+			return err
+		}
+	}
+
+	for _, object := range export.Objects {
+		data, err := yaml.Marshal(object.Object)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(writer, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteTar writes the export as a tar archive with one YAML file per object. Errors are written to errors.txt file.
+func (export *Export) WriteTar(writer io.Writer) error {
+	tarWriter := tar.NewWriter(writer)
+	modTime := time.Now()
+
+	if len(export.Errors) > 0 {
+		messages := make([]string, 0)
+		for _, err := range export.Errors {
+			messages = append(messages, err.Error())
+		}
+		if err := writeTarFile(tarWriter, "errors.txt", []byte(strings.Join(messages, "\n")+"\n"), modTime); err != nil {
+			return err
+		}
+	}
+
+	for _, object := range export.Objects {
+		data, err := yaml.Marshal(object.Object)
+		if err != nil {
+			return err
+		}
+
+		if err := writeTarFile(tarWriter, getFileName(&object), data, modTime); err != nil {
+			return err
+		}
+	}
+
+	return tarWriter.Close()
+}
+
+func writeTarFile(tarWriter *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+		Typeflag: tar.TypeReg,
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err := tarWriter.Write(data)
+	return err
+}
@@ -0,0 +1,218 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newObject(apiVersion, kind, namespace, name string, labels map[string]string,
+	owner string) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetNamespace(namespace)
+	object.SetName(name)
+	object.SetUID(types.UID(kind + "-" + namespace + "-" + name))
+	object.SetResourceVersion("1")
+	object.SetLabels(labels)
+	if len(owner) > 0 {
+		controller := true
+		object.SetOwnerReferences([]metaV1.OwnerReference{{Name: owner, UID: types.UID(owner), Controller: &controller}})
+	}
+	return object
+}
+
+func getTestClients() (*fake.Clientset, *fakedynamic.FakeDynamicClient) {
+	tokenSecret := newObject("v1", "Secret", "ns", "default-token", nil, "")
+	tokenSecret.Object["type"] = "kubernetes.io/service-account-token"
+
+	objects := []runtime.Object{
+		newObject("apps/v1", "Deployment", "ns", "web", map[string]string{"app": "web"}, ""),
+		newObject("apps/v1", "ReplicaSet", "ns", "web-1", map[string]string{"app": "web"}, "web"),
+		newObject("v1", "Pod", "ns", "web-1-a", map[string]string{"app": "web"}, "web-1"),
+		newObject("v1", "Service", "ns", "web", map[string]string{"app": "web"}, ""),
+		newObject("v1", "ConfigMap", "ns", "config", nil, ""),
+		newObject("v1", "ConfigMap", "other", "config", nil, ""),
+		newObject("v1", "Event", "ns", "web.1", nil, ""),
+		tokenSecret,
+	}
+
+	verbs := metaV1.Verbs{"list", "create", "get"}
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metaV1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: verbs, ShortNames: []string{"po"}},
+				{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: metaV1.Verbs{"get"}},
+				{Name: "services", Kind: "Service", Namespaced: true, Verbs: verbs, ShortNames: []string{"svc"}},
+				{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: verbs, ShortNames: []string{"cm"}},
+				{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: verbs},
+				{Name: "events", Kind: "Event", Namespaced: true, Verbs: verbs, ShortNames: []string{"ev"}},
+				{Name: "componentstatuses", Kind: "ComponentStatus", Verbs: metaV1.Verbs{"list"}},
+			},
+		},
+		{
+			GroupVersion: "apps/v1",
+			APIResources: []metaV1.APIResource{
+				{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: verbs},
+				{Name: "replicasets", Kind: "ReplicaSet", Namespaced: true, Verbs: verbs},
+			},
+		},
+	}
+
+	return client, fakedynamic.NewSimpleDynamicClient(runtime.NewScheme(), objects...)
+}
+
+func getNames(export *Export) []string {
+	names := make([]string, 0)
+	for _, object := range export.Objects {
+		names = append(names, getFileName(&object))
+	}
+	return names
+}
+
+func TestGetExport(t *testing.T) {
+	cases := []struct {
+		kinds, labelSelector string
+		expected             []string
+	}{
+		{
+			"", "",
+			[]string{"configmap/config.yaml", "deployment.apps/web.yaml", "service/web.yaml"},
+		},
+		{
+			"", "app=web",
+			[]string{"deployment.apps/web.yaml", "service/web.yaml"},
+		},
+		{
+			"Pod, replicasets", "",
+			[]string{"pod/web-1-a.yaml", "replicaset.apps/web-1.yaml"},
+		},
+		{
+			"cm,ev", "",
+			[]string{"configmap/config.yaml", "event/web.1.yaml"},
+		},
+		{
+			"secret", "",
+			[]string{"secret/default-token.yaml"},
+		},
+	}
+
+	for _, c := range cases {
+		client, dynamicClient := getTestClients()
+		query, err := NewExportQuery(c.kinds, c.labelSelector)
+		if err != nil {
+			t.Fatalf("NewExportQuery(%q, %q): unexpected error: %s", c.kinds, c.labelSelector, err.Error())
+		}
+
+		actual, err := GetObjects(client.Discovery(), dynamicClient, "ns", query)
+		if err != nil {
+			t.Fatalf("GetObjects(%q, %q): unexpected error: %s", c.kinds, c.labelSelector, err.Error())
+		}
+
+		if names := getNames(actual); !reflect.DeepEqual(names, c.expected) {
+			t.Errorf("GetObjects(%q, %q) == %v, expected %v", c.kinds, c.labelSelector, names, c.expected)
+		}
+
+		for _, object := range actual.Objects {
+			if len(object.GetUID()) > 0 || len(object.GetResourceVersion()) > 0 {
+				t.Errorf("GetObjects(%q, %q) returned object %s with server metadata", c.kinds, c.labelSelector,
+					object.GetName())
+			}
+		}
+	}
+}
+
+func TestNewExportQueryInvalidSelector(t *testing.T) {
+	if _, err := NewExportQuery("", "app in (web"); err == nil {
+		t.Error("NewExportQuery() with invalid label selector: expected error")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	cases := []struct {
+		format   string
+		expected Format
+		valid    bool
+	}{
+		{"", FormatYAML, true},
+		{"yaml", FormatYAML, true},
+		{"tar", FormatTar, true},
+		{"zip", "", false},
+	}
+
+	for _, c := range cases {
+		actual, err := ParseFormat(c.format)
+		if (err == nil) != c.valid {
+			t.Errorf("ParseFormat(%q): unexpected error %v", c.format, err)
+		}
+		if c.valid && actual != c.expected {
+			t.Errorf("ParseFormat(%q) == %s, expected %s", c.format, actual, c.expected)
+		}
+	}
+}
+
+func TestWriteExport(t *testing.T) {
+	client, dynamicClient := getTestClients()
+	query, _ := NewExportQuery("cm,svc", "")
+	export, err := GetObjects(client.Discovery(), dynamicClient, "ns", query)
+	if err != nil {
+		t.Fatalf("GetObjects(): unexpected error: %s", err.Error())
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := export.WriteYAML(buffer); err != nil {
+		t.Fatalf("WriteYAML(): unexpected error: %s", err.Error())
+	}
+	expected := "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n  namespace: ns\n" +
+		"---\napiVersion: v1\nkind: Service\nmetadata:\n  labels:\n    app: web\n  name: web\n  namespace: ns\n"
+	if buffer.String() != expected {
+		t.Errorf("WriteYAML() wrote:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+
+	buffer.Reset()
+	if err := export.WriteTar(buffer); err != nil {
+		t.Fatalf("WriteTar(): unexpected error: %s", err.Error())
+	}
+	files := make([]string, 0)
+	reader := tar.NewReader(buffer)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("WriteTar() wrote invalid archive: %s", err.Error())
+		}
+		files = append(files, header.Name)
+	}
+	if joined := strings.Join(files, ","); joined != "configmap/config.yaml,service/web.yaml" {
+		t.Errorf("WriteTar() wrote files %s, expected configmap/config.yaml,service/web.yaml", joined)
+	}
+}
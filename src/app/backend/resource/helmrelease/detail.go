@@ -0,0 +1,232 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrelease
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// HelmReleaseRevision is a single revision from the history of Helm release.
+type HelmReleaseRevision struct {
+	Revision    int         `json:"revision"`
+	Status      string      `json:"status"`
+	Chart       Chart       `json:"chart"`
+	Updated     metaV1.Time `json:"updated"`
+	Description string      `json:"description"`
+}
+
+// ReleaseObject is an object from the manifest of Helm release.
+type ReleaseObject struct {
+	// Meta of the live object, or name and namespace from the manifest if the object does not exist.
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	// Whether the object exists in the cluster.
+	Exists bool `json:"exists"`
+}
+
+// HelmReleaseDetail is a presentation layer view of Helm release.
+type HelmReleaseDetail struct {
+	// Extends list item structure.
+	HelmRelease `json:",inline"`
+
+	Description string `json:"description"`
+	Notes       string `json:"notes"`
+
+	// Values supplied by the user when installing or upgrading the release.
+	Values map[string]interface{} `json:"values"`
+
+	// Default values of the chart.
+	ChartValues map[string]interface{} `json:"chartValues"`
+
+	// Manifest rendered from the chart templates.
+	Manifest string `json:"manifest"`
+
+	// Revisions of the release, the latest one first.
+	History []HelmReleaseRevision `json:"history"`
+
+	// Objects from the manifest with their live state.
+	Objects []ReleaseObject `json:"objects"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetHelmReleaseDetail returns the latest revision of Helm release with its history and live objects. Release
+// secrets and live objects are read with the permissions of the user.
+func GetHelmReleaseDetail(cfg *rest.Config, client kubernetes.Interface, namespace, name string) (
+	*HelmReleaseDetail, error) {
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return getHelmReleaseDetail(client, dynamicClient, namespace, name)
+}
+
+func getHelmReleaseDetail(client kubernetes.Interface, dynamicClient dynamic.Interface, namespace, name string) (
+	*HelmReleaseDetail, error) {
+	log.Printf("Getting details of %s Helm release in %s namespace\n", name, namespace)
+	secrets, err := client.CoreV1().Secrets(namespace).List(metaV1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s,name=%s", releaseSecretSelector, name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := getReleaseSecrets(secrets.Items)[releaseID{Namespace: namespace, Name: name}]
+	if len(revisions) == 0 {
+		return nil, errors.NewNotFound(fmt.Sprintf("Helm release %s not found", name))
+	}
+
+	latest := revisions[len(revisions)-1]
+	release, err := decodeRelease(latest)
+	if err != nil {
+		return nil, errors.NewInternal(err.Error())
+	}
+
+	detail := &HelmReleaseDetail{
+		HelmRelease: toHelmRelease(latest, release),
+		Description: release.Info.Description,
+		Notes:       release.Info.Notes,
+		Values:      release.Config,
+		ChartValues: release.Chart.Values,
+		Manifest:    release.Manifest,
+		History:     make([]HelmReleaseRevision, 0),
+		Errors:      make([]error, 0),
+	}
+
+	for i := len(revisions) - 1; i >= 0; i-- {
+		revision := release
+		if i != len(revisions)-1 {
+			revision, err = decodeRelease(revisions[i])
+			if err != nil {
+				detail.Errors = append(detail.Errors, errors.NewInternal(err.Error()))
+				continue
+			}
+		}
+		detail.History = append(detail.History, toHelmReleaseRevision(revisions[i], revision))
+	}
+
+	objects, err := getReleaseObjects(client.Discovery(), dynamicClient, namespace, release.Manifest)
+	if err != nil {
+		return nil, err
+	}
+	detail.Objects = objects.objects
+	detail.Errors = append(detail.Errors, objects.errors...)
+
+	return detail, nil
+}
+
+func toHelmReleaseRevision(secret *v1.Secret, release *release) HelmReleaseRevision {
+	item := toHelmRelease(secret, release)
+	return HelmReleaseRevision{
+		Revision:    item.Revision,
+		Status:      item.Status,
+		Chart:       item.Chart,
+		Updated:     item.LastDeployed,
+		Description: release.Info.Description,
+	}
+}
+
+type releaseObjects struct {
+	objects []ReleaseObject
+	errors  []error
+}
+
+// getReleaseObjects returns objects from the release manifest together with their live state. Objects that could
+// not be read are reported as non-critical errors.
+func getReleaseObjects(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface,
+	namespace, manifest string) (*releaseObjects, error) {
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	result := &releaseObjects{objects: make([]ReleaseObject, 0), errors: make([]error, 0)}
+
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	for {
+		data := map[string]interface{}{}
+		if err := decoder.Decode(&data); err != nil {
+			if err == io.EOF {
+				return result, nil
+			}
+			return nil, errors.NewInternal(fmt.Sprintf("Invalid release manifest: %s", err.Error()))
+		}
+
+		if len(data) == 0 {
+			continue
+		}
+
+		object := &unstructured.Unstructured{Object: data}
+		releaseObject := ReleaseObject{
+			ObjectMeta: api.ObjectMeta{Name: object.GetName()},
+			APIVersion: object.GetAPIVersion(),
+			Kind:       object.GetKind(),
+		}
+
+		gvk := object.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			result.objects = append(result.objects, releaseObject)
+			result.errors = append(result.errors, err)
+			continue
+		}
+
+		var client dynamic.ResourceInterface = dynamicClient.Resource(mapping.Resource)
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			releaseObject.ObjectMeta.Namespace = object.GetNamespace()
+			if len(releaseObject.ObjectMeta.Namespace) == 0 {
+				releaseObject.ObjectMeta.Namespace = namespace
+			}
+			client = dynamicClient.Resource(mapping.Resource).Namespace(releaseObject.ObjectMeta.Namespace)
+		}
+
+		live, err := client.Get(object.GetName(), metaV1.GetOptions{})
+		switch {
+		case err == nil:
+			releaseObject.ObjectMeta = api.NewObjectMeta(metaV1.ObjectMeta{
+				Name:              live.GetName(),
+				Namespace:         live.GetNamespace(),
+				UID:               live.GetUID(),
+				Labels:            live.GetLabels(),
+				Annotations:       live.GetAnnotations(),
+				CreationTimestamp: live.GetCreationTimestamp(),
+			})
+			releaseObject.Exists = true
+		case !errors.IsNotFoundError(err):
+			result.errors = append(result.errors, err)
+		}
+
+		result.objects = append(result.objects, releaseObject)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrelease
+
+import (
+	"reflect"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+const testManifest = `---
+# Source: app/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`
+
+func TestGetHelmReleaseDetail(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newReleaseSecret("ns", 1, newRelease("web", "superseded", "0.1.0", "")),
+		newReleaseSecret("ns", 2, newRelease("web", "deployed", "0.2.0", testManifest)),
+	)
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metaV1.APIResourceList{{
+		GroupVersion: "v1",
+		APIResources: []metaV1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true},
+			{Name: "services", Kind: "Service", Namespaced: true},
+		},
+	}}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "web-config", "namespace": "ns", "uid": "uid"},
+		},
+	})
+
+	actual, err := getHelmReleaseDetail(client, dynamicClient, "ns", "web")
+	if err != nil {
+		t.Fatalf("getHelmReleaseDetail(): unexpected error: %s", err.Error())
+	}
+
+	if actual.Revision != 2 || actual.Status != "deployed" || actual.Manifest != testManifest ||
+		!reflect.DeepEqual(actual.Values, map[string]interface{}{"replicas": float64(2)}) {
+		t.Errorf("getHelmReleaseDetail() returned %#v", actual)
+	}
+
+	history := make([]HelmReleaseRevision, 0)
+	for _, revision := range actual.History {
+		history = append(history, HelmReleaseRevision{Revision: revision.Revision, Status: revision.Status})
+	}
+	expectedHistory := []HelmReleaseRevision{{Revision: 2, Status: "deployed"}, {Revision: 1, Status: "superseded"}}
+	if !reflect.DeepEqual(history, expectedHistory) {
+		t.Errorf("getHelmReleaseDetail() returned history %#v, expected %#v", history, expectedHistory)
+	}
+
+	objects := make(map[string]bool)
+	for _, object := range actual.Objects {
+		objects[object.Kind+"/"+object.ObjectMeta.Namespace+"/"+object.ObjectMeta.Name] = object.Exists
+	}
+	expectedObjects := map[string]bool{"ConfigMap/ns/web-config": true, "Service/ns/web": false}
+	if !reflect.DeepEqual(objects, expectedObjects) {
+		t.Errorf("getHelmReleaseDetail() returned objects %v, expected %v", objects, expectedObjects)
+	}
+
+	if len(actual.Errors) != 0 {
+		t.Errorf("getHelmReleaseDetail() returned unexpected errors %v", actual.Errors)
+	}
+
+	if _, err := getHelmReleaseDetail(client, dynamicClient, "ns", "missing"); !errors.IsNotFoundError(err) {
+		t.Errorf("getHelmReleaseDetail(): expected not found error, got %v", err)
+	}
+}
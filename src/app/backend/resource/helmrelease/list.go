@@ -0,0 +1,139 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrelease
+
+import (
+	"log"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// Chart describes the chart the release has been installed from.
+type Chart struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	AppVersion string `json:"appVersion"`
+}
+
+// HelmRelease is a presentation layer view of the latest revision of Helm release.
+type HelmRelease struct {
+	// Name and namespace of the release. Creation timestamp is the time of the first deployment.
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+
+	Chart Chart `json:"chart"`
+
+	// Status of the release, e.g. deployed, failed or pending-upgrade.
+	Status string `json:"status"`
+
+	Revision     int         `json:"revision"`
+	LastDeployed metaV1.Time `json:"lastDeployed"`
+}
+
+// HelmReleaseList contains a list of Helm releases.
+type HelmReleaseList struct {
+	ListMeta api.ListMeta  `json:"listMeta"`
+	Releases []HelmRelease `json:"releases"`
+
+	// List of non-critical errors, that occurred during resource retrieval. Releases that could not be decoded are
+	// reported here.
+	Errors []error `json:"errors"`
+}
+
+// GetHelmReleaseList returns Helm releases in the given namespace. Releases are read from release secrets, so only
+// releases stored in secrets the user is allowed to list are returned.
+func GetHelmReleaseList(client kubernetes.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*HelmReleaseList, error) {
+	log.Printf("Getting list of Helm releases in %s namespace\n", nsQuery)
+	secrets, err := client.CoreV1().Secrets(nsQuery.ToRequestParam()).List(metaV1.ListOptions{
+		LabelSelector: releaseSecretSelector,
+	})
+
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	var items []v1.Secret
+	if secrets != nil {
+		items = secrets.Items
+	}
+
+	return toHelmReleaseList(items, nonCriticalErrors, dsQuery), nil
+}
+
+func toHelmReleaseList(secrets []v1.Secret, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *HelmReleaseList {
+	result := &HelmReleaseList{
+		Releases: make([]HelmRelease, 0),
+		Errors:   nonCriticalErrors,
+	}
+
+	releases := make([]HelmRelease, 0)
+	for _, revisions := range getReleaseSecrets(secrets) {
+		latest := revisions[len(revisions)-1]
+		decoded, err := decodeRelease(latest)
+		if err != nil {
+			result.Errors = append(result.Errors, errors.NewInternal(err.Error()))
+			continue
+		}
+		releases = append(releases, toHelmRelease(latest, decoded))
+	}
+
+	// Releases are grouped in a map, so they are sorted to keep the order stable if no sorting is requested.
+	sort.Slice(releases, func(i, j int) bool {
+		if releases[i].ObjectMeta.Namespace != releases[j].ObjectMeta.Namespace {
+			return releases[i].ObjectMeta.Namespace < releases[j].ObjectMeta.Namespace
+		}
+		return releases[i].ObjectMeta.Name < releases[j].ObjectMeta.Name
+	})
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(releases), dsQuery)
+	result.Releases = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+	return result
+}
+
+func toHelmRelease(secret *v1.Secret, release *release) HelmRelease {
+	objectMeta := api.ObjectMeta{
+		Name:              secret.Labels["name"],
+		Namespace:         secret.Namespace,
+		CreationTimestamp: metaV1.NewTime(release.Info.FirstDeployed.Time),
+	}
+
+	status := release.Info.Status
+	if len(status) == 0 {
+		status = secret.Labels["status"]
+	}
+
+	return HelmRelease{
+		ObjectMeta: objectMeta,
+		Chart: Chart{
+			Name:       release.Chart.Metadata.Name,
+			Version:    release.Chart.Metadata.Version,
+			AppVersion: release.Chart.Metadata.AppVersion,
+		},
+		Status:       status,
+		Revision:     getRevision(secret),
+		LastDeployed: metaV1.NewTime(release.Info.LastDeployed.Time),
+	}
+}
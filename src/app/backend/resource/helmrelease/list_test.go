@@ -0,0 +1,62 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrelease
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func TestGetHelmReleaseList(t *testing.T) {
+	invalid := newReleaseSecret("ns", 1, newRelease("invalid", "deployed", "0.1.0", ""))
+	invalid.Data[releaseKey] = []byte("invalid")
+
+	client := fake.NewSimpleClientset(
+		newReleaseSecret("ns", 2, newRelease("web", "deployed", "0.2.0", "")),
+		newReleaseSecret("ns", 1, newRelease("web", "superseded", "0.1.0", "")),
+		newReleaseSecret("ns", 1, newRelease("db", "failed", "1.0.0", "")),
+		invalid,
+		&v1.Secret{ObjectMeta: metaV1.ObjectMeta{Name: "other", Namespace: "ns"}},
+	)
+
+	actual, err := GetHelmReleaseList(client, common.NewNamespaceQuery(nil), dataselect.NoDataSelect)
+	if err != nil {
+		t.Fatalf("GetHelmReleaseList(): unexpected error: %s", err.Error())
+	}
+
+	releases := make([]string, 0)
+	for _, release := range actual.Releases {
+		releases = append(releases, release.ObjectMeta.Name+" "+release.Status+" "+release.Chart.Version)
+	}
+	expected := []string{"db failed 1.0.0", "web deployed 0.2.0"}
+	if !reflect.DeepEqual(releases, expected) {
+		t.Errorf("GetHelmReleaseList() returned %v, expected %v", releases, expected)
+	}
+
+	if actual.ListMeta.TotalItems != 2 || actual.Releases[1].Revision != 2 {
+		t.Errorf("GetHelmReleaseList() returned %#v", actual)
+	}
+
+	if len(actual.Errors) != 1 {
+		t.Errorf("GetHelmReleaseList() returned errors %v, expected error of invalid release", actual.Errors)
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrelease
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ReleaseSecretType is the type of secrets in which Helm stores releases.
+	ReleaseSecretType v1.SecretType = "helm.sh/release.v1"
+
+	// releaseSecretSelector selects secrets owned by Helm. Release name, revision and status are stored in name,
+	// version and status labels.
+	releaseSecretSelector = "owner=helm"
+
+	// releaseKey is the key of release secret data containing the encoded release.
+	releaseKey = "release"
+)
+
+// gzipMagic is the header of gzip compressed data. Helm compresses releases before encoding them.
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// release is the part of Helm release stored in release secret used by the dashboard.
+type release struct {
+	Name      string                 `json:"name"`
+	Namespace string                 `json:"namespace"`
+	Version   int                    `json:"version"`
+	Info      releaseInfo            `json:"info"`
+	Chart     chart                  `json:"chart"`
+	Config    map[string]interface{} `json:"config"`
+	Manifest  string                 `json:"manifest"`
+}
+
+type releaseInfo struct {
+	FirstDeployed releaseTime `json:"first_deployed"`
+	LastDeployed  releaseTime `json:"last_deployed"`
+	Description   string      `json:"description"`
+	Status        string      `json:"status"`
+	Notes         string      `json:"notes"`
+}
+
+type chart struct {
+	Metadata chartMetadata          `json:"metadata"`
+	Values   map[string]interface{} `json:"values"`
+}
+
+type chartMetadata struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion"`
+	Description string `json:"description"`
+}
+
+// releaseTime is time serialized by Helm. Unlike time.Time, zero time is serialized as an empty string.
+type releaseTime struct {
+	time.Time
+}
+
+func (t *releaseTime) UnmarshalJSON(data []byte) error {
+	if string(data) == `""` || string(data) == "null" {
+		return nil
+	}
+	return t.Time.UnmarshalJSON(data)
+}
+
+// decodeRelease decodes release stored in Helm release secret. Release is JSON compressed with gzip and encoded
+// with base64, on top of base64 encoding of secret data.
+func decodeRelease(secret *v1.Secret) (*release, error) {
+	data, err := base64.StdEncoding.DecodeString(string(secret.Data[releaseKey]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encoding of release secret %s: %s", secret.Name, err.Error())
+	}
+
+	if bytes.HasPrefix(data, gzipMagic) {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid compression of release secret %s: %s", secret.Name, err.Error())
+		}
+		defer reader.Close()
+
+		data, err = ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("invalid compression of release secret %s: %s", secret.Name, err.Error())
+		}
+	}
+
+	result := &release{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, fmt.Errorf("invalid content of release secret %s: %s", secret.Name, err.Error())
+	}
+
+	return result, nil
+}
+
+// isReleaseSecret returns true if the secret stores Helm release.
+func isReleaseSecret(secret *v1.Secret) bool {
+	return secret.Type == ReleaseSecretType && len(secret.Labels["name"]) > 0
+}
+
+// getRevision returns revision of the release stored in the secret, as recorded by its version label.
+func getRevision(secret *v1.Secret) int {
+	revision, err := strconv.Atoi(secret.Labels["version"])
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+// getReleaseSecrets filters Helm release secrets and groups them by release. Secrets of every release are sorted by
+// revision, the latest revision is the last one.
+func getReleaseSecrets(secrets []v1.Secret) map[releaseID][]*v1.Secret {
+	result := make(map[releaseID][]*v1.Secret)
+	for i := range secrets {
+		secret := &secrets[i]
+		if !isReleaseSecret(secret) {
+			continue
+		}
+
+		key := releaseID{Namespace: secret.Namespace, Name: secret.Labels["name"]}
+		revisions := append(result[key], secret)
+		for j := len(revisions) - 1; j > 0 && getRevision(revisions[j]) < getRevision(revisions[j-1]); j-- {
+			revisions[j], revisions[j-1] = revisions[j-1], revisions[j]
+		}
+		result[key] = revisions
+	}
+
+	return result
+}
+
+// releaseID identifies release by its namespace and name.
+type releaseID struct {
+	Namespace string
+	Name      string
+}
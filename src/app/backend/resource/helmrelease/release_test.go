@@ -0,0 +1,111 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package helmrelease
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newReleaseSecret returns release secret encoded the same way as Helm does it.
+func newReleaseSecret(namespace string, revision int, release map[string]interface{}) *v1.Secret {
+	data, err := json.Marshal(release)
+	if err != nil {
+		panic(err)
+	}
+
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	writer.Write(data)
+	writer.Close()
+
+	name := release["name"].(string)
+	status := release["info"].(map[string]interface{})["status"].(string)
+	return &v1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      fmt.Sprintf("sh.helm.release.v1.%s.v%d", name, revision),
+			Namespace: namespace,
+			Labels: map[string]string{
+				"owner":   "helm",
+				"name":    name,
+				"status":  status,
+				"version": fmt.Sprint(revision),
+			},
+		},
+		Type: ReleaseSecretType,
+		Data: map[string][]byte{releaseKey: []byte(base64.StdEncoding.EncodeToString(buffer.Bytes()))},
+	}
+}
+
+func newRelease(name, status, chartVersion, manifest string) map[string]interface{} {
+	return map[string]interface{}{
+		"name": name,
+		"info": map[string]interface{}{
+			"first_deployed": "2019-10-01T10:00:00Z",
+			"last_deployed":  "2019-10-02T10:00:00Z",
+			"deleted":        "",
+			"status":         status,
+			"description":    "Upgrade complete",
+		},
+		"chart": map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "app", "version": chartVersion, "appVersion": "1.0"},
+			"values":   map[string]interface{}{"replicas": 1},
+		},
+		"config":   map[string]interface{}{"replicas": 2},
+		"manifest": manifest,
+	}
+}
+
+func TestDecodeRelease(t *testing.T) {
+	secret := newReleaseSecret("ns", 1, newRelease("app", "deployed", "0.1.0", "manifest"))
+
+	actual, err := decodeRelease(secret)
+	if err != nil {
+		t.Fatalf("decodeRelease(): unexpected error: %s", err.Error())
+	}
+
+	expected := &release{
+		Name: "app",
+		Info: releaseInfo{
+			FirstDeployed: releaseTime{time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)},
+			LastDeployed:  releaseTime{time.Date(2019, 10, 2, 10, 0, 0, 0, time.UTC)},
+			Description:   "Upgrade complete",
+			Status:        "deployed",
+		},
+		Chart: chart{
+			Metadata: chartMetadata{Name: "app", Version: "0.1.0", AppVersion: "1.0"},
+			Values:   map[string]interface{}{"replicas": float64(1)},
+		},
+		Config:   map[string]interface{}{"replicas": float64(2)},
+		Manifest: "manifest",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("decodeRelease() == %#v, expected %#v", actual, expected)
+	}
+
+	secret.Data[releaseKey] = []byte("invalid")
+	if _, err := decodeRelease(secret); err == nil {
+		t.Errorf("decodeRelease(): expected error for invalid release")
+	}
+}
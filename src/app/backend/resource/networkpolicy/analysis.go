@@ -0,0 +1,284 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"log"
+
+	v1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// PodTrafficAnalysis describes traffic allowed to and from a pod by all network policies that select it.
+type PodTrafficAnalysis struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Traffic allowed to reach the pod.
+	Ingress TrafficAnalysis `json:"ingress"`
+
+	// Traffic allowed to leave the pod.
+	Egress TrafficAnalysis `json:"egress"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// TrafficAnalysis describes traffic allowed in a single direction. Traffic is allowed if it matches any rule.
+type TrafficAnalysis struct {
+	// Isolated is true if at least one policy that selects the pod applies to this direction. Traffic of pods that
+	// are not isolated is not restricted at all.
+	Isolated bool `json:"isolated"`
+
+	// AllowAll is true if the pod is not isolated or one of the rules allows all peers on all ports.
+	AllowAll bool `json:"allowAll"`
+
+	// Names of policies that select the pod and apply to this direction.
+	Policies []string `json:"policies"`
+
+	// Rules of all policies with peers resolved to existing pods and namespaces.
+	Rules []TrafficRule `json:"rules"`
+}
+
+// TrafficRule is a single ingress or egress rule of a policy.
+type TrafficRule struct {
+	// Name of the policy that defines the rule.
+	Policy string `json:"policy"`
+
+	// Ports allowed by the rule. Empty list means all ports.
+	Ports []TrafficPort `json:"ports"`
+
+	// AllPeers is true if the rule does not restrict peers.
+	AllPeers bool `json:"allPeers"`
+
+	// Peers allowed by the rule.
+	Peers []TrafficPeer `json:"peers"`
+}
+
+// TrafficPort is a port allowed by a rule.
+type TrafficPort struct {
+	Protocol v1.Protocol `json:"protocol"`
+
+	// Port number or name. Empty means all ports of the protocol.
+	Port string `json:"port,omitempty"`
+}
+
+// TrafficPeer is a single peer of a rule with its selectors resolved.
+type TrafficPeer struct {
+	PodSelector       *metaV1.LabelSelector `json:"podSelector,omitempty"`
+	NamespaceSelector *metaV1.LabelSelector `json:"namespaceSelector,omitempty"`
+	IPBlock           *networking.IPBlock   `json:"ipBlock,omitempty"`
+
+	// Namespaces matched by the peer. Empty for IP block peers.
+	Namespaces []string `json:"namespaces"`
+
+	// Pods matched by the peer. Empty for IP block peers.
+	Pods []TrafficPeerPod `json:"pods"`
+}
+
+// TrafficPeerPod identifies a pod matched by a peer.
+type TrafficPeerPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// GetPodTrafficAnalysis returns traffic allowed to and from the pod with given name by all network policies in
+// its namespace. Pods and namespaces of the whole cluster are used to resolve peers of the rules.
+func GetPodTrafficAnalysis(client client.Interface, namespace, name string) (*PodTrafficAnalysis, error) {
+	log.Printf("Analyzing network policies of %s pod in %s namespace", name, namespace)
+
+	pod, err := client.CoreV1().Pods(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	channels := &common.ResourceChannels{
+		NetworkPolicyList: common.GetNetworkPolicyListChannel(client, common.NewSameNamespaceQuery(namespace), 1),
+		PodList:           common.GetPodListChannel(client, common.NewNamespaceQuery(nil), 1),
+		NamespaceList:     common.GetNamespaceListChannel(client, 1),
+	}
+
+	policies := <-channels.NetworkPolicyList.List
+	err = <-channels.NetworkPolicyList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	pods := <-channels.PodList.List
+	err = <-channels.PodList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	namespaces := <-channels.NamespaceList.List
+	err = <-channels.NamespaceList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	result := AnalyzePodTraffic(*pod, policies.Items, pods.Items, namespaces.Items)
+	result.Errors = nonCriticalErrors
+	return result, nil
+}
+
+// AnalyzePodTraffic evaluates all given policies that select the pod. Policies from other namespaces are ignored.
+func AnalyzePodTraffic(pod v1.Pod, policies []networking.NetworkPolicy, pods []v1.Pod,
+	namespaces []v1.Namespace) *PodTrafficAnalysis {
+	result := &PodTrafficAnalysis{
+		ObjectMeta: api.NewObjectMeta(pod.ObjectMeta),
+		TypeMeta:   api.NewTypeMeta(api.ResourceKindPod),
+		Ingress:    TrafficAnalysis{Policies: make([]string, 0), Rules: make([]TrafficRule, 0)},
+		Egress:     TrafficAnalysis{Policies: make([]string, 0), Rules: make([]TrafficRule, 0)},
+		Errors:     make([]error, 0),
+	}
+
+	for _, policy := range policies {
+		if policy.Namespace != pod.Namespace || !selectorMatches(&policy.Spec.PodSelector, pod.Labels) {
+			continue
+		}
+
+		if hasPolicyType(policy, networking.PolicyTypeIngress) {
+			result.Ingress.Isolated = true
+			result.Ingress.Policies = append(result.Ingress.Policies, policy.Name)
+			for _, rule := range policy.Spec.Ingress {
+				result.Ingress.Rules = append(result.Ingress.Rules,
+					toTrafficRule(policy, rule.Ports, rule.From, pods, namespaces))
+			}
+		}
+
+		if hasPolicyType(policy, networking.PolicyTypeEgress) {
+			result.Egress.Isolated = true
+			result.Egress.Policies = append(result.Egress.Policies, policy.Name)
+			for _, rule := range policy.Spec.Egress {
+				result.Egress.Rules = append(result.Egress.Rules,
+					toTrafficRule(policy, rule.Ports, rule.To, pods, namespaces))
+			}
+		}
+	}
+
+	result.Ingress.AllowAll = allowsAll(result.Ingress)
+	result.Egress.AllowAll = allowsAll(result.Egress)
+	return result
+}
+
+func allowsAll(analysis TrafficAnalysis) bool {
+	if !analysis.Isolated {
+		return true
+	}
+
+	for _, rule := range analysis.Rules {
+		if rule.AllPeers && len(rule.Ports) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toTrafficRule(policy networking.NetworkPolicy, ports []networking.NetworkPolicyPort,
+	peers []networking.NetworkPolicyPeer, pods []v1.Pod, namespaces []v1.Namespace) TrafficRule {
+	rule := TrafficRule{
+		Policy:   policy.Name,
+		Ports:    make([]TrafficPort, 0),
+		AllPeers: len(peers) == 0,
+		Peers:    make([]TrafficPeer, 0),
+	}
+
+	for _, port := range ports {
+		trafficPort := TrafficPort{Protocol: v1.ProtocolTCP}
+		if port.Protocol != nil {
+			trafficPort.Protocol = *port.Protocol
+		}
+		if port.Port != nil {
+			trafficPort.Port = port.Port.String()
+		}
+		rule.Ports = append(rule.Ports, trafficPort)
+	}
+
+	for _, peer := range peers {
+		rule.Peers = append(rule.Peers, toTrafficPeer(policy.Namespace, peer, pods, namespaces))
+	}
+
+	return rule
+}
+
+// Resolves peer selectors. Pod selector without namespace selector matches pods from the policy namespace only and
+// namespace selector without pod selector matches all pods from selected namespaces.
+func toTrafficPeer(policyNamespace string, peer networking.NetworkPolicyPeer, pods []v1.Pod,
+	namespaces []v1.Namespace) TrafficPeer {
+	result := TrafficPeer{
+		PodSelector:       peer.PodSelector,
+		NamespaceSelector: peer.NamespaceSelector,
+		IPBlock:           peer.IPBlock,
+		Namespaces:        make([]string, 0),
+		Pods:              make([]TrafficPeerPod, 0),
+	}
+
+	if peer.IPBlock != nil {
+		return result
+	}
+
+	matchedNamespaces := make(map[string]bool)
+	if peer.NamespaceSelector == nil {
+		matchedNamespaces[policyNamespace] = true
+	} else {
+		for _, namespace := range namespaces {
+			if selectorMatches(peer.NamespaceSelector, namespace.Labels) {
+				matchedNamespaces[namespace.Name] = true
+			}
+		}
+	}
+
+	for _, namespace := range namespaces {
+		if matchedNamespaces[namespace.Name] {
+			result.Namespaces = append(result.Namespaces, namespace.Name)
+		}
+	}
+	if peer.NamespaceSelector == nil && len(result.Namespaces) == 0 {
+		// Namespace list may not be available, but policy namespace always exists.
+		result.Namespaces = append(result.Namespaces, policyNamespace)
+	}
+
+	for _, pod := range pods {
+		if !matchedNamespaces[pod.Namespace] {
+			continue
+		}
+
+		if peer.PodSelector == nil || selectorMatches(peer.PodSelector, pod.Labels) {
+			result.Pods = append(result.Pods, TrafficPeerPod{Namespace: pod.Namespace, Name: pod.Name})
+		}
+	}
+
+	return result
+}
+
+func selectorMatches(selector *metaV1.LabelSelector, objectLabels map[string]string) bool {
+	s, err := metaV1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+
+	return s.Matches(labels.Set(objectLabels))
+}
@@ -0,0 +1,193 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAnalyzePodTraffic(t *testing.T) {
+	web := v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "prod", Labels: map[string]string{"app": "web"}}}
+	db := v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "prod", Labels: map[string]string{"app": "db"}}}
+	monitoring := v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "prometheus", Namespace: "monitoring",
+		Labels: map[string]string{"app": "prometheus"}}}
+	pods := []v1.Pod{web, db, monitoring}
+	namespaces := []v1.Namespace{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "prod"}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "monitoring", Labels: map[string]string{"team": "ops"}}},
+	}
+
+	port := intstr.FromInt(5432)
+	udp := v1.ProtocolUDP
+	dnsPort := intstr.FromInt(53)
+
+	cases := []struct {
+		info     string
+		pod      v1.Pod
+		policies []networking.NetworkPolicy
+		ingress  TrafficAnalysis
+		egress   TrafficAnalysis
+	}{
+		{
+			"pod not selected by any policy allows all traffic",
+			web,
+			[]networking.NetworkPolicy{
+				{
+					ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "prod"},
+					Spec: networking.NetworkPolicySpec{
+						PodSelector: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+					},
+				},
+			},
+			TrafficAnalysis{AllowAll: true, Policies: []string{}, Rules: []TrafficRule{}},
+			TrafficAnalysis{AllowAll: true, Policies: []string{}, Rules: []TrafficRule{}},
+		},
+		{
+			"policies from other namespaces are ignored",
+			web,
+			[]networking.NetworkPolicy{
+				{ObjectMeta: metaV1.ObjectMeta{Name: "deny-all", Namespace: "monitoring"}},
+			},
+			TrafficAnalysis{AllowAll: true, Policies: []string{}, Rules: []TrafficRule{}},
+			TrafficAnalysis{AllowAll: true, Policies: []string{}, Rules: []TrafficRule{}},
+		},
+		{
+			"ingress to db from web and monitoring namespace, egress for dns only",
+			db,
+			[]networking.NetworkPolicy{
+				{
+					ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "prod"},
+					Spec: networking.NetworkPolicySpec{
+						PodSelector: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+						PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress, networking.PolicyTypeEgress},
+						Ingress: []networking.NetworkPolicyIngressRule{
+							{
+								Ports: []networking.NetworkPolicyPort{{Port: &port}},
+								From: []networking.NetworkPolicyPeer{
+									{PodSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+									{NamespaceSelector: &metaV1.LabelSelector{
+										MatchLabels: map[string]string{"team": "ops"}}},
+								},
+							},
+						},
+						Egress: []networking.NetworkPolicyEgressRule{
+							{
+								Ports: []networking.NetworkPolicyPort{{Protocol: &udp, Port: &dnsPort}},
+								To:    []networking.NetworkPolicyPeer{{IPBlock: &networking.IPBlock{CIDR: "10.0.0.10/32"}}},
+							},
+						},
+					},
+				},
+			},
+			TrafficAnalysis{
+				Isolated: true,
+				Policies: []string{"db"},
+				Rules: []TrafficRule{
+					{
+						Policy: "db",
+						Ports:  []TrafficPort{{Protocol: v1.ProtocolTCP, Port: "5432"}},
+						Peers: []TrafficPeer{
+							{
+								PodSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+								Namespaces:  []string{"prod"},
+								Pods:        []TrafficPeerPod{{Namespace: "prod", Name: "web"}},
+							},
+							{
+								NamespaceSelector: &metaV1.LabelSelector{MatchLabels: map[string]string{"team": "ops"}},
+								Namespaces:        []string{"monitoring"},
+								Pods:              []TrafficPeerPod{{Namespace: "monitoring", Name: "prometheus"}},
+							},
+						},
+					},
+				},
+			},
+			TrafficAnalysis{
+				Isolated: true,
+				Policies: []string{"db"},
+				Rules: []TrafficRule{
+					{
+						Policy: "db",
+						Ports:  []TrafficPort{{Protocol: v1.ProtocolUDP, Port: "53"}},
+						Peers: []TrafficPeer{
+							{
+								IPBlock:    &networking.IPBlock{CIDR: "10.0.0.10/32"},
+								Namespaces: []string{},
+								Pods:       []TrafficPeerPod{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			"deny all ingress combined with allow all ingress",
+			web,
+			[]networking.NetworkPolicy{
+				{ObjectMeta: metaV1.ObjectMeta{Name: "deny-all", Namespace: "prod"}},
+				{
+					ObjectMeta: metaV1.ObjectMeta{Name: "allow-all", Namespace: "prod"},
+					Spec: networking.NetworkPolicySpec{
+						Ingress: []networking.NetworkPolicyIngressRule{{}},
+					},
+				},
+			},
+			TrafficAnalysis{
+				Isolated: true,
+				AllowAll: true,
+				Policies: []string{"deny-all", "allow-all"},
+				Rules: []TrafficRule{
+					{Policy: "allow-all", Ports: []TrafficPort{}, AllPeers: true, Peers: []TrafficPeer{}},
+				},
+			},
+			TrafficAnalysis{AllowAll: true, Policies: []string{}, Rules: []TrafficRule{}},
+		},
+	}
+
+	for _, c := range cases {
+		actual := AnalyzePodTraffic(c.pod, c.policies, pods, namespaces)
+		if !reflect.DeepEqual(actual.Ingress, c.ingress) {
+			t.Errorf("%s: AnalyzePodTraffic().Ingress ==\n%#v\nexpected\n%#v", c.info, actual.Ingress, c.ingress)
+		}
+		if !reflect.DeepEqual(actual.Egress, c.egress) {
+			t.Errorf("%s: AnalyzePodTraffic().Egress ==\n%#v\nexpected\n%#v", c.info, actual.Egress, c.egress)
+		}
+	}
+}
+
+func TestGetPodTrafficAnalysis(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "prod"}}
+	policy := &networking.NetworkPolicy{ObjectMeta: metaV1.ObjectMeta{Name: "deny-all", Namespace: "prod"}}
+	fakeClient := fake.NewSimpleClientset(pod, policy)
+
+	actual, err := GetPodTrafficAnalysis(fakeClient, "prod", "web")
+	if err != nil {
+		t.Fatalf("GetPodTrafficAnalysis(): unexpected error: %s", err.Error())
+	}
+
+	if !actual.Ingress.Isolated || actual.Ingress.AllowAll || actual.Egress.Isolated {
+		t.Errorf("GetPodTrafficAnalysis() == %#v, expected isolated ingress only", actual)
+	}
+
+	if _, err := GetPodTrafficAnalysis(fakeClient, "prod", "missing"); err == nil {
+		t.Error("GetPodTrafficAnalysis(): expected error for missing pod")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	networking "k8s.io/api/networking/v1"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// getPolicyTypes returns policy types that given network policy applies to. When policy types are not set
+// explicitly, ingress is always assumed and egress only if the policy has egress rules.
+func getPolicyTypes(policy networking.NetworkPolicy) []networking.PolicyType {
+	if len(policy.Spec.PolicyTypes) > 0 {
+		return policy.Spec.PolicyTypes
+	}
+
+	result := []networking.PolicyType{networking.PolicyTypeIngress}
+	if len(policy.Spec.Egress) > 0 {
+		result = append(result, networking.PolicyTypeEgress)
+	}
+
+	return result
+}
+
+func hasPolicyType(policy networking.NetworkPolicy, policyType networking.PolicyType) bool {
+	for _, t := range getPolicyTypes(policy) {
+		if t == policyType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// The code below allows to perform complex data section on []networking.NetworkPolicy
+
+type NetworkPolicyCell networking.NetworkPolicy
+
+func (self NetworkPolicyCell) GetProperty(name dataselect.PropertyName) dataselect.ComparableValue {
+	switch name {
+	case dataselect.NameProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Name)
+	case dataselect.CreationTimestampProperty:
+		return dataselect.StdComparableTime(self.ObjectMeta.CreationTimestamp.Time)
+	case dataselect.NamespaceProperty:
+		return dataselect.StdComparableString(self.ObjectMeta.Namespace)
+	default:
+		// if name is not supported then just return a constant dummy value, sort will have no effect.
+		return nil
+	}
+}
+
+func toCells(std []networking.NetworkPolicy) []dataselect.DataCell {
+	cells := make([]dataselect.DataCell, len(std))
+	for i := range std {
+		cells[i] = NetworkPolicyCell(std[i])
+	}
+	return cells
+}
+
+func fromCells(cells []dataselect.DataCell) []networking.NetworkPolicy {
+	std := make([]networking.NetworkPolicy, len(cells))
+	for i := range std {
+		std[i] = networking.NetworkPolicy(cells[i].(NetworkPolicyCell))
+	}
+	return std
+}
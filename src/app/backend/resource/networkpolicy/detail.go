@@ -0,0 +1,59 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"log"
+
+	networking "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+)
+
+// NetworkPolicyDetail contains detailed information about a network policy.
+type NetworkPolicyDetail struct {
+	// Extends list item structure.
+	NetworkPolicy `json:",inline"`
+
+	// Ingress rules of the policy.
+	Ingress []networking.NetworkPolicyIngressRule `json:"ingress"`
+
+	// Egress rules of the policy.
+	Egress []networking.NetworkPolicyEgressRule `json:"egress"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetNetworkPolicyDetail returns detailed information about a network policy.
+func GetNetworkPolicyDetail(client client.Interface, namespace, name string) (*NetworkPolicyDetail, error) {
+	log.Printf("Getting details of %s network policy in %s namespace", name, namespace)
+
+	policy, err := client.NetworkingV1().NetworkPolicies(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toNetworkPolicyDetail(*policy), nil
+}
+
+func toNetworkPolicyDetail(policy networking.NetworkPolicy) *NetworkPolicyDetail {
+	return &NetworkPolicyDetail{
+		NetworkPolicy: toNetworkPolicy(policy),
+		Ingress:       policy.Spec.Ingress,
+		Egress:        policy.Spec.Egress,
+		Errors:        make([]error, 0),
+	}
+}
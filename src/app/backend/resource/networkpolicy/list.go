@@ -0,0 +1,105 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"log"
+
+	networking "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// NetworkPolicy is a presentation layer view of Kubernetes network policy resource.
+type NetworkPolicy struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Selector of pods that the policy applies to. Empty selector selects all pods in the namespace.
+	PodSelector metaV1.LabelSelector `json:"podSelector"`
+
+	// Traffic directions that the policy applies to.
+	PolicyTypes []networking.PolicyType `json:"policyTypes"`
+}
+
+// NetworkPolicyList contains a list of network policies in the cluster.
+type NetworkPolicyList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of network policies.
+	Items []NetworkPolicy `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetNetworkPolicyList returns a list of all network policies in the given namespace.
+func GetNetworkPolicyList(client client.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*NetworkPolicyList, error) {
+	log.Print("Getting list of network policies")
+
+	channels := &common.ResourceChannels{
+		NetworkPolicyList: common.GetNetworkPolicyListChannel(client, nsQuery, 1),
+	}
+
+	return GetNetworkPolicyListFromChannels(channels, dsQuery)
+}
+
+// GetNetworkPolicyListFromChannels returns a list of all network policies reading required resource list once
+// from the channels.
+func GetNetworkPolicyListFromChannels(channels *common.ResourceChannels,
+	dsQuery *dataselect.DataSelectQuery) (*NetworkPolicyList, error) {
+	policies := <-channels.NetworkPolicyList.List
+	err := <-channels.NetworkPolicyList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return toNetworkPolicyList(policies.Items, nonCriticalErrors, dsQuery), nil
+}
+
+func toNetworkPolicy(policy networking.NetworkPolicy) NetworkPolicy {
+	return NetworkPolicy{
+		ObjectMeta:  api.NewObjectMeta(policy.ObjectMeta),
+		TypeMeta:    api.NewTypeMeta(api.ResourceKindNetworkPolicy),
+		PodSelector: policy.Spec.PodSelector,
+		PolicyTypes: getPolicyTypes(policy),
+	}
+}
+
+func toNetworkPolicyList(policies []networking.NetworkPolicy, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *NetworkPolicyList {
+	result := &NetworkPolicyList{
+		Items:    make([]NetworkPolicy, 0),
+		ListMeta: api.ListMeta{TotalItems: len(policies)},
+		Errors:   nonCriticalErrors,
+	}
+
+	policyCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(policies), dsQuery)
+	policies = fromCells(policyCells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for _, policy := range policies {
+		result.Items = append(result.Items, toNetworkPolicy(policy))
+	}
+
+	return result
+}
@@ -0,0 +1,93 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	networking "k8s.io/api/networking/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func TestGetNetworkPolicyList(t *testing.T) {
+	cases := []struct {
+		policyList      *networking.NetworkPolicyList
+		expectedActions []string
+		expected        *NetworkPolicyList
+	}{
+		{
+			policyList: &networking.NetworkPolicyList{
+				Items: []networking.NetworkPolicy{
+					{
+						ObjectMeta: metaV1.ObjectMeta{Name: "deny-all", Namespace: "default"},
+					},
+					{
+						ObjectMeta: metaV1.ObjectMeta{Name: "egress", Namespace: "default"},
+						Spec: networking.NetworkPolicySpec{
+							PodSelector: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+							Egress:      []networking.NetworkPolicyEgressRule{{}},
+						},
+					},
+				}},
+			expectedActions: []string{"list"},
+			expected: &NetworkPolicyList{
+				ListMeta: api.ListMeta{TotalItems: 2},
+				Items: []NetworkPolicy{
+					{
+						ObjectMeta:  api.ObjectMeta{Name: "deny-all", Namespace: "default"},
+						TypeMeta:    api.TypeMeta{Kind: api.ResourceKindNetworkPolicy},
+						PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+					},
+					{
+						ObjectMeta:  api.ObjectMeta{Name: "egress", Namespace: "default"},
+						TypeMeta:    api.TypeMeta{Kind: api.ResourceKindNetworkPolicy},
+						PodSelector: metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress, networking.PolicyTypeEgress},
+					},
+				},
+				Errors: []error{},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		fakeClient := fake.NewSimpleClientset(c.policyList)
+
+		actual, _ := GetNetworkPolicyList(fakeClient, common.NewNamespaceQuery(nil), dataselect.NoDataSelect)
+
+		actions := fakeClient.Actions()
+		if len(actions) != len(c.expectedActions) {
+			t.Errorf("Unexpected actions: %v, expected %d actions got %d", actions,
+				len(c.expectedActions), len(actions))
+			continue
+		}
+
+		for i, verb := range c.expectedActions {
+			if actions[i].GetVerb() != verb {
+				t.Errorf("Unexpected action: %+v, expected %s", actions[i], verb)
+			}
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetNetworkPolicyList(client) == got\n%#v, expected\n %#v", actual, c.expected)
+		}
+	}
+}
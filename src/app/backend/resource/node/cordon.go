@@ -0,0 +1,41 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"fmt"
+	"log"
+
+	"k8s.io/apimachinery/pkg/types"
+	k8sClient "k8s.io/client-go/kubernetes"
+)
+
+// CordonNode marks node with given name as unschedulable, so no new pods are scheduled on it.
+func CordonNode(client k8sClient.Interface, name string) error {
+	log.Printf("Cordoning %s node", name)
+	return setUnschedulable(client, name, true)
+}
+
+// UncordonNode marks node with given name as schedulable.
+func UncordonNode(client k8sClient.Interface, name string) error {
+	log.Printf("Uncordoning %s node", name)
+	return setUnschedulable(client, name, false)
+}
+
+func setUnschedulable(client k8sClient.Interface, name string, unschedulable bool) error {
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	_, err := client.CoreV1().Nodes().Patch(name, types.MergePatchType, patch)
+	return err
+}
@@ -0,0 +1,45 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCordonNode(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "test-node"}})
+
+	if err := CordonNode(client, "test-node"); err != nil {
+		t.Fatalf("CordonNode(): unexpected error: %s", err.Error())
+	}
+
+	node, _ := client.CoreV1().Nodes().Get("test-node", metaV1.GetOptions{})
+	if !node.Spec.Unschedulable {
+		t.Errorf("CordonNode(): expected node to be unschedulable")
+	}
+
+	if err := UncordonNode(client, "test-node"); err != nil {
+		t.Fatalf("UncordonNode(): unexpected error: %s", err.Error())
+	}
+
+	node, _ = client.CoreV1().Nodes().Get("test-node", metaV1.GetOptions{})
+	if node.Spec.Unschedulable {
+		t.Errorf("UncordonNode(): expected node to be schedulable")
+	}
+}
@@ -114,6 +114,12 @@ type NodeDetail struct {
 	// Taints
 	Taints []v1.Taint `json:"taints,omitempty"`
 
+	// Taints of the node together with pods that tolerate them.
+	TaintTolerations []NodeTaint `json:"taintTolerations"`
+
+	// Pods running on the node that would be evicted by a new NoExecute taint.
+	NoExecuteEvictablePods []api.ObjectMeta `json:"noExecuteEvictablePods"`
+
 	// Addresses is a list of addresses reachable to the node. Queried from cloud provider, if available.
 	Addresses []v1.NodeAddress `json:"addresses,omitempty"`
 
@@ -162,7 +168,7 @@ func GetNodeDetail(client k8sClient.Interface, metricClient metricapi.MetricClie
 	}
 
 	metrics, _ := metricPromises.GetMetrics()
-	nodeDetails := toNodeDetail(*node, podList, eventList, allocatedResources, metrics, nonCriticalErrors)
+	nodeDetails := toNodeDetail(*node, pods.Items, podList, eventList, allocatedResources, metrics, nonCriticalErrors)
 	return &nodeDetails, nil
 }
 
@@ -320,7 +326,7 @@ func getNodePods(client k8sClient.Interface, node v1.Node) (*v1.PodList, error)
 	})
 }
 
-func toNodeDetail(node v1.Node, pods *pod.PodList, eventList *common.EventList,
+func toNodeDetail(node v1.Node, rawPods []v1.Pod, pods *pod.PodList, eventList *common.EventList,
 	allocatedResources NodeAllocatedResources, metrics []metricapi.Metric, nonCriticalErrors []error) NodeDetail {
 	return NodeDetail{
 		Node: Node{
@@ -328,18 +334,20 @@ func toNodeDetail(node v1.Node, pods *pod.PodList, eventList *common.EventList,
 			TypeMeta:           api.NewTypeMeta(api.ResourceKindNode),
 			AllocatedResources: allocatedResources,
 		},
-		Phase:           node.Status.Phase,
-		ProviderID:      node.Spec.ProviderID,
-		PodCIDR:         node.Spec.PodCIDR,
-		Unschedulable:   node.Spec.Unschedulable,
-		NodeInfo:        node.Status.NodeInfo,
-		Conditions:      getNodeConditions(node),
-		ContainerImages: getContainerImages(node),
-		PodList:         *pods,
-		EventList:       *eventList,
-		Metrics:         metrics,
-		Taints:          node.Spec.Taints,
-		Addresses:       node.Status.Addresses,
-		Errors:          nonCriticalErrors,
+		Phase:                  node.Status.Phase,
+		ProviderID:             node.Spec.ProviderID,
+		PodCIDR:                node.Spec.PodCIDR,
+		Unschedulable:          node.Spec.Unschedulable,
+		NodeInfo:               node.Status.NodeInfo,
+		Conditions:             getNodeConditions(node),
+		ContainerImages:        getContainerImages(node),
+		PodList:                *pods,
+		EventList:              *eventList,
+		Metrics:                metrics,
+		Taints:                 node.Spec.Taints,
+		TaintTolerations:       getNodeTaints(node, rawPods),
+		NoExecuteEvictablePods: getNoExecuteEvictablePods(rawPods),
+		Addresses:              node.Status.Addresses,
+		Errors:                 nonCriticalErrors,
 	}
 }
@@ -75,8 +75,10 @@ func TestGetNodeDetail(t *testing.T) {
 				EventList: common.EventList{
 					Events: make([]common.Event, 0),
 				},
-				Metrics: make([]metricapi.Metric, 0),
-				Errors:  []error{},
+				Metrics:                make([]metricapi.Metric, 0),
+				TaintTolerations:       make([]NodeTaint, 0),
+				NoExecuteEvictablePods: make([]api.ObjectMeta, 0),
+				Errors:                 []error{},
 			},
 		},
 	}
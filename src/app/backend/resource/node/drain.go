@@ -0,0 +1,228 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	k8sClient "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// DefaultDrainTimeout is used when drain spec does not specify timeout.
+const DefaultDrainTimeout = 5 * time.Minute
+
+// Annotation set by kubelet on mirror pods of static pods. Mirror pods can not be evicted.
+const mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+// Interval between eviction retries and pod deletion checks. Variable to allow overriding in tests.
+var drainPollInterval = 5 * time.Second
+
+// DrainSpec contains options of node drain.
+type DrainSpec struct {
+	// Evict also pods that use emptyDir volumes. Data stored in these volumes is lost.
+	DeleteEmptyDirData bool `json:"deleteEmptyDirData"`
+
+	// Grace period given to evicted pods in seconds. Grace period of the pod is used if not set.
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds"`
+
+	// Maximum time in seconds to wait for all pods to be evicted. DefaultDrainTimeout is used if not set.
+	TimeoutSeconds int64 `json:"timeoutSeconds"`
+}
+
+// DrainStatus is a state of a single pod during node drain or the final state of the drain.
+type DrainStatus string
+
+// List of states reported during node drain.
+const (
+	// Pod is not evicted, i.e. it is managed by a daemon set or it is a mirror pod.
+	DrainStatusSkipped DrainStatus = "Skipped"
+	// Eviction is blocked by a pod disruption budget and will be retried.
+	DrainStatusBlocked DrainStatus = "Blocked"
+	// Eviction was accepted and pod is terminating.
+	DrainStatusEvicting DrainStatus = "Evicting"
+	// Pod was deleted.
+	DrainStatusDeleted DrainStatus = "Deleted"
+	// Pod could not be evicted or the drain failed.
+	DrainStatusFailed DrainStatus = "Failed"
+	// All pods were evicted. Reported once at the end of the drain.
+	DrainStatusCompleted DrainStatus = "Completed"
+)
+
+// DrainProgress is reported for every change of pod state during node drain. Pod namespace and name are empty
+// for the final state of the whole drain.
+type DrainProgress struct {
+	Namespace string      `json:"namespace,omitempty"`
+	Name      string      `json:"name,omitempty"`
+	Status    DrainStatus `json:"status"`
+	Message   string      `json:"message,omitempty"`
+}
+
+// DrainNode cordons node with given name and evicts all its pods using eviction API, so pod disruption budgets are
+// respected. Pods managed by daemon sets and mirror pods are skipped. Progress of every pod is reported through the
+// progress function, which is never called concurrently. Returned error means that the drain did not complete.
+func DrainNode(client k8sClient.Interface, name string, spec *DrainSpec, progress func(DrainProgress)) error {
+	log.Printf("Draining %s node", name)
+
+	timeout := DefaultDrainTimeout
+	if spec.TimeoutSeconds > 0 {
+		timeout = time.Duration(spec.TimeoutSeconds) * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	pods, err := client.CoreV1().Pods(metaV1.NamespaceAll).List(metaV1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	var mux sync.Mutex
+	report := func(pod v1.Pod, status DrainStatus, message string) {
+		mux.Lock()
+		defer mux.Unlock()
+		progress(DrainProgress{Namespace: pod.Namespace, Name: pod.Name, Status: status, Message: message})
+	}
+
+	toEvict := make([]v1.Pod, 0)
+	blocked := 0
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != name {
+			continue
+		}
+
+		if reason := getSkipReason(pod); len(reason) > 0 {
+			report(pod, DrainStatusSkipped, reason)
+			continue
+		}
+
+		if !spec.DeleteEmptyDirData && hasEmptyDir(pod) {
+			report(pod, DrainStatusFailed, "Pod uses emptyDir volume. Its data would be lost.")
+			blocked++
+			continue
+		}
+
+		toEvict = append(toEvict, pod)
+	}
+
+	// Node is not even cordoned if any pod would lose its data, so the drain does not leave it half done.
+	if blocked > 0 {
+		return errors.NewInvalid(fmt.Sprintf("%d pods use emptyDir volumes. Enable deletion of emptyDir data "+
+			"to drain the node.", blocked))
+	}
+
+	if err := CordonNode(client, name); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	failed := make(chan bool, len(toEvict))
+	for _, pod := range toEvict {
+		wg.Add(1)
+		go func(pod v1.Pod) {
+			defer wg.Done()
+			if err := evictPod(client, pod, spec.GracePeriodSeconds, deadline, report); err != nil {
+				report(pod, DrainStatusFailed, err.Error())
+				failed <- true
+			}
+		}(pod)
+	}
+
+	wg.Wait()
+	close(failed)
+	if len(failed) > 0 {
+		return errors.NewInternal(fmt.Sprintf("%d pods could not be evicted from %s node", len(failed), name))
+	}
+
+	mux.Lock()
+	defer mux.Unlock()
+	progress(DrainProgress{Status: DrainStatusCompleted})
+	return nil
+}
+
+// Evicts pod and waits for its deletion. Eviction blocked by pod disruption budget is retried until the deadline.
+func evictPod(client k8sClient.Interface, pod v1.Pod, gracePeriodSeconds *int64, deadline time.Time,
+	report func(v1.Pod, DrainStatus, string)) error {
+	eviction := &policy.Eviction{
+		ObjectMeta:    metaV1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		DeleteOptions: &metaV1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds},
+	}
+
+	for {
+		err := client.PolicyV1beta1().Evictions(pod.Namespace).Evict(eviction)
+		if err == nil || errors.IsNotFoundError(err) {
+			break
+		}
+
+		if !errors.IsTooManyRequests(err) {
+			return err
+		}
+
+		report(pod, DrainStatusBlocked, err.Error())
+		if time.Now().Add(drainPollInterval).After(deadline) {
+			return errors.NewInternal("Timed out waiting for pod disruption budget to allow eviction.")
+		}
+		time.Sleep(drainPollInterval)
+	}
+
+	report(pod, DrainStatusEvicting, "")
+	for {
+		current, err := client.CoreV1().Pods(pod.Namespace).Get(pod.Name, metaV1.GetOptions{})
+		if errors.IsNotFoundError(err) || (err == nil && current.UID != pod.UID) {
+			report(pod, DrainStatusDeleted, "")
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if time.Now().Add(drainPollInterval).After(deadline) {
+			return errors.NewInternal("Timed out waiting for pod to be deleted.")
+		}
+		time.Sleep(drainPollInterval)
+	}
+}
+
+// Returns reason why pod should not be evicted or empty string if it should be evicted.
+func getSkipReason(pod v1.Pod) string {
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return "Mirror pods of static pods can not be evicted."
+	}
+
+	if controller := metaV1.GetControllerOf(&pod); controller != nil && controller.Kind == "DaemonSet" {
+		return "Pods managed by daemon sets are ignored."
+	}
+
+	return ""
+}
+
+func hasEmptyDir(pod v1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,169 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+func newDrainTestClient(pods ...*v1.Pod) *fake.Clientset {
+	client := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metaV1.ObjectMeta{Name: "test-node"}})
+	for _, pod := range pods {
+		client.CoreV1().Pods(pod.Namespace).Create(pod)
+	}
+
+	// Evictions of pods named "blocked" are rejected once as if a pod disruption budget did not allow them.
+	blocked := true
+	client.PrependReactor("create", "pods", func(action core.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		eviction := action.(core.CreateAction).GetObject().(*policy.Eviction)
+		if eviction.Name == "blocked" && blocked {
+			blocked = false
+			return true, nil, errors.NewTooManyRequests("Cannot evict pod as it would violate the pod's "+
+				"disruption budget.", 0)
+		}
+
+		return true, nil, client.Tracker().Delete(action.GetResource(), eviction.Namespace, eviction.Name)
+	})
+
+	return client
+}
+
+func TestDrainNode(t *testing.T) {
+	drainPollInterval = time.Millisecond
+	isController := true
+	pods := []*v1.Pod{
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "regular", Namespace: "default", UID: "regular"},
+			Spec:       v1.PodSpec{NodeName: "test-node"},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "blocked", Namespace: "default", UID: "blocked"},
+			Spec:       v1.PodSpec{NodeName: "test-node"},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      "daemon",
+				Namespace: "default",
+				OwnerReferences: []metaV1.OwnerReference{
+					{Kind: "DaemonSet", Name: "ds", Controller: &isController},
+				},
+			},
+			Spec: v1.PodSpec{NodeName: "test-node"},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:        "mirror",
+				Namespace:   "kube-system",
+				Annotations: map[string]string{mirrorPodAnnotation: "hash"},
+			},
+			Spec: v1.PodSpec{NodeName: "test-node"},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "other-node", Namespace: "default"},
+			Spec:       v1.PodSpec{NodeName: "other-node"},
+		},
+	}
+
+	client := newDrainTestClient(pods...)
+	progress := make([]DrainProgress, 0)
+	err := DrainNode(client, "test-node", &DrainSpec{TimeoutSeconds: 10}, func(p DrainProgress) {
+		progress = append(progress, p)
+	})
+	if err != nil {
+		t.Fatalf("DrainNode(): unexpected error: %s", err.Error())
+	}
+
+	node, _ := client.CoreV1().Nodes().Get("test-node", metaV1.GetOptions{})
+	if !node.Spec.Unschedulable {
+		t.Errorf("DrainNode(): expected node to be cordoned")
+	}
+
+	statuses := map[string][]DrainStatus{}
+	for _, p := range progress {
+		statuses[p.Name] = append(statuses[p.Name], p.Status)
+	}
+	expected := map[string][]DrainStatus{
+		"regular": {DrainStatusEvicting, DrainStatusDeleted},
+		"blocked": {DrainStatusBlocked, DrainStatusEvicting, DrainStatusDeleted},
+		"daemon":  {DrainStatusSkipped},
+		"mirror":  {DrainStatusSkipped},
+		"":        {DrainStatusCompleted},
+	}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("DrainNode() reported %v, expected %v", statuses, expected)
+	}
+	if progress[len(progress)-1].Status != DrainStatusCompleted {
+		t.Errorf("DrainNode(): expected last reported status to be %s", DrainStatusCompleted)
+	}
+
+	list, _ := client.CoreV1().Pods(metaV1.NamespaceAll).List(metaV1.ListOptions{})
+	remaining := make([]string, 0)
+	for _, pod := range list.Items {
+		remaining = append(remaining, pod.Name)
+	}
+	sort.Strings(remaining)
+	if !reflect.DeepEqual(remaining, []string{"daemon", "mirror", "other-node"}) {
+		t.Errorf("DrainNode(): remaining pods %v, expected [daemon mirror other-node]", remaining)
+	}
+}
+
+func TestDrainNodeWithEmptyDir(t *testing.T) {
+	drainPollInterval = time.Millisecond
+	pod := &v1.Pod{
+		ObjectMeta: metaV1.ObjectMeta{Name: "cache", Namespace: "default", UID: "cache"},
+		Spec: v1.PodSpec{
+			NodeName: "test-node",
+			Volumes: []v1.Volume{
+				{Name: "data", VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+
+	client := newDrainTestClient(pod)
+	err := DrainNode(client, "test-node", &DrainSpec{}, func(DrainProgress) {})
+	if err == nil {
+		t.Fatalf("DrainNode(): expected error for pod with emptyDir volume")
+	}
+	if _, err := client.CoreV1().Pods("default").Get("cache", metaV1.GetOptions{}); err != nil {
+		t.Errorf("DrainNode(): expected pod with emptyDir volume not to be evicted")
+	}
+	if node, _ := client.CoreV1().Nodes().Get("test-node", metaV1.GetOptions{}); node.Spec.Unschedulable {
+		t.Errorf("DrainNode(): expected node not to be cordoned when drain is refused")
+	}
+
+	err = DrainNode(client, "test-node", &DrainSpec{DeleteEmptyDirData: true}, func(DrainProgress) {})
+	if err != nil {
+		t.Fatalf("DrainNode(): unexpected error: %s", err.Error())
+	}
+	if _, err := client.CoreV1().Pods("default").Get("cache", metaV1.GetOptions{}); !errors.IsNotFoundError(err) {
+		t.Errorf("DrainNode(): expected pod with emptyDir volume to be evicted")
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	k8sClient "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// NodeLabel is a single label of the node.
+type NodeLabel struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// AddLabel sets label with given key and value on the node. Existing label with the same key is overwritten.
+func AddLabel(client k8sClient.Interface, name string, label NodeLabel) error {
+	log.Printf("Adding label %s=%s to %s node", label.Key, label.Value, name)
+
+	if err := ValidateLabel(label); err != nil {
+		return err
+	}
+
+	return patchLabel(client, name, label.Key, &label.Value)
+}
+
+// RemoveLabel removes label with given key from the node.
+func RemoveLabel(client k8sClient.Interface, name, key string) error {
+	log.Printf("Removing label %s from %s node", key, name)
+
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid label key %q: %s", key, strings.Join(errs, "; ")))
+	}
+
+	return patchLabel(client, name, key, nil)
+}
+
+// ValidateLabel checks syntax of label key and value.
+func ValidateLabel(label NodeLabel) error {
+	if errs := validation.IsQualifiedName(label.Key); len(errs) > 0 {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid label key %q: %s", label.Key, strings.Join(errs, "; ")))
+	}
+
+	if errs := validation.IsValidLabelValue(label.Value); len(errs) > 0 {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid label value %q: %s", label.Value,
+			strings.Join(errs, "; ")))
+	}
+
+	return nil
+}
+
+// Labels are changed with merge patch, so only the single label is modified. Nil value removes the label.
+func patchLabel(client k8sClient.Interface, name, key string, value *string) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]*string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = client.CoreV1().Nodes().Patch(name, types.MergePatchType, patch)
+	return err
+}
@@ -0,0 +1,165 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	k8sClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// NodeTaint is a taint of the node together with pods running on the node that tolerate it.
+type NodeTaint struct {
+	v1.Taint `json:",inline"`
+
+	// Pods running on the node that tolerate the taint.
+	TolerantPods []api.ObjectMeta `json:"tolerantPods"`
+}
+
+// AddTaint adds given taint to the node. Existing taint with the same key and effect is replaced.
+func AddTaint(client k8sClient.Interface, name string, taint v1.Taint) error {
+	log.Printf("Adding taint %s:%s to %s node", taint.Key, taint.Effect, name)
+
+	if err := ValidateTaint(taint); err != nil {
+		return err
+	}
+
+	return updateTaints(client, name, func(taints []v1.Taint) ([]v1.Taint, error) {
+		result := []v1.Taint{taint}
+		for _, t := range taints {
+			if t.Key != taint.Key || t.Effect != taint.Effect {
+				result = append(result, t)
+			}
+		}
+		return result, nil
+	})
+}
+
+// RemoveTaint removes taints with given key from the node. If effect is not empty, only the taint with given key
+// and effect is removed.
+func RemoveTaint(client k8sClient.Interface, name, key string, effect v1.TaintEffect) error {
+	log.Printf("Removing taint %s:%s from %s node", key, effect, name)
+
+	return updateTaints(client, name, func(taints []v1.Taint) ([]v1.Taint, error) {
+		result := make([]v1.Taint, 0)
+		for _, t := range taints {
+			if t.Key != key || (len(effect) > 0 && t.Effect != effect) {
+				result = append(result, t)
+			}
+		}
+
+		if len(result) == len(taints) {
+			return nil, errors.NewNotFound(fmt.Sprintf("Taint %s:%s not found on %s node", key, effect, name))
+		}
+		return result, nil
+	})
+}
+
+// ValidateTaint checks syntax of taint key, value and effect.
+func ValidateTaint(taint v1.Taint) error {
+	if errs := validation.IsQualifiedName(taint.Key); len(errs) > 0 {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid taint key %q: %s", taint.Key, strings.Join(errs, "; ")))
+	}
+
+	if errs := validation.IsValidLabelValue(taint.Value); len(errs) > 0 {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid taint value %q: %s", taint.Value, strings.Join(errs, "; ")))
+	}
+
+	switch taint.Effect {
+	case v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute:
+		return nil
+	default:
+		return errors.NewBadRequest(fmt.Sprintf("Invalid taint effect %q, must be one of %s, %s or %s",
+			taint.Effect, v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute))
+	}
+}
+
+// Taints are a list without merge key, so they can not be patched without replacing the whole list. Node is updated
+// with the resource version it was read with and the update is retried on conflicts, e.g. with kubelet status updates.
+func updateTaints(client k8sClient.Interface, name string, update func([]v1.Taint) ([]v1.Taint, error)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := client.CoreV1().Nodes().Get(name, metaV1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		taints, err := update(node.Spec.Taints)
+		if err != nil {
+			return err
+		}
+
+		node.Spec.Taints = taints
+		_, err = client.CoreV1().Nodes().Update(node)
+		return err
+	})
+}
+
+func getNodeTaints(node v1.Node, pods []v1.Pod) []NodeTaint {
+	result := make([]NodeTaint, 0)
+	for _, taint := range node.Spec.Taints {
+		nodeTaint := NodeTaint{Taint: taint, TolerantPods: make([]api.ObjectMeta, 0)}
+		for _, pod := range pods {
+			if toleratesTaint(pod.Spec.Tolerations, &taint) {
+				nodeTaint.TolerantPods = append(nodeTaint.TolerantPods, api.NewObjectMeta(pod.ObjectMeta))
+			}
+		}
+		result = append(result, nodeTaint)
+	}
+
+	return result
+}
+
+// Returns pods that would be evicted by a newly added NoExecute taint, i.e. pods that do not tolerate all NoExecute
+// taints.
+func getNoExecuteEvictablePods(pods []v1.Pod) []api.ObjectMeta {
+	result := make([]api.ObjectMeta, 0)
+	for _, pod := range pods {
+		if !toleratesAllTaints(pod.Spec.Tolerations, v1.TaintEffectNoExecute) {
+			result = append(result, api.NewObjectMeta(pod.ObjectMeta))
+		}
+	}
+
+	return result
+}
+
+func toleratesTaint(tolerations []v1.Toleration, taint *v1.Taint) bool {
+	for _, toleration := range tolerations {
+		if toleration.ToleratesTaint(taint) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toleratesAllTaints(tolerations []v1.Toleration, effect v1.TaintEffect) bool {
+	for _, toleration := range tolerations {
+		if len(toleration.Key) == 0 && toleration.Operator == v1.TolerationOpExists &&
+			(len(toleration.Effect) == 0 || toleration.Effect == effect) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+func TestAddAndRemoveTaint(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: "test-node"},
+		Spec: v1.NodeSpec{Taints: []v1.Taint{
+			{Key: "dedicated", Value: "old", Effect: v1.TaintEffectNoSchedule},
+			{Key: "other", Effect: v1.TaintEffectNoExecute},
+		}},
+	})
+
+	err := AddTaint(client, "test-node", v1.Taint{Key: "dedicated", Value: "new", Effect: v1.TaintEffectNoSchedule})
+	if err != nil {
+		t.Fatalf("AddTaint(): unexpected error: %s", err.Error())
+	}
+
+	node, _ := client.CoreV1().Nodes().Get("test-node", metaV1.GetOptions{})
+	expected := []v1.Taint{
+		{Key: "dedicated", Value: "new", Effect: v1.TaintEffectNoSchedule},
+		{Key: "other", Effect: v1.TaintEffectNoExecute},
+	}
+	if !reflect.DeepEqual(node.Spec.Taints, expected) {
+		t.Errorf("AddTaint(): got taints %v, expected %v", node.Spec.Taints, expected)
+	}
+
+	if err := RemoveTaint(client, "test-node", "other", ""); err != nil {
+		t.Fatalf("RemoveTaint(): unexpected error: %s", err.Error())
+	}
+
+	node, _ = client.CoreV1().Nodes().Get("test-node", metaV1.GetOptions{})
+	if !reflect.DeepEqual(node.Spec.Taints, expected[:1]) {
+		t.Errorf("RemoveTaint(): got taints %v, expected %v", node.Spec.Taints, expected[:1])
+	}
+
+	if err := RemoveTaint(client, "test-node", "other", ""); !errors.IsNotFoundError(err) {
+		t.Errorf("RemoveTaint(): expected not found error, got %v", err)
+	}
+}
+
+func TestValidateTaint(t *testing.T) {
+	cases := []struct {
+		taint v1.Taint
+		valid bool
+	}{
+		{v1.Taint{Key: "example.com/dedicated", Value: "gpu", Effect: v1.TaintEffectNoExecute}, true},
+		{v1.Taint{Key: "dedicated", Effect: v1.TaintEffectPreferNoSchedule}, true},
+		{v1.Taint{Key: "-invalid", Effect: v1.TaintEffectNoSchedule}, false},
+		{v1.Taint{Key: "dedicated", Value: "in valid", Effect: v1.TaintEffectNoSchedule}, false},
+		{v1.Taint{Key: "dedicated", Effect: "NoRun"}, false},
+	}
+
+	for _, c := range cases {
+		if err := ValidateTaint(c.taint); (err == nil) != c.valid {
+			t.Errorf("ValidateTaint(%v) == %v, expected valid: %t", c.taint, err, c.valid)
+		}
+	}
+}
+
+func TestAddAndRemoveLabel(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metaV1.ObjectMeta{Name: "test-node", Labels: map[string]string{"zone": "a"}},
+	})
+
+	if err := AddLabel(client, "test-node", NodeLabel{Key: "example.com/disk", Value: "ssd"}); err != nil {
+		t.Fatalf("AddLabel(): unexpected error: %s", err.Error())
+	}
+
+	node, _ := client.CoreV1().Nodes().Get("test-node", metaV1.GetOptions{})
+	expected := map[string]string{"zone": "a", "example.com/disk": "ssd"}
+	if !reflect.DeepEqual(node.Labels, expected) {
+		t.Errorf("AddLabel(): got labels %v, expected %v", node.Labels, expected)
+	}
+
+	if err := RemoveLabel(client, "test-node", "zone"); err != nil {
+		t.Fatalf("RemoveLabel(): unexpected error: %s", err.Error())
+	}
+
+	node, _ = client.CoreV1().Nodes().Get("test-node", metaV1.GetOptions{})
+	expected = map[string]string{"example.com/disk": "ssd"}
+	if !reflect.DeepEqual(node.Labels, expected) {
+		t.Errorf("RemoveLabel(): got labels %v, expected %v", node.Labels, expected)
+	}
+
+	if err := AddLabel(client, "test-node", NodeLabel{Key: "zone", Value: "not valid"}); err == nil {
+		t.Errorf("AddLabel(): expected validation error")
+	}
+}
+
+func TestGetNodeTaints(t *testing.T) {
+	node := v1.Node{Spec: v1.NodeSpec{Taints: []v1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+	}}}
+	pods := []v1.Pod{
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "tolerant"},
+			Spec: v1.PodSpec{Tolerations: []v1.Toleration{
+				{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+			}},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "tolerates-everything"},
+			Spec:       v1.PodSpec{Tolerations: []v1.Toleration{{Operator: v1.TolerationOpExists}}},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "intolerant"},
+		},
+	}
+
+	taints := getNodeTaints(node, pods)
+	expected := []NodeTaint{{
+		Taint:        node.Spec.Taints[0],
+		TolerantPods: []api.ObjectMeta{{Name: "tolerant"}, {Name: "tolerates-everything"}},
+	}}
+	if !reflect.DeepEqual(taints, expected) {
+		t.Errorf("getNodeTaints() == %v, expected %v", taints, expected)
+	}
+
+	evictable := getNoExecuteEvictablePods(pods)
+	expectedEvictable := []api.ObjectMeta{{Name: "tolerant"}, {Name: "intolerant"}}
+	if !reflect.DeepEqual(evictable, expectedEvictable) {
+		t.Errorf("getNoExecuteEvictablePods() == %v, expected %v", evictable, expectedEvictable)
+	}
+}
@@ -0,0 +1,369 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overview
+
+import (
+	"log"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/cronjob"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/daemonset"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/event"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/job"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/node"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/persistentvolumeclaim"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/replicaset"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/replicationcontroller"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/statefulset"
+)
+
+// MaxWarningEvents is the maximum number of most recent warning events included in the overview.
+const MaxWarningEvents = 20
+
+// Overview is a single page summary of cluster health.
+type Overview struct {
+	// Readiness and pressure conditions of cluster nodes.
+	Nodes NodeSummary `json:"nodes"`
+
+	// Number of workloads of every kind in each status.
+	Workloads WorkloadSummary `json:"workloads"`
+
+	// Pods that are pending or failed.
+	ProblemPods []pod.Pod `json:"problemPods"`
+
+	// Most recent warning events, newest first.
+	WarningEvents []common.Event `json:"warningEvents"`
+
+	// Persistent volume claims that are not bound to a volume.
+	UnboundPersistentVolumeClaims []persistentvolumeclaim.PersistentVolumeClaim `json:"unboundPersistentVolumeClaims"`
+
+	// Resources requested by scheduled pods compared to allocatable resources of nodes.
+	Resources ResourceSummary `json:"resources"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// NodeSummary contains number of nodes in each readiness and pressure condition.
+type NodeSummary struct {
+	Total              int `json:"total"`
+	Ready              int `json:"ready"`
+	NotReady           int `json:"notReady"`
+	MemoryPressure     int `json:"memoryPressure"`
+	DiskPressure       int `json:"diskPressure"`
+	PIDPressure        int `json:"pidPressure"`
+	NetworkUnavailable int `json:"networkUnavailable"`
+
+	// Names of nodes that are not ready or report any pressure condition.
+	Unhealthy []string `json:"unhealthy"`
+}
+
+// WorkloadSummary contains status of every workload kind as computed for its list view.
+type WorkloadSummary struct {
+	Deployments            common.ResourceStatus `json:"deployments"`
+	ReplicaSets            common.ResourceStatus `json:"replicaSets"`
+	ReplicationControllers common.ResourceStatus `json:"replicationControllers"`
+	DaemonSets             common.ResourceStatus `json:"daemonSets"`
+	StatefulSets           common.ResourceStatus `json:"statefulSets"`
+	Jobs                   common.ResourceStatus `json:"jobs"`
+	CronJobs               common.ResourceStatus `json:"cronJobs"`
+	Pods                   common.ResourceStatus `json:"pods"`
+}
+
+// ResourceSummary contains total CPU and memory requested by pods and allocatable on nodes.
+type ResourceSummary struct {
+	// CPU requests of all scheduled, not terminated pods in millicores.
+	CPURequests int64 `json:"cpuRequests"`
+
+	// Allocatable CPU of all nodes in millicores.
+	CPUAllocatable int64 `json:"cpuAllocatable"`
+
+	// Memory requests of all scheduled, not terminated pods in bytes.
+	MemoryRequests int64 `json:"memoryRequests"`
+
+	// Allocatable memory of all nodes in bytes.
+	MemoryAllocatable int64 `json:"memoryAllocatable"`
+}
+
+// Number of workload lists that read pods and events from the channels. Overview reads them once more on its own.
+const podAndEventConsumers = 7
+
+// GetOverview returns cluster overview. Every resource list is downloaded only once and shared between all
+// summaries.
+func GetOverview(client client.Interface) (*Overview, error) {
+	log.Print("Getting cluster overview")
+
+	nsQuery := common.NewNamespaceQuery(nil)
+	channels := &common.ResourceChannels{
+		NodeList:                  common.GetNodeListChannel(client, 1),
+		PodList:                   common.GetPodListChannel(client, nsQuery, podAndEventConsumers+1),
+		EventList:                 common.GetEventListChannel(client, nsQuery, podAndEventConsumers+1),
+		DeploymentList:            common.GetDeploymentListChannel(client, nsQuery, 1),
+		ReplicaSetList:            common.GetReplicaSetListChannel(client, nsQuery, 2),
+		ReplicationControllerList: common.GetReplicationControllerListChannel(client, nsQuery, 1),
+		DaemonSetList:             common.GetDaemonSetListChannel(client, nsQuery, 1),
+		StatefulSetList:           common.GetStatefulSetListChannel(client, nsQuery, 1),
+		JobList:                   common.GetJobListChannel(client, nsQuery, 1),
+		CronJobList:               common.GetCronJobListChannel(client, nsQuery, 1),
+		PersistentVolumeClaimList: common.GetPersistentVolumeClaimListChannel(client, nsQuery, 1),
+	}
+
+	return GetOverviewFromChannels(channels, nsQuery)
+}
+
+// GetOverviewFromChannels returns cluster overview reading required resource lists from the channels. Pod and
+// event channels have to be readable podAndEventConsumers + 1 times and replica set channel twice.
+func GetOverviewFromChannels(channels *common.ResourceChannels, nsQuery *common.NamespaceQuery) (*Overview, error) {
+	// Only statuses of workloads are needed, so items are not converted at all.
+	statusQuery := dataselect.NewDataSelectQuery(dataselect.EmptyPagination, dataselect.NoSort, dataselect.NoFilter,
+		dataselect.NoMetrics)
+	result := &Overview{}
+
+	deployments, err := deployment.GetDeploymentListFromChannels(channels, statusQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Workloads.Deployments = deployments.Status
+	result.Errors = errors.MergeErrors(result.Errors, deployments.Errors)
+
+	replicaSets, err := replicaset.GetReplicaSetListFromChannels(channels, statusQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Workloads.ReplicaSets = replicaSets.Status
+	result.Errors = errors.MergeErrors(result.Errors, replicaSets.Errors)
+
+	rcs, err := replicationcontroller.GetReplicationControllerListFromChannels(channels, statusQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Workloads.ReplicationControllers = rcs.Status
+	result.Errors = errors.MergeErrors(result.Errors, rcs.Errors)
+
+	daemonSets, err := daemonset.GetDaemonSetListFromChannels(channels, statusQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Workloads.DaemonSets = daemonSets.Status
+	result.Errors = errors.MergeErrors(result.Errors, daemonSets.Errors)
+
+	statefulSets, err := statefulset.GetStatefulSetListFromChannels(channels, statusQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Workloads.StatefulSets = statefulSets.Status
+	result.Errors = errors.MergeErrors(result.Errors, statefulSets.Errors)
+
+	jobs, err := job.GetJobListFromChannels(channels, statusQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Workloads.Jobs = jobs.Status
+	result.Errors = errors.MergeErrors(result.Errors, jobs.Errors)
+
+	cronJobs, err := cronjob.GetCronJobListFromChannels(channels, statusQuery, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Workloads.CronJobs = cronJobs.Status
+	result.Errors = errors.MergeErrors(result.Errors, cronJobs.Errors)
+
+	// Pods are converted, because status shown on the pod list is needed to find problematic pods.
+	pods, err := pod.GetPodListFromChannels(channels, dataselect.NoDataSelect, nil)
+	if err != nil {
+		return nil, err
+	}
+	result.Workloads.Pods = pods.Status
+	result.Errors = errors.MergeErrors(result.Errors, pods.Errors)
+	result.ProblemPods = getProblemPods(pods.Pods)
+
+	rawPods := <-channels.PodList.List
+	err = <-channels.PodList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	events := <-channels.EventList.List
+	err = <-channels.EventList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	nodes := <-channels.NodeList.List
+	err = <-channels.NodeList.Error
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	pvcs, err := persistentvolumeclaim.GetPersistentVolumeClaimListFromChannels(channels, nsQuery,
+		dataselect.NoDataSelect)
+	if err != nil {
+		return nil, err
+	}
+	result.Errors = errors.MergeErrors(result.Errors, pvcs.Errors)
+	result.Errors = errors.MergeErrors(result.Errors, nonCriticalErrors)
+	if result.Errors == nil {
+		result.Errors = make([]error, 0)
+	}
+
+	result.Nodes = getNodeSummary(nodes)
+	result.WarningEvents = getWarningEvents(events)
+	result.UnboundPersistentVolumeClaims = getUnboundPersistentVolumeClaims(pvcs.Items)
+	result.Resources = getResourceSummary(nodes, rawPods)
+	return result, nil
+}
+
+func getProblemPods(pods []pod.Pod) []pod.Pod {
+	result := make([]pod.Pod, 0)
+	for _, p := range pods {
+		if p.PodStatus.Status == string(v1.PodPending) || p.PodStatus.Status == string(v1.PodFailed) {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
+
+func getNodeSummary(nodes *v1.NodeList) NodeSummary {
+	result := NodeSummary{Unhealthy: make([]string, 0)}
+	if nodes == nil {
+		return result
+	}
+
+	for _, n := range nodes.Items {
+		result.Total++
+		healthy := false
+		for _, condition := range n.Status.Conditions {
+			if condition.Type == v1.NodeReady {
+				healthy = condition.Status == v1.ConditionTrue
+			}
+		}
+
+		if healthy {
+			result.Ready++
+		} else {
+			result.NotReady++
+		}
+
+		for _, condition := range n.Status.Conditions {
+			if condition.Status != v1.ConditionTrue {
+				continue
+			}
+
+			switch condition.Type {
+			case v1.NodeMemoryPressure:
+				result.MemoryPressure++
+			case v1.NodeDiskPressure:
+				result.DiskPressure++
+			case v1.NodePIDPressure:
+				result.PIDPressure++
+			case v1.NodeNetworkUnavailable:
+				result.NetworkUnavailable++
+			default:
+				continue
+			}
+			healthy = false
+		}
+
+		if !healthy {
+			result.Unhealthy = append(result.Unhealthy, n.Name)
+		}
+	}
+
+	return result
+}
+
+func getWarningEvents(events *v1.EventList) []common.Event {
+	result := make([]common.Event, 0)
+	if events == nil {
+		return result
+	}
+
+	warnings := make([]v1.Event, 0)
+	for _, e := range event.FillEventsType(events.Items) {
+		if e.Type == v1.EventTypeWarning {
+			warnings = append(warnings, e)
+		}
+	}
+
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return warnings[j].LastTimestamp.Before(&warnings[i].LastTimestamp)
+	})
+
+	for i := 0; i < len(warnings) && i < MaxWarningEvents; i++ {
+		result = append(result, event.ToEvent(warnings[i]))
+	}
+
+	return result
+}
+
+func getUnboundPersistentVolumeClaims(pvcs []persistentvolumeclaim.PersistentVolumeClaim) []persistentvolumeclaim.PersistentVolumeClaim {
+	result := make([]persistentvolumeclaim.PersistentVolumeClaim, 0)
+	for _, pvc := range pvcs {
+		if pvc.Status != string(v1.ClaimBound) {
+			result = append(result, pvc)
+		}
+	}
+
+	return result
+}
+
+func getResourceSummary(nodes *v1.NodeList, pods *v1.PodList) ResourceSummary {
+	result := ResourceSummary{}
+	if nodes != nil {
+		for _, n := range nodes.Items {
+			result.CPUAllocatable += n.Status.Allocatable.Cpu().MilliValue()
+			result.MemoryAllocatable += n.Status.Allocatable.Memory().Value()
+		}
+	}
+
+	if pods == nil {
+		return result
+	}
+
+	for _, p := range pods.Items {
+		// Only pods that occupy node resources are taken into account.
+		if len(p.Spec.NodeName) == 0 || p.Status.Phase == v1.PodSucceeded || p.Status.Phase == v1.PodFailed {
+			continue
+		}
+
+		reqs, _, err := node.PodRequestsAndLimits(&p)
+		if err != nil {
+			continue
+		}
+
+		result.CPURequests += quantityOf(reqs, v1.ResourceCPU).MilliValue()
+		result.MemoryRequests += quantityOf(reqs, v1.ResourceMemory).Value()
+	}
+
+	return result
+}
+
+func quantityOf(resources map[v1.ResourceName]resource.Quantity, name v1.ResourceName) *resource.Quantity {
+	quantity := resources[name]
+	return &quantity
+}
@@ -0,0 +1,186 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overview
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+func TestGetOverview(t *testing.T) {
+	now := time.Now()
+	nodes := []*v1.Node{
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "node-1"},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+				Allocatable: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("2"),
+					v1.ResourceMemory: resource.MustParse("4Gi"),
+				},
+			},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "node-2"},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{
+					{Type: v1.NodeReady, Status: v1.ConditionFalse},
+					{Type: v1.NodeMemoryPressure, Status: v1.ConditionTrue},
+				},
+				Allocatable: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("1"),
+					v1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}
+
+	requests := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("500m"),
+		v1.ResourceMemory: resource.MustParse("1Gi"),
+	}
+	pods := []*v1.Pod{
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "running", Namespace: "default", UID: "running"},
+			Spec: v1.PodSpec{
+				NodeName:   "node-1",
+				Containers: []v1.Container{{Resources: v1.ResourceRequirements{Requests: requests}}},
+			},
+			Status: v1.PodStatus{
+				Phase: v1.PodRunning,
+				Conditions: []v1.PodCondition{
+					{Type: v1.PodInitialized, Status: v1.ConditionTrue},
+					{Type: v1.PodReady, Status: v1.ConditionTrue},
+				},
+			},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "pending", Namespace: "default", UID: "pending"},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Resources: v1.ResourceRequirements{Requests: requests}}},
+			},
+			Status: v1.PodStatus{Phase: v1.PodPending},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "failed", Namespace: "default", UID: "failed"},
+			Spec: v1.PodSpec{
+				NodeName:   "node-1",
+				Containers: []v1.Container{{Resources: v1.ResourceRequirements{Requests: requests}}},
+			},
+			Status: v1.PodStatus{Phase: v1.PodFailed},
+		},
+	}
+
+	events := []*v1.Event{
+		{
+			ObjectMeta:    metaV1.ObjectMeta{Name: "old-warning", Namespace: "default"},
+			Type:          v1.EventTypeWarning,
+			LastTimestamp: metaV1.NewTime(now.Add(-time.Hour)),
+		},
+		{
+			ObjectMeta:    metaV1.ObjectMeta{Name: "new-warning", Namespace: "default"},
+			Type:          v1.EventTypeWarning,
+			LastTimestamp: metaV1.NewTime(now),
+		},
+		{
+			ObjectMeta:    metaV1.ObjectMeta{Name: "normal", Namespace: "default"},
+			Type:          v1.EventTypeNormal,
+			LastTimestamp: metaV1.NewTime(now),
+		},
+	}
+
+	pvcs := []*v1.PersistentVolumeClaim{
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "bound", Namespace: "default"},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+		},
+		{
+			ObjectMeta: metaV1.ObjectMeta{Name: "unbound", Namespace: "default"},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		},
+	}
+
+	client := fake.NewSimpleClientset()
+	for _, node := range nodes {
+		client.CoreV1().Nodes().Create(node)
+	}
+	for _, pod := range pods {
+		client.CoreV1().Pods(pod.Namespace).Create(pod)
+	}
+	for _, event := range events {
+		client.CoreV1().Events(event.Namespace).Create(event)
+	}
+	for _, pvc := range pvcs {
+		client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(pvc)
+	}
+
+	actual, err := GetOverview(client)
+	if err != nil {
+		t.Fatalf("GetOverview(): unexpected error: %s", err.Error())
+	}
+	if actual.Errors == nil || len(actual.Errors) != 0 {
+		t.Errorf("GetOverview() returned errors %#v, expected empty list", actual.Errors)
+	}
+
+	expectedNodes := NodeSummary{Total: 2, Ready: 1, NotReady: 1, MemoryPressure: 1, Unhealthy: []string{"node-2"}}
+	if !reflect.DeepEqual(actual.Nodes, expectedNodes) {
+		t.Errorf("GetOverview().Nodes == %#v, expected %#v", actual.Nodes, expectedNodes)
+	}
+
+	expectedPods := common.ResourceStatus{Running: 1, Pending: 1, Failed: 1}
+	if !reflect.DeepEqual(actual.Workloads.Pods, expectedPods) {
+		t.Errorf("GetOverview().Workloads.Pods == %#v, expected %#v", actual.Workloads.Pods, expectedPods)
+	}
+
+	problemPods := make([]string, 0)
+	for _, pod := range actual.ProblemPods {
+		problemPods = append(problemPods, pod.ObjectMeta.Name)
+	}
+	if !reflect.DeepEqual(problemPods, []string{"pending", "failed"}) {
+		t.Errorf("GetOverview().ProblemPods == %v, expected [pending failed]", problemPods)
+	}
+
+	warnings := make([]string, 0)
+	for _, event := range actual.WarningEvents {
+		warnings = append(warnings, event.ObjectMeta.Name)
+	}
+	if !reflect.DeepEqual(warnings, []string{"new-warning", "old-warning"}) {
+		t.Errorf("GetOverview().WarningEvents == %v, expected [new-warning old-warning]", warnings)
+	}
+
+	if len(actual.UnboundPersistentVolumeClaims) != 1 ||
+		actual.UnboundPersistentVolumeClaims[0].ObjectMeta.Name != "unbound" {
+		t.Errorf("GetOverview().UnboundPersistentVolumeClaims == %#v, expected only unbound claim",
+			actual.UnboundPersistentVolumeClaims)
+	}
+
+	expectedResources := ResourceSummary{
+		CPURequests:       500,
+		CPUAllocatable:    3000,
+		MemoryRequests:    1024 * 1024 * 1024,
+		MemoryAllocatable: 5 * 1024 * 1024 * 1024,
+	}
+	if !reflect.DeepEqual(actual.Resources, expectedResources) {
+		t.Errorf("GetOverview().Resources == %#v, expected %#v", actual.Resources, expectedResources)
+	}
+}
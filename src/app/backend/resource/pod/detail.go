@@ -54,6 +54,15 @@ type PodDetail struct {
 	EventList                 common.EventList                                `json:"eventList"`
 	PersistentvolumeclaimList persistentvolumeclaim.PersistentVolumeClaimList `json:"persistentVolumeClaimList"`
 
+	// Name of the priority class of the pod.
+	PriorityClassName string `json:"priorityClassName"`
+
+	// Priority of the pod resolved from its priority class.
+	Priority *int32 `json:"priority"`
+
+	// Whether the pod can preempt pods with lower priority.
+	PreemptionPolicy *v1.PreemptionPolicy `json:"preemptionPolicy"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -224,6 +233,9 @@ func toPodDetail(pod *v1.Pod, metrics []metricapi.Metric, configMaps *v1.ConfigM
 		Conditions:                getPodConditions(*pod),
 		EventList:                 *events,
 		PersistentvolumeclaimList: *persistentVolumeClaimList,
+		PriorityClassName:         pod.Spec.PriorityClassName,
+		Priority:                  pod.Spec.Priority,
+		PreemptionPolicy:          pod.Spec.PreemptionPolicy,
 		Errors:                    nonCriticalErrors,
 	}
 }
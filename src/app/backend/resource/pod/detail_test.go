@@ -31,6 +31,9 @@ import (
 )
 
 func TestGetPodDetail(t *testing.T) {
+	priority := int32(1000)
+	preemptionPolicy := v1.PreemptNever
+
 	cases := []struct {
 		pod      *v1.PodList
 		expected *PodDetail
@@ -60,6 +63,32 @@ func TestGetPodDetail(t *testing.T) {
 				Errors:                    []error{},
 			},
 		},
+		{
+			pod: &v1.PodList{Items: []v1.Pod{{
+				ObjectMeta: metaV1.ObjectMeta{Name: "test-pod", Namespace: "test-namespace"},
+				Spec: v1.PodSpec{
+					PriorityClassName: "high-priority",
+					Priority:          &priority,
+					PreemptionPolicy:  &preemptionPolicy,
+				}}}},
+			expected: &PodDetail{
+				TypeMeta:       api.TypeMeta{Kind: api.ResourceKindPod},
+				ObjectMeta:     api.ObjectMeta{Name: "test-pod", Namespace: "test-namespace"},
+				Controller:     &controller.ResourceOwner{},
+				Containers:     []Container{},
+				InitContainers: []Container{},
+				EventList: common.EventList{
+					Events: []common.Event{},
+					Errors: []error{},
+				},
+				Metrics:                   []metricapi.Metric{},
+				PersistentvolumeclaimList: persistentvolumeclaim.PersistentVolumeClaimList{},
+				PriorityClassName:         "high-priority",
+				Priority:                  &priority,
+				PreemptionPolicy:          &preemptionPolicy,
+				Errors:                    []error{},
+			},
+		},
 	}
 
 	for _, c := range cases {
@@ -0,0 +1,76 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"log"
+
+	policy "k8s.io/api/policy/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// PodDisruptionBudgetDetail contains detailed information about a pod disruption budget.
+type PodDisruptionBudgetDetail struct {
+	// Extends list item structure.
+	PodDisruptionBudget `json:",inline"`
+
+	// Pods that were evicted but are not yet deleted, with the time of eviction.
+	DisruptedPods map[string]metaV1.Time `json:"disruptedPods"`
+
+	// Pods covered by the budget.
+	Pods []api.ObjectMeta `json:"pods"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetPodDisruptionBudgetDetail returns detailed information about a pod disruption budget.
+func GetPodDisruptionBudgetDetail(client client.Interface, namespace, name string) (*PodDisruptionBudgetDetail,
+	error) {
+	log.Printf("Getting details of %s pod disruption budget in %s namespace", name, namespace)
+
+	pdb, err := client.PolicyV1beta1().PodDisruptionBudgets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := client.CoreV1().Pods(namespace).List(api.ListEverything)
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	result := toPodDisruptionBudgetDetail(*pdb)
+	for _, pod := range pods.Items {
+		if matchesLabels(*pdb, pod.Labels) {
+			result.Pods = append(result.Pods, api.NewObjectMeta(pod.ObjectMeta))
+		}
+	}
+
+	result.Errors = nonCriticalErrors
+	return result, nil
+}
+
+func toPodDisruptionBudgetDetail(pdb policy.PodDisruptionBudget) *PodDisruptionBudgetDetail {
+	return &PodDisruptionBudgetDetail{
+		PodDisruptionBudget: toPodDisruptionBudget(pdb),
+		DisruptedPods:       pdb.Status.DisruptedPods,
+		Pods:                make([]api.ObjectMeta, 0),
+	}
+}
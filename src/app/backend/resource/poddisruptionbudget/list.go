@@ -0,0 +1,145 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"log"
+
+	policy "k8s.io/api/policy/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// PodDisruptionBudget is a presentation layer view of Kubernetes pod disruption budget resource.
+type PodDisruptionBudget struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Minimum number or percentage of pods that must stay available after an eviction.
+	MinAvailable *intstr.IntOrString `json:"minAvailable"`
+
+	// Maximum number or percentage of pods that can be unavailable after an eviction.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable"`
+
+	// Selector of pods covered by the budget.
+	Selector *metaV1.LabelSelector `json:"selector"`
+
+	// Number of pod disruptions that are currently allowed.
+	DisruptionsAllowed int32 `json:"disruptionsAllowed"`
+
+	// Number of healthy pods.
+	CurrentHealthy int32 `json:"currentHealthy"`
+
+	// Minimum number of healthy pods required by the budget.
+	DesiredHealthy int32 `json:"desiredHealthy"`
+
+	// Total number of pods covered by the budget.
+	ExpectedPods int32 `json:"expectedPods"`
+}
+
+// PodDisruptionBudgetList contains a list of pod disruption budgets in the cluster.
+type PodDisruptionBudgetList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of pod disruption budgets.
+	Items []PodDisruptionBudget `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetPodDisruptionBudgetList returns a list of all pod disruption budgets in the given namespace.
+func GetPodDisruptionBudgetList(client client.Interface, nsQuery *common.NamespaceQuery,
+	dsQuery *dataselect.DataSelectQuery) (*PodDisruptionBudgetList, error) {
+	log.Print("Getting list of pod disruption budgets")
+
+	channels := &common.ResourceChannels{
+		PodDisruptionBudgetList: common.GetPodDisruptionBudgetListChannel(client, nsQuery, 1),
+	}
+
+	return GetPodDisruptionBudgetListFromChannels(channels, dsQuery)
+}
+
+// GetPodDisruptionBudgetListFromChannels returns a list of all pod disruption budgets reading required resource
+// list once from the channels.
+func GetPodDisruptionBudgetListFromChannels(channels *common.ResourceChannels,
+	dsQuery *dataselect.DataSelectQuery) (*PodDisruptionBudgetList, error) {
+	pdbs := <-channels.PodDisruptionBudgetList.List
+	err := <-channels.PodDisruptionBudgetList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return toPodDisruptionBudgetList(pdbs.Items, nonCriticalErrors, dsQuery), nil
+}
+
+// GetMatchingPodDisruptionBudgets returns pod disruption budgets from given namespace that cover pods with given
+// labels, i.e. labels of a workload pod template.
+func GetMatchingPodDisruptionBudgets(client client.Interface, namespace string,
+	podLabels map[string]string) ([]PodDisruptionBudget, error) {
+	result := make([]PodDisruptionBudget, 0)
+	pdbs, err := client.PolicyV1beta1().PodDisruptionBudgets(namespace).List(api.ListEverything)
+	if err != nil {
+		return result, err
+	}
+
+	for _, pdb := range pdbs.Items {
+		if matchesLabels(pdb, podLabels) {
+			result = append(result, toPodDisruptionBudget(pdb))
+		}
+	}
+
+	return result, nil
+}
+
+func toPodDisruptionBudget(pdb policy.PodDisruptionBudget) PodDisruptionBudget {
+	return PodDisruptionBudget{
+		ObjectMeta:         api.NewObjectMeta(pdb.ObjectMeta),
+		TypeMeta:           api.NewTypeMeta(api.ResourceKindPodDisruptionBudget),
+		MinAvailable:       pdb.Spec.MinAvailable,
+		MaxUnavailable:     pdb.Spec.MaxUnavailable,
+		Selector:           pdb.Spec.Selector,
+		DisruptionsAllowed: pdb.Status.PodDisruptionsAllowed,
+		CurrentHealthy:     pdb.Status.CurrentHealthy,
+		DesiredHealthy:     pdb.Status.DesiredHealthy,
+		ExpectedPods:       pdb.Status.ExpectedPods,
+	}
+}
+
+func toPodDisruptionBudgetList(pdbs []policy.PodDisruptionBudget, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *PodDisruptionBudgetList {
+	result := &PodDisruptionBudgetList{
+		Items:    make([]PodDisruptionBudget, 0),
+		ListMeta: api.ListMeta{TotalItems: len(pdbs)},
+		Errors:   nonCriticalErrors,
+	}
+
+	pdbCells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(pdbs), dsQuery)
+	pdbs = fromCells(pdbCells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for _, pdb := range pdbs {
+		result.Items = append(result.Items, toPodDisruptionBudget(pdb))
+	}
+
+	return result
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package poddisruptionbudget
+
+import (
+	"reflect"
+	"testing"
+
+	policy "k8s.io/api/policy/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func TestGetPodDisruptionBudgetList(t *testing.T) {
+	maxUnavailable := intstr.FromString("25%")
+	cases := []struct {
+		pdbList         *policy.PodDisruptionBudgetList
+		expectedActions []string
+		expected        *PodDisruptionBudgetList
+	}{
+		{
+			pdbList: &policy.PodDisruptionBudgetList{
+				Items: []policy.PodDisruptionBudget{
+					{
+						ObjectMeta: metaV1.ObjectMeta{Name: "pdb-1", Namespace: "default"},
+						Spec: policy.PodDisruptionBudgetSpec{
+							MaxUnavailable: &maxUnavailable,
+							Selector:       &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						},
+						Status: policy.PodDisruptionBudgetStatus{
+							PodDisruptionsAllowed: 1,
+							CurrentHealthy:        4,
+							DesiredHealthy:        3,
+							ExpectedPods:          4,
+						},
+					},
+				}},
+			expectedActions: []string{"list"},
+			expected: &PodDisruptionBudgetList{
+				ListMeta: api.ListMeta{TotalItems: 1},
+				Items: []PodDisruptionBudget{
+					{
+						ObjectMeta:         api.ObjectMeta{Name: "pdb-1", Namespace: "default"},
+						TypeMeta:           api.TypeMeta{Kind: api.ResourceKindPodDisruptionBudget},
+						MaxUnavailable:     &maxUnavailable,
+						Selector:           &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+						DisruptionsAllowed: 1,
+						CurrentHealthy:     4,
+						DesiredHealthy:     3,
+						ExpectedPods:       4,
+					},
+				},
+				Errors: []error{},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		fakeClient := fake.NewSimpleClientset(c.pdbList)
+
+		actual, _ := GetPodDisruptionBudgetList(fakeClient, common.NewNamespaceQuery(nil), dataselect.NoDataSelect)
+
+		actions := fakeClient.Actions()
+		if len(actions) != len(c.expectedActions) {
+			t.Errorf("Unexpected actions: %v, expected %d actions got %d", actions,
+				len(c.expectedActions), len(actions))
+			continue
+		}
+
+		for i, verb := range c.expectedActions {
+			if actions[i].GetVerb() != verb {
+				t.Errorf("Unexpected action: %+v, expected %s", actions[i], verb)
+			}
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetPodDisruptionBudgetList(client) == got\n%#v, expected\n %#v", actual, c.expected)
+		}
+	}
+}
+
+func TestGetMatchingPodDisruptionBudgets(t *testing.T) {
+	pdbList := &policy.PodDisruptionBudgetList{
+		Items: []policy.PodDisruptionBudget{
+			{
+				ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec: policy.PodDisruptionBudgetSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				},
+			},
+			{
+				ObjectMeta: metaV1.ObjectMeta{Name: "empty-selector", Namespace: "default"},
+				Spec:       policy.PodDisruptionBudgetSpec{Selector: &metaV1.LabelSelector{}},
+			},
+			{
+				ObjectMeta: metaV1.ObjectMeta{Name: "no-selector", Namespace: "default"},
+			},
+			{
+				ObjectMeta: metaV1.ObjectMeta{Name: "db", Namespace: "default"},
+				Spec: policy.PodDisruptionBudgetSpec{
+					Selector: &metaV1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		labels   map[string]string
+		expected []string
+	}{
+		{map[string]string{"app": "web", "track": "stable"}, []string{"web"}},
+		{map[string]string{"app": "db"}, []string{"db"}},
+		{map[string]string{}, []string{}},
+	}
+
+	for _, c := range cases {
+		fakeClient := fake.NewSimpleClientset(pdbList)
+		pdbs, err := GetMatchingPodDisruptionBudgets(fakeClient, "default", c.labels)
+		if err != nil {
+			t.Fatalf("GetMatchingPodDisruptionBudgets(): unexpected error: %s", err.Error())
+		}
+
+		actual := make([]string, 0)
+		for _, pdb := range pdbs {
+			actual = append(actual, pdb.ObjectMeta.Name)
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetMatchingPodDisruptionBudgets(%v) == %v, expected %v", c.labels, actual, c.expected)
+		}
+	}
+}
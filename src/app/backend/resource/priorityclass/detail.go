@@ -0,0 +1,55 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityclass
+
+import (
+	"log"
+
+	scheduling "k8s.io/api/scheduling/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+)
+
+// PriorityClassDetail contains detailed information about a priority class.
+type PriorityClassDetail struct {
+	// Extends list item structure.
+	PriorityClass `json:",inline"`
+
+	// Description of when this class should be used.
+	Description string `json:"description"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetPriorityClassDetail returns detailed information about a priority class.
+func GetPriorityClassDetail(client client.Interface, name string) (*PriorityClassDetail, error) {
+	log.Printf("Getting details of %s priority class", name)
+
+	priorityClass, err := client.SchedulingV1().PriorityClasses().Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPriorityClassDetail(*priorityClass), nil
+}
+
+func toPriorityClassDetail(priorityClass scheduling.PriorityClass) *PriorityClassDetail {
+	return &PriorityClassDetail{
+		PriorityClass: toPriorityClass(priorityClass),
+		Description:   priorityClass.Description,
+		Errors:        make([]error, 0),
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityclass
+
+import (
+	"log"
+
+	v1 "k8s.io/api/core/v1"
+	scheduling "k8s.io/api/scheduling/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+// PriorityClass is a presentation layer view of Kubernetes priority class resource.
+type PriorityClass struct {
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+
+	// Priority of pods that use this class.
+	Value int32 `json:"value"`
+
+	// Whether this class is used for pods without priority class name.
+	GlobalDefault bool `json:"globalDefault"`
+
+	// Whether pods of this class can preempt pods with lower priority.
+	PreemptionPolicy *v1.PreemptionPolicy `json:"preemptionPolicy"`
+}
+
+// PriorityClassList contains a list of priority classes in the cluster.
+type PriorityClassList struct {
+	ListMeta api.ListMeta `json:"listMeta"`
+
+	// Unordered list of priority classes.
+	Items []PriorityClass `json:"items"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// GetPriorityClassList returns a list of all priority classes in the cluster.
+func GetPriorityClassList(client client.Interface, dsQuery *dataselect.DataSelectQuery) (*PriorityClassList, error) {
+	log.Print("Getting list of priority classes")
+
+	channels := &common.ResourceChannels{
+		PriorityClassList: common.GetPriorityClassListChannel(client, 1),
+	}
+
+	return GetPriorityClassListFromChannels(channels, dsQuery)
+}
+
+// GetPriorityClassListFromChannels returns a list of all priority classes reading required resource list once from
+// the channels.
+func GetPriorityClassListFromChannels(channels *common.ResourceChannels,
+	dsQuery *dataselect.DataSelectQuery) (*PriorityClassList, error) {
+	priorityClasses := <-channels.PriorityClassList.List
+	err := <-channels.PriorityClassList.Error
+	nonCriticalErrors, criticalError := errors.HandleError(err)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	return toPriorityClassList(priorityClasses.Items, nonCriticalErrors, dsQuery), nil
+}
+
+func toPriorityClass(priorityClass scheduling.PriorityClass) PriorityClass {
+	return PriorityClass{
+		ObjectMeta:       api.NewObjectMeta(priorityClass.ObjectMeta),
+		TypeMeta:         api.NewTypeMeta(api.ResourceKindPriorityClass),
+		Value:            priorityClass.Value,
+		GlobalDefault:    priorityClass.GlobalDefault,
+		PreemptionPolicy: priorityClass.PreemptionPolicy,
+	}
+}
+
+func toPriorityClassList(priorityClasses []scheduling.PriorityClass, nonCriticalErrors []error,
+	dsQuery *dataselect.DataSelectQuery) *PriorityClassList {
+	result := &PriorityClassList{
+		Items:    make([]PriorityClass, 0),
+		ListMeta: api.ListMeta{TotalItems: len(priorityClasses)},
+		Errors:   nonCriticalErrors,
+	}
+
+	cells, filteredTotal := dataselect.GenericDataSelectWithFilter(toCells(priorityClasses), dsQuery)
+	priorityClasses = fromCells(cells)
+	result.ListMeta = api.ListMeta{TotalItems: filteredTotal}
+
+	for _, priorityClass := range priorityClasses {
+		result.Items = append(result.Items, toPriorityClass(priorityClass))
+	}
+
+	return result
+}
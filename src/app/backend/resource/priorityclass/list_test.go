@@ -0,0 +1,85 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priorityclass
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	scheduling "k8s.io/api/scheduling/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
+)
+
+func TestGetPriorityClassList(t *testing.T) {
+	preemptNever := v1.PreemptNever
+	cases := []struct {
+		priorityClassList *scheduling.PriorityClassList
+		expectedActions   []string
+		expected          *PriorityClassList
+	}{
+		{
+			priorityClassList: &scheduling.PriorityClassList{
+				Items: []scheduling.PriorityClass{
+					{
+						ObjectMeta:       metaV1.ObjectMeta{Name: "batch"},
+						Value:            100,
+						PreemptionPolicy: &preemptNever,
+						Description:      "Batch jobs",
+					},
+				}},
+			expectedActions: []string{"list"},
+			expected: &PriorityClassList{
+				ListMeta: api.ListMeta{TotalItems: 1},
+				Items: []PriorityClass{
+					{
+						ObjectMeta:       api.ObjectMeta{Name: "batch"},
+						TypeMeta:         api.TypeMeta{Kind: api.ResourceKindPriorityClass},
+						Value:            100,
+						PreemptionPolicy: &preemptNever,
+					},
+				},
+				Errors: []error{},
+			},
+		},
+	}
+
+	for _, c := range cases {
+		fakeClient := fake.NewSimpleClientset(c.priorityClassList)
+
+		actual, _ := GetPriorityClassList(fakeClient, dataselect.NoDataSelect)
+
+		actions := fakeClient.Actions()
+		if len(actions) != len(c.expectedActions) {
+			t.Errorf("Unexpected actions: %v, expected %d actions got %d", actions,
+				len(c.expectedActions), len(actions))
+			continue
+		}
+
+		for i, verb := range c.expectedActions {
+			if actions[i].GetVerb() != verb {
+				t.Errorf("Unexpected action: %+v, expected %s", actions[i], verb)
+			}
+		}
+
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("GetPriorityClassList(client) == got\n%#v, expected\n %#v", actual, c.expected)
+		}
+	}
+}
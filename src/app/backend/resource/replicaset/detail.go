@@ -21,6 +21,7 @@ import (
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
 	hpa "github.com/kubernetes/dashboard/src/app/backend/resource/horizontalpodautoscaler"
+	pdb "github.com/kubernetes/dashboard/src/app/backend/resource/poddisruptionbudget"
 	apps "k8s.io/api/apps/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sClient "k8s.io/client-go/kubernetes"
@@ -39,6 +40,9 @@ type ReplicaSetDetail struct {
 	// List of Horizontal Pod Autoscalers targeting this Replica Set.
 	HorizontalPodAutoscalerList hpa.HorizontalPodAutoscalerList `json:"horizontalPodAutoscalerList"`
 
+	// Pod disruption budgets that cover pods of this Replica Set.
+	PodDisruptionBudgets []pdb.PodDisruptionBudget `json:"podDisruptionBudgets"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -65,15 +69,23 @@ func GetReplicaSetDetail(client k8sClient.Interface, metricClient metricapi.Metr
 		return nil, criticalError
 	}
 
-	rsDetail := toReplicaSetDetail(rs, *podInfo, *hpas, nonCriticalErrors)
+	pdbs, err := pdb.GetMatchingPodDisruptionBudgets(client, namespace, rs.Spec.Template.Labels)
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	rsDetail := toReplicaSetDetail(rs, *podInfo, *hpas, pdbs, nonCriticalErrors)
 	return &rsDetail, nil
 }
 
-func toReplicaSetDetail(rs *apps.ReplicaSet, podInfo common.PodInfo, hpas hpa.HorizontalPodAutoscalerList, nonCriticalErrors []error) ReplicaSetDetail {
+func toReplicaSetDetail(rs *apps.ReplicaSet, podInfo common.PodInfo, hpas hpa.HorizontalPodAutoscalerList,
+	pdbs []pdb.PodDisruptionBudget, nonCriticalErrors []error) ReplicaSetDetail {
 	return ReplicaSetDetail{
 		ReplicaSet:                  ToReplicaSet(rs, &podInfo),
 		Selector:                    rs.Spec.Selector,
 		HorizontalPodAutoscalerList: hpas,
+		PodDisruptionBudgets:        pdbs,
 		Errors:                      nonCriticalErrors,
 	}
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/resource/dataselect"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/horizontalpodautoscaler"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/pod"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/poddisruptionbudget"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/service"
 	apps "k8s.io/api/apps/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,7 +40,7 @@ func TestGetReplicaSetDetail(t *testing.T) {
 	}{
 		{
 			"ns-1", "rs-1",
-			[]string{"get", "list", "list"},
+			[]string{"get", "list", "list", "list"},
 			&apps.ReplicaSet{
 				ObjectMeta: metaV1.ObjectMeta{Name: "rs-1", Namespace: "ns-1",
 					Labels: map[string]string{"app": "test"}},
@@ -67,7 +68,8 @@ func TestGetReplicaSetDetail(t *testing.T) {
 					HorizontalPodAutoscalers: []horizontalpodautoscaler.HorizontalPodAutoscaler{},
 					Errors:                   []error{},
 				},
-				Errors: []error{},
+				PodDisruptionBudgets: []poddisruptionbudget.PodDisruptionBudget{},
+				Errors:               []error{},
 			},
 		},
 	}
@@ -107,6 +109,7 @@ func TestToReplicaSetDetail(t *testing.T) {
 		podInfo     common.PodInfo
 		serviceList service.ServiceList
 		hpaList     horizontalpodautoscaler.HorizontalPodAutoscalerList
+		pdbList     []poddisruptionbudget.PodDisruptionBudget
 		expected    ReplicaSetDetail
 	}{
 		{
@@ -116,11 +119,13 @@ func TestToReplicaSetDetail(t *testing.T) {
 			common.PodInfo{},
 			service.ServiceList{},
 			horizontalpodautoscaler.HorizontalPodAutoscalerList{},
+			[]poddisruptionbudget.PodDisruptionBudget{},
 			ReplicaSetDetail{
 				ReplicaSet: ReplicaSet{
 					TypeMeta: api.TypeMeta{Kind: api.ResourceKindReplicaSet, Scalable: true},
 				},
-				Errors: []error{},
+				PodDisruptionBudgets: []poddisruptionbudget.PodDisruptionBudget{},
+				Errors:               []error{},
 			},
 		}, {
 			&apps.ReplicaSet{ObjectMeta: metaV1.ObjectMeta{Name: "replica-set"}},
@@ -133,6 +138,7 @@ func TestToReplicaSetDetail(t *testing.T) {
 					ObjectMeta: api.ObjectMeta{Name: "hpa-1"},
 				}},
 			},
+			[]poddisruptionbudget.PodDisruptionBudget{{ObjectMeta: api.ObjectMeta{Name: "pdb-1"}}},
 			ReplicaSetDetail{
 				ReplicaSet: ReplicaSet{
 					ObjectMeta: api.ObjectMeta{Name: "replica-set"},
@@ -143,13 +149,14 @@ func TestToReplicaSetDetail(t *testing.T) {
 						ObjectMeta: api.ObjectMeta{Name: "hpa-1"},
 					}},
 				},
-				Errors: []error{},
+				PodDisruptionBudgets: []poddisruptionbudget.PodDisruptionBudget{{ObjectMeta: api.ObjectMeta{Name: "pdb-1"}}},
+				Errors:               []error{},
 			},
 		},
 	}
 
 	for _, c := range cases {
-		actual := toReplicaSetDetail(c.replicaSet, c.podInfo, c.hpaList, []error{})
+		actual := toReplicaSetDetail(c.replicaSet, c.podInfo, c.hpaList, c.pdbList, []error{})
 
 		if !reflect.DeepEqual(actual, c.expected) {
 			t.Errorf("toReplicaSetDetail(%#v, %#v, %#v, %#v, %#v) == \ngot %#v, \nexpected %#v",
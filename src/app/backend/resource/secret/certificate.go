@@ -0,0 +1,61 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CertificateInfo describes the leaf certificate stored in a TLS secret.
+type CertificateInfo struct {
+	Subject   string      `json:"subject"`
+	Issuer    string      `json:"issuer"`
+	DNSNames  []string    `json:"dnsNames"`
+	NotBefore metaV1.Time `json:"notBefore"`
+	NotAfter  metaV1.Time `json:"notAfter"`
+	Expired   bool        `json:"expired"`
+}
+
+// Returns information about the first certificate of a TLS secret. Nil is returned for other secret types and
+// certificates that can not be parsed.
+func getCertificateInfo(secret *v1.Secret) *CertificateInfo {
+	if secret.Type != v1.SecretTypeTLS {
+		return nil
+	}
+
+	block, _ := pem.Decode(secret.Data[v1.TLSCertKey])
+	if block == nil {
+		return nil
+	}
+
+	certificate, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil
+	}
+
+	return &CertificateInfo{
+		Subject:   certificate.Subject.String(),
+		Issuer:    certificate.Issuer.String(),
+		DNSNames:  certificate.DNSNames,
+		NotBefore: metaV1.NewTime(certificate.NotBefore),
+		NotAfter:  metaV1.NewTime(certificate.NotAfter),
+		Expired:   time.Now().After(certificate.NotAfter),
+	}
+}
@@ -17,6 +17,8 @@ package secret
 import (
 	"log"
 
+	"github.com/kubernetes/dashboard/src/app/backend/resource/consumer"
+
 	v1 "k8s.io/api/core/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -33,6 +35,12 @@ type SecretDetail struct {
 	// The serialized form of the secret data is a base64 encoded string,
 	// representing the arbitrary (possibly non-string) data value here.
 	Data map[string][]byte `json:"data"`
+
+	// Certificate stored in the secret. Set only for TLS secrets.
+	Certificate *CertificateInfo `json:"certificate,omitempty"`
+
+	// Pods, resources with pod templates and service accounts that consume the secret.
+	Consumers *consumer.ConsumerList `json:"consumers,omitempty"`
 }
 
 // GetSecretDetail returns detailed information about a secret
@@ -44,12 +52,20 @@ func GetSecretDetail(client kubernetes.Interface, namespace, name string) (*Secr
 		return nil, err
 	}
 
-	return getSecretDetail(rawSecret), nil
+	consumers, err := consumer.GetSecretConsumers(client, rawSecret.ObjectMeta)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := getSecretDetail(rawSecret)
+	detail.Consumers = consumers
+	return detail, nil
 }
 
 func getSecretDetail(rawSecret *v1.Secret) *SecretDetail {
 	return &SecretDetail{
-		Secret: toSecret(rawSecret),
-		Data:   rawSecret.Data,
+		Secret:      toSecret(rawSecret),
+		Data:        rawSecret.Data,
+		Certificate: getCertificateInfo(rawSecret),
 	}
 }
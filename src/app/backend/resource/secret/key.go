@@ -0,0 +1,130 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/consumer"
+)
+
+// KeySpec is a new value of a single secret key.
+type KeySpec struct {
+	// Text value of the key.
+	Value string `json:"value"`
+
+	// Binary value of the key, e.g. uploaded file. It must be Base64 encoded. Takes precedence over Value.
+	BinaryValue []byte `json:"binaryValue,omitempty"`
+
+	// Resource version the change is based on. The change is rejected if the secret was modified in the meantime.
+	// Not checked if empty.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Whether to trigger rolling restart of workloads consuming the secret.
+	Restart bool `json:"restart"`
+}
+
+// KeyUpdateResult describes the secret after a change of a single key.
+type KeyUpdateResult struct {
+	// Resource version of the changed secret.
+	ResourceVersion string `json:"resourceVersion"`
+
+	// Workloads whose rolling restart was triggered.
+	RestartedConsumers []consumer.Consumer `json:"restartedConsumers"`
+
+	// Errors of failed restarts. The secret itself was changed.
+	Errors []error `json:"errors"`
+}
+
+// SetSecretKey creates or updates a single key of the secret.
+func SetSecretKey(client kubernetes.Interface, namespace, name, key string, spec *KeySpec) (*KeyUpdateResult,
+	error) {
+	log.Printf("Setting %s key of %s secret in %s namespace", key, name, namespace)
+
+	if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Invalid key %q: %s", key, strings.Join(errs, "; ")))
+	}
+
+	value := spec.BinaryValue
+	if value == nil {
+		value = []byte(spec.Value)
+	}
+
+	return patchSecret(client, namespace, name, spec.ResourceVersion, map[string]interface{}{key: value},
+		spec.Restart)
+}
+
+// DeleteSecretKey removes a single key of the secret.
+func DeleteSecretKey(client kubernetes.Interface, namespace, name, key, resourceVersion string,
+	restart bool) (*KeyUpdateResult, error) {
+	log.Printf("Deleting %s key of %s secret in %s namespace", key, name, namespace)
+
+	secret, err := client.CoreV1().Secrets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := secret.Data[key]; !ok {
+		return nil, errors.NewNotFound(fmt.Sprintf("Key %s not found in %s secret", key, name))
+	}
+
+	return patchSecret(client, namespace, name, resourceVersion, map[string]interface{}{key: nil}, restart)
+}
+
+// Applies JSON merge patch to data of the secret. Resource version in the patch works as a precondition, so
+// concurrent changes are not overwritten.
+func patchSecret(client kubernetes.Interface, namespace, name, resourceVersion string, data map[string]interface{},
+	restart bool) (*KeyUpdateResult, error) {
+	patch := map[string]interface{}{"data": data}
+	if len(resourceVersion) > 0 {
+		patch["metadata"] = map[string]interface{}{"resourceVersion": resourceVersion}
+	}
+
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := client.CoreV1().Secrets(namespace).Patch(name, types.MergePatchType, body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &KeyUpdateResult{
+		ResourceVersion:    secret.ResourceVersion,
+		RestartedConsumers: make([]consumer.Consumer, 0),
+		Errors:             make([]error, 0),
+	}
+
+	if restart {
+		consumers, err := consumer.GetSecretConsumers(client, secret.ObjectMeta)
+		if err != nil {
+			result.Errors = append(result.Errors, err)
+			return result, nil
+		}
+		result.RestartedConsumers, result.Errors = consumer.RestartConsumers(client, consumers)
+	}
+
+	return result, nil
+}
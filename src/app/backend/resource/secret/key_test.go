@@ -0,0 +1,59 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+func TestSetAndDeleteSecretKey(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: "secret", Namespace: "ns"},
+		Data:       map[string][]byte{"a": []byte("1")},
+	})
+
+	get := func() map[string][]byte {
+		secret, _ := client.CoreV1().Secrets("ns").Get("secret", metaV1.GetOptions{})
+		return secret.Data
+	}
+
+	if _, err := SetSecretKey(client, "ns", "secret", "a", &KeySpec{Value: "updated"}); err != nil {
+		t.Fatalf("SetSecretKey(): unexpected error: %s", err.Error())
+	}
+	if _, err := SetSecretKey(client, "ns", "secret", "b", &KeySpec{BinaryValue: []byte{0, 1}}); err != nil {
+		t.Fatalf("SetSecretKey(): unexpected error: %s", err.Error())
+	}
+	if expected := map[string][]byte{"a": []byte("updated"), "b": {0, 1}}; !reflect.DeepEqual(get(), expected) {
+		t.Errorf("SetSecretKey(): got data %v, expected %v", get(), expected)
+	}
+
+	if _, err := DeleteSecretKey(client, "ns", "secret", "a", "", false); err != nil {
+		t.Fatalf("DeleteSecretKey(): unexpected error: %s", err.Error())
+	}
+	if expected := map[string][]byte{"b": {0, 1}}; !reflect.DeepEqual(get(), expected) {
+		t.Errorf("DeleteSecretKey(): got data %v, expected %v", get(), expected)
+	}
+
+	if _, err := DeleteSecretKey(client, "ns", "secret", "a", "", false); !errors.IsNotFoundError(err) {
+		t.Errorf("DeleteSecretKey(): expected not found error, got %v", err)
+	}
+}
@@ -32,6 +32,7 @@ type SecretSpec interface {
 	GetType() v1.SecretType
 	GetNamespace() string
 	GetData() map[string][]byte
+	Validate() error
 }
 
 // ImagePullSecretSpec is a specification of an image pull secret implements SecretSpec
@@ -63,6 +64,11 @@ func (spec *ImagePullSecretSpec) GetData() map[string][]byte {
 	return map[string][]byte{v1.DockerConfigKey: spec.Data}
 }
 
+// Validate does not check the data, as it is provided already encoded
+func (spec *ImagePullSecretSpec) Validate() error {
+	return nil
+}
+
 // Secret is a single secret returned to the frontend.
 type Secret struct {
 	ObjectMeta api.ObjectMeta `json:"objectMeta"`
@@ -97,6 +103,10 @@ func GetSecretList(client kubernetes.Interface, namespace *common.NamespaceQuery
 
 // CreateSecret creates a single secret using the cluster API client
 func CreateSecret(client kubernetes.Interface, spec SecretSpec) (*Secret, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
 	namespace := spec.GetNamespace()
 	secret := &v1.Secret{
 		ObjectMeta: metaV1.ObjectMeta{
@@ -0,0 +1,290 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// NewSecretSpec creates secret spec matching the type field of given JSON. Specs without type are image pull
+// secret specs, which was the only supported spec in the past.
+func NewSecretSpec(data []byte) (SecretSpec, error) {
+	typed := struct {
+		Type v1.SecretType `json:"type"`
+	}{}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+
+	var spec SecretSpec
+	switch typed.Type {
+	case "", v1.SecretTypeDockercfg:
+		spec = new(ImagePullSecretSpec)
+	case v1.SecretTypeOpaque:
+		spec = new(OpaqueSecretSpec)
+	case v1.SecretTypeTLS:
+		spec = new(TLSSecretSpec)
+	case v1.SecretTypeBasicAuth:
+		spec = new(BasicAuthSecretSpec)
+	case v1.SecretTypeSSHAuth:
+		spec = new(SSHAuthSecretSpec)
+	case v1.SecretTypeDockerConfigJson:
+		spec = new(DockerConfigJSONSecretSpec)
+	default:
+		return nil, errors.NewBadRequest(fmt.Sprintf("Unsupported secret type: %s", typed.Type))
+	}
+
+	if err := json.Unmarshal(data, spec); err != nil {
+		return nil, errors.NewBadRequest(err.Error())
+	}
+	return spec, nil
+}
+
+// OpaqueSecretSpec is a specification of a secret with arbitrary data, implements SecretSpec.
+type OpaqueSecretSpec struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Key value pairs entered as plain text.
+	StringData map[string]string `json:"stringData"`
+
+	// Uploaded files by key. Values must be Base64 encoded. Takes precedence over StringData with the same key.
+	Data map[string][]byte `json:"data"`
+}
+
+// GetName returns the name of the secret
+func (spec *OpaqueSecretSpec) GetName() string {
+	return spec.Name
+}
+
+// GetType returns the type of the secret, which is always api.SecretTypeOpaque
+func (spec *OpaqueSecretSpec) GetType() v1.SecretType {
+	return v1.SecretTypeOpaque
+}
+
+// GetNamespace returns the namespace of the secret
+func (spec *OpaqueSecretSpec) GetNamespace() string {
+	return spec.Namespace
+}
+
+// GetData returns both string and uploaded data of the secret
+func (spec *OpaqueSecretSpec) GetData() map[string][]byte {
+	data := make(map[string][]byte)
+	for key, value := range spec.StringData {
+		data[key] = []byte(value)
+	}
+	for key, value := range spec.Data {
+		data[key] = value
+	}
+	return data
+}
+
+// Validate checks that the secret has at least one key
+func (spec *OpaqueSecretSpec) Validate() error {
+	if len(spec.StringData) == 0 && len(spec.Data) == 0 {
+		return errors.NewBadRequest("Secret has to contain at least one key.")
+	}
+	return nil
+}
+
+// TLSSecretSpec is a specification of a TLS secret, implements SecretSpec.
+type TLSSecretSpec struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// PEM encoded certificate chain.
+	Certificate string `json:"certificate"`
+
+	// PEM encoded private key matching the certificate.
+	Key string `json:"key"`
+}
+
+// GetName returns the name of the secret
+func (spec *TLSSecretSpec) GetName() string {
+	return spec.Name
+}
+
+// GetType returns the type of the secret, which is always api.SecretTypeTLS
+func (spec *TLSSecretSpec) GetType() v1.SecretType {
+	return v1.SecretTypeTLS
+}
+
+// GetNamespace returns the namespace of the secret
+func (spec *TLSSecretSpec) GetNamespace() string {
+	return spec.Namespace
+}
+
+// GetData returns the certificate and the key
+func (spec *TLSSecretSpec) GetData() map[string][]byte {
+	return map[string][]byte{
+		v1.TLSCertKey:       []byte(spec.Certificate),
+		v1.TLSPrivateKeyKey: []byte(spec.Key),
+	}
+}
+
+// Validate checks that the certificate and the key are valid and form a pair
+func (spec *TLSSecretSpec) Validate() error {
+	if _, err := tls.X509KeyPair([]byte(spec.Certificate), []byte(spec.Key)); err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid certificate and key pair: %s", err.Error()))
+	}
+	return nil
+}
+
+// BasicAuthSecretSpec is a specification of a basic authentication secret, implements SecretSpec.
+type BasicAuthSecretSpec struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+}
+
+// GetName returns the name of the secret
+func (spec *BasicAuthSecretSpec) GetName() string {
+	return spec.Name
+}
+
+// GetType returns the type of the secret, which is always api.SecretTypeBasicAuth
+func (spec *BasicAuthSecretSpec) GetType() v1.SecretType {
+	return v1.SecretTypeBasicAuth
+}
+
+// GetNamespace returns the namespace of the secret
+func (spec *BasicAuthSecretSpec) GetNamespace() string {
+	return spec.Namespace
+}
+
+// GetData returns the username and the password
+func (spec *BasicAuthSecretSpec) GetData() map[string][]byte {
+	return map[string][]byte{
+		v1.BasicAuthUsernameKey: []byte(spec.Username),
+		v1.BasicAuthPasswordKey: []byte(spec.Password),
+	}
+}
+
+// Validate checks that the username or the password is set
+func (spec *BasicAuthSecretSpec) Validate() error {
+	if len(spec.Username) == 0 && len(spec.Password) == 0 {
+		return errors.NewBadRequest("Username or password has to be provided.")
+	}
+	return nil
+}
+
+// SSHAuthSecretSpec is a specification of an SSH authentication secret, implements SecretSpec.
+type SSHAuthSecretSpec struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// PEM encoded private key.
+	PrivateKey string `json:"privateKey"`
+}
+
+// GetName returns the name of the secret
+func (spec *SSHAuthSecretSpec) GetName() string {
+	return spec.Name
+}
+
+// GetType returns the type of the secret, which is always api.SecretTypeSSHAuth
+func (spec *SSHAuthSecretSpec) GetType() v1.SecretType {
+	return v1.SecretTypeSSHAuth
+}
+
+// GetNamespace returns the namespace of the secret
+func (spec *SSHAuthSecretSpec) GetNamespace() string {
+	return spec.Namespace
+}
+
+// GetData returns the private key
+func (spec *SSHAuthSecretSpec) GetData() map[string][]byte {
+	return map[string][]byte{v1.SSHAuthPrivateKey: []byte(spec.PrivateKey)}
+}
+
+// Validate checks that the private key is set
+func (spec *SSHAuthSecretSpec) Validate() error {
+	if len(spec.PrivateKey) == 0 {
+		return errors.NewBadRequest("Private key has to be provided.")
+	}
+	return nil
+}
+
+// DockerConfigJSONSecretSpec is a specification of an image pull secret built from registry credentials,
+// implements SecretSpec.
+type DockerConfigJSONSecretSpec struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Registry server, e.g. https://index.docker.io/v1/ or registry.example.com.
+	Registry string `json:"registry"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email"`
+}
+
+// Content of the .dockerconfigjson key.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Email    string `json:"email,omitempty"`
+	Auth     string `json:"auth"`
+}
+
+// GetName returns the name of the secret
+func (spec *DockerConfigJSONSecretSpec) GetName() string {
+	return spec.Name
+}
+
+// GetType returns the type of the secret, which is always api.SecretTypeDockerConfigJson
+func (spec *DockerConfigJSONSecretSpec) GetType() v1.SecretType {
+	return v1.SecretTypeDockerConfigJson
+}
+
+// GetNamespace returns the namespace of the secret
+func (spec *DockerConfigJSONSecretSpec) GetNamespace() string {
+	return spec.Namespace
+}
+
+// GetData returns docker config with credentials of the registry
+func (spec *DockerConfigJSONSecretSpec) GetData() map[string][]byte {
+	config := dockerConfigJSON{Auths: map[string]dockerConfigEntry{
+		spec.Registry: {
+			Username: spec.Username,
+			Password: spec.Password,
+			Email:    spec.Email,
+			Auth:     base64.StdEncoding.EncodeToString([]byte(spec.Username + ":" + spec.Password)),
+		},
+	}}
+
+	// Marshalling of plain strings can not fail.
+	data, _ := json.Marshal(config)
+	return map[string][]byte{v1.DockerConfigJsonKey: data}
+}
+
+// Validate checks that the registry and the username are set
+func (spec *DockerConfigJSONSecretSpec) Validate() error {
+	if len(spec.Registry) == 0 || len(spec.Username) == 0 {
+		return errors.NewBadRequest("Registry and username have to be provided.")
+	}
+	return nil
+}
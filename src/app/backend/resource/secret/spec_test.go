@@ -0,0 +1,196 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secret
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// Returns PEM encoded self-signed certificate and its private key.
+func newCertificate(t *testing.T, commonName string, notAfter time.Time) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    notAfter.Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func TestNewSecretSpec(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected SecretSpec
+	}{
+		{
+			`{"name": "pull", "namespace": "ns", "data": "e30="}`,
+			&ImagePullSecretSpec{Name: "pull", Namespace: "ns", Data: []byte("{}")},
+		},
+		{
+			`{"type": "Opaque", "name": "opaque", "namespace": "ns", "stringData": {"a": "b"}}`,
+			&OpaqueSecretSpec{Name: "opaque", Namespace: "ns", StringData: map[string]string{"a": "b"}},
+		},
+		{
+			`{"type": "kubernetes.io/basic-auth", "name": "basic", "username": "user", "password": "pass"}`,
+			&BasicAuthSecretSpec{Name: "basic", Username: "user", Password: "pass"},
+		},
+		{
+			`{"type": "kubernetes.io/ssh-auth", "name": "ssh", "privateKey": "key"}`,
+			&SSHAuthSecretSpec{Name: "ssh", PrivateKey: "key"},
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := NewSecretSpec([]byte(c.body))
+		if err != nil {
+			t.Errorf("NewSecretSpec(%s): unexpected error: %s", c.body, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("NewSecretSpec(%s) == %#v, expected %#v", c.body, actual, c.expected)
+		}
+	}
+
+	if _, err := NewSecretSpec([]byte(`{"type": "example.com/unknown"}`)); err == nil {
+		t.Errorf("NewSecretSpec(): expected error for unsupported type")
+	}
+}
+
+func TestCreateSecret(t *testing.T) {
+	certificate, key := newCertificate(t, "example.com", time.Now().Add(time.Hour))
+	_, otherKey := newCertificate(t, "other.com", time.Now().Add(time.Hour))
+
+	cases := []struct {
+		spec     SecretSpec
+		expected map[string][]byte
+		valid    bool
+	}{
+		{
+			&OpaqueSecretSpec{
+				Name:       "opaque",
+				StringData: map[string]string{"a": "plain", "b": "overwritten"},
+				Data:       map[string][]byte{"b": {0, 1}},
+			},
+			map[string][]byte{"a": []byte("plain"), "b": {0, 1}},
+			true,
+		},
+		{&OpaqueSecretSpec{Name: "empty"}, nil, false},
+		{
+			&TLSSecretSpec{Name: "tls", Certificate: certificate, Key: key},
+			map[string][]byte{v1.TLSCertKey: []byte(certificate), v1.TLSPrivateKeyKey: []byte(key)},
+			true,
+		},
+		{&TLSSecretSpec{Name: "mismatch", Certificate: certificate, Key: otherKey}, nil, false},
+		{
+			&BasicAuthSecretSpec{Name: "basic", Username: "user", Password: "pass"},
+			map[string][]byte{v1.BasicAuthUsernameKey: []byte("user"), v1.BasicAuthPasswordKey: []byte("pass")},
+			true,
+		},
+		{&SSHAuthSecretSpec{Name: "ssh"}, nil, false},
+		{
+			&DockerConfigJSONSecretSpec{Name: "docker", Registry: "registry.example.com", Username: "user",
+				Password: "pass"},
+			map[string][]byte{v1.DockerConfigJsonKey: []byte(`{"auths":{"registry.example.com":` +
+				`{"username":"user","password":"pass","auth":"dXNlcjpwYXNz"}}}`)},
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset()
+		_, err := CreateSecret(client, c.spec)
+		if (err == nil) != c.valid {
+			t.Errorf("CreateSecret(%s) == %v, expected valid: %t", c.spec.GetName(), err, c.valid)
+			continue
+		}
+		if !c.valid {
+			continue
+		}
+
+		secret, _ := client.CoreV1().Secrets("").Get(c.spec.GetName(), metaV1.GetOptions{})
+		if secret.Type != c.spec.GetType() || !reflect.DeepEqual(secret.Data, c.expected) {
+			t.Errorf("CreateSecret(%s) created %s secret with %s, expected %s secret with %s", c.spec.GetName(),
+				secret.Type, toJSON(secret.Data), c.spec.GetType(), toJSON(c.expected))
+		}
+	}
+}
+
+func TestGetCertificateInfo(t *testing.T) {
+	notAfter := time.Now().Add(-time.Minute).Truncate(time.Second)
+	certificate, _ := newCertificate(t, "example.com", notAfter)
+	secret := &v1.Secret{
+		Type: v1.SecretTypeTLS,
+		Data: map[string][]byte{v1.TLSCertKey: []byte(certificate)},
+	}
+
+	actual := getCertificateInfo(secret)
+	expected := &CertificateInfo{
+		Subject:   "CN=example.com",
+		Issuer:    "CN=example.com",
+		DNSNames:  []string{"example.com"},
+		NotBefore: metaV1.NewTime(notAfter.Add(-time.Hour)),
+		NotAfter:  metaV1.NewTime(notAfter),
+		Expired:   true,
+	}
+	if actual == nil || actual.Subject != expected.Subject || actual.Issuer != expected.Issuer ||
+		!reflect.DeepEqual(actual.DNSNames, expected.DNSNames) || !actual.NotAfter.Equal(&expected.NotAfter) ||
+		!actual.NotBefore.Equal(&expected.NotBefore) || actual.Expired != expected.Expired {
+		t.Errorf("getCertificateInfo() == %#v, expected %#v", actual, expected)
+	}
+
+	if info := getCertificateInfo(&v1.Secret{Type: v1.SecretTypeOpaque}); info != nil {
+		t.Errorf("getCertificateInfo() == %#v, expected nil for opaque secret", info)
+	}
+}
+
+func toJSON(data map[string][]byte) string {
+	result := make(map[string]string)
+	for key, value := range data {
+		result[key] = string(value)
+	}
+	bytes, _ := json.Marshal(result)
+	return string(bytes)
+}
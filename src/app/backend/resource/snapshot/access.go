@@ -0,0 +1,79 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// AccessChecker returns true if the user is allowed to perform the access review, e.g. client manager CanI bound to
+// the request.
+type AccessChecker func(ssar *authorizationv1.SelfSubjectAccessReview) bool
+
+// checkAccess returns forbidden error unless the user is allowed to perform the verb on resources of all objects in
+// the snapshot. Directory store reads snapshots from the file system of Dashboard and secret store with the client of
+// the user, who can be allowed to read snapshot secrets, but not the snapshotted objects. Without the check users
+// could see or remove copies of objects that they have no access to.
+func checkAccess(discoveryClient discovery.DiscoveryInterface, canI AccessChecker, snapshot *Snapshot,
+	namespace, verb string) error {
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+	resources := make(map[schema.GroupResource]bool)
+	for i := range snapshot.Objects {
+		gvk := snapshot.Objects[i].GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			// Resources can be removed from the cluster after the snapshot was taken, e.g. custom resources.
+			plural, _ := meta.UnsafeGuessKindToResource(gvk)
+			resources[plural.GroupResource()] = true
+			continue
+		}
+		resources[mapping.Resource.GroupResource()] = true
+	}
+
+	sorted := make([]schema.GroupResource, 0, len(resources))
+	for resource := range resources {
+		sorted = append(sorted, resource)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].String() < sorted[j].String() })
+
+	for _, resource := range sorted {
+		ssar := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Group:     resource.Group,
+					Resource:  resource.Resource,
+					Verb:      verb,
+				},
+			},
+		}
+		if !canI(ssar) {
+			return errors.NewForbidden(fmt.Sprintf("User is not allowed to %s %s in %s namespace", verb,
+				resource.String(), namespace))
+		}
+	}
+
+	return nil
+}
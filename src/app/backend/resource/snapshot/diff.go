@@ -0,0 +1,190 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"log"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/diff"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/export"
+)
+
+// ObjectStatus describes how an object changed since the snapshot was taken.
+type ObjectStatus string
+
+const (
+	// ObjectStatusUnchanged means that the live object is the same as in the snapshot.
+	ObjectStatusUnchanged ObjectStatus = "unchanged"
+
+	// ObjectStatusModified means that the live object differs from the snapshot.
+	ObjectStatusModified ObjectStatus = "modified"
+
+	// ObjectStatusDeleted means that the object from the snapshot does not exist anymore.
+	ObjectStatusDeleted ObjectStatus = "deleted"
+
+	// ObjectStatusCreated means that the live object is not in the snapshot.
+	ObjectStatusCreated ObjectStatus = "created"
+)
+
+// ObjectDiff is a difference between the live object and its copy in the snapshot.
+type ObjectDiff struct {
+	SnapshotObject
+
+	Status ObjectStatus `json:"status"`
+
+	// Unified diff between normalized YAML of the live object and the object in the snapshot, i.e. the change that
+	// restore of the object would make. Empty for unchanged objects.
+	Diff string `json:"diff"`
+}
+
+// SnapshotDiff is a difference between the snapshot and live objects of its namespace.
+type SnapshotDiff struct {
+	SnapshotInfo
+
+	Objects []ObjectDiff `json:"objects"`
+
+	// List of non-critical errors, that occurred during live objects retrieval.
+	Errors []error `json:"errors"`
+}
+
+// objectKey identifies an object regardless of API version, which can differ between the snapshot and live state.
+type objectKey struct {
+	Group string
+	Kind  string
+	Name  string
+}
+
+func getObjectKey(object *unstructured.Unstructured) objectKey {
+	gvk := object.GroupVersionKind()
+	return objectKey{Group: gvk.Group, Kind: gvk.Kind, Name: object.GetName()}
+}
+
+// GetSnapshotDiff compares the snapshot with the given name with live objects of its namespace. Returns forbidden
+// error unless the user is allowed to list objects of the snapshot in its namespace.
+func GetSnapshotDiff(cfg *rest.Config, canI AccessChecker, store Store, namespace, name string) (*SnapshotDiff,
+	error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSnapshotDiff(discoveryClient, dynamicClient, canI, store, namespace, name)
+}
+
+func getSnapshotDiff(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface,
+	canI AccessChecker, store Store, namespace, name string) (*SnapshotDiff, error) {
+	log.Printf("Comparing snapshot %s with %s namespace\n", name, namespace)
+	snapshot, err := store.Get(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAccess(discoveryClient, canI, snapshot, namespace, "list"); err != nil {
+		return nil, err
+	}
+
+	// Live objects are cleaned the same way as objects in the snapshot, so that only meaningful changes are shown.
+	query, err := export.NewExportQuery("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := export.GetObjects(discoveryClient, dynamicClient, namespace, query)
+	if err != nil {
+		return nil, err
+	}
+
+	liveObjects := make(map[objectKey]*unstructured.Unstructured)
+	for i := range live.Objects {
+		liveObjects[getObjectKey(&live.Objects[i])] = &live.Objects[i]
+	}
+
+	result := &SnapshotDiff{SnapshotInfo: snapshot.SnapshotInfo, Objects: make([]ObjectDiff, 0), Errors: live.Errors}
+	for i := range snapshot.Objects {
+		object := &snapshot.Objects[i]
+		key := getObjectKey(object)
+		objectDiff, err := newObjectDiff(liveObjects[key], object)
+		if err != nil {
+			return nil, err
+		}
+		result.Objects = append(result.Objects, *objectDiff)
+		delete(liveObjects, key)
+	}
+
+	for i := range live.Objects {
+		object := &live.Objects[i]
+		if _, ok := liveObjects[getObjectKey(object)]; !ok {
+			continue
+		}
+
+		objectDiff, err := newObjectDiff(object, nil)
+		if err != nil {
+			return nil, err
+		}
+		result.Objects = append(result.Objects, *objectDiff)
+	}
+
+	return result, nil
+}
+
+// newObjectDiff compares the live object with the object from the snapshot. Any of them can be nil, but not both.
+func newObjectDiff(live, snapshotted *unstructured.Unstructured) (*ObjectDiff, error) {
+	var liveData, snapshotData []byte
+	var err error
+	result := &ObjectDiff{}
+
+	if live != nil {
+		if liveData, err = live.MarshalJSON(); err != nil {
+			return nil, err
+		}
+		result.SnapshotObject = SnapshotObject{APIVersion: live.GetAPIVersion(), Kind: live.GetKind(),
+			Name: live.GetName()}
+	}
+
+	if snapshotted != nil {
+		if snapshotData, err = snapshotted.MarshalJSON(); err != nil {
+			return nil, err
+		}
+		result.SnapshotObject = SnapshotObject{APIVersion: snapshotted.GetAPIVersion(), Kind: snapshotted.GetKind(),
+			Name: snapshotted.GetName()}
+	}
+
+	if result.Diff, err = diff.Objects("live", "snapshot", liveData, snapshotData); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case live == nil:
+		result.Status = ObjectStatusDeleted
+	case snapshotted == nil:
+		result.Status = ObjectStatusCreated
+	case len(result.Diff) > 0:
+		result.Status = ObjectStatusModified
+	default:
+		result.Status = ObjectStatusUnchanged
+	}
+
+	return result, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fileExtension is an extension of snapshot files in directory store.
+const fileExtension = ".json.gz"
+
+// directoryStore stores every snapshot as a gzipped JSON file in a subdirectory named after the namespace, e.g.
+// <dir>/default/20191001-100000.json.gz. It is meant to be used with a volume mounted to Dashboard pod.
+type directoryStore struct {
+	dir string
+}
+
+// NewDirectoryStore creates a store that keeps snapshots in the given directory.
+func NewDirectoryStore(dir string) Store {
+	return &directoryStore{dir: dir}
+}
+
+// List implements Store. Only info at the beginning of snapshot files is read.
+func (store *directoryStore) List(namespace string) ([]SnapshotInfo, error) {
+	result := make([]SnapshotInfo, 0)
+	if err := validateNames(namespace); err != nil {
+		return nil, err
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(store.dir, namespace))
+	if os.IsNotExist(err) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), fileExtension) {
+			continue
+		}
+
+		info, err := store.getInfo(namespace, strings.TrimSuffix(file.Name(), fileExtension))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *info)
+	}
+
+	return result, nil
+}
+
+// getInfo returns info of the snapshot with the given name without decoding its objects.
+func (store *directoryStore) getInfo(namespace, name string) (*SnapshotInfo, error) {
+	path, err := store.path(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, newNotFound(namespace, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := decodeSnapshotInfo(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot file %s: %s", path, err.Error())
+	}
+	return info, nil
+}
+
+// Get implements Store.
+func (store *directoryStore) Get(namespace, name string) (*Snapshot, error) {
+	path, err := store.path(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, newNotFound(namespace, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := decodeSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot file %s: %s", path, err.Error())
+	}
+	return snapshot, nil
+}
+
+// Save implements Store. The snapshot is written to a temporary file first, so that incomplete snapshots are never
+// listed.
+func (store *directoryStore) Save(snapshot *Snapshot) error {
+	path, err := store.path(snapshot.Namespace, snapshot.Name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		return newAlreadyExists(snapshot.Namespace, snapshot.Name)
+	}
+
+	data, err := encodeSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+snapshot.Name)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+// Delete implements Store.
+func (store *directoryStore) Delete(namespace, name string) error {
+	path, err := store.path(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return newNotFound(namespace, name)
+	}
+	return err
+}
+
+func (store *directoryStore) path(namespace, name string) (string, error) {
+	if err := validateNames(namespace, name); err != nil {
+		return "", err
+	}
+	return filepath.Join(store.dir, namespace, name+fileExtension), nil
+}
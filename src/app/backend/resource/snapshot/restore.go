@@ -0,0 +1,138 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+// RestoreSpec selects objects restored from a snapshot.
+type RestoreSpec struct {
+	// Objects to restore. API version can be omitted. If empty, all objects from the snapshot are restored.
+	Objects []SnapshotObject `json:"objects"`
+
+	// Whether only preview the changes without persisting them.
+	DryRun bool `json:"dryRun"`
+}
+
+// RestoreSnapshot applies selected objects from the snapshot with the given name to its namespace. Objects are
+// applied the same way as objects deployed from file, so the result contains the outcome of every object. Returns
+// forbidden error unless the user is allowed to list objects of the snapshot in its namespace.
+func RestoreSnapshot(cfg *rest.Config, canI AccessChecker, store Store, namespace, name string,
+	spec *RestoreSpec) (*deployment.AppDeploymentFromFileResponse, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return restoreSnapshot(discoveryClient, dynamicClient, canI, store, namespace, name, spec)
+}
+
+func restoreSnapshot(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface,
+	canI AccessChecker, store Store, namespace, name string, spec *RestoreSpec) (
+	*deployment.AppDeploymentFromFileResponse, error) {
+	snapshot, err := store.Get(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAccess(discoveryClient, canI, snapshot, namespace, "list"); err != nil {
+		return nil, err
+	}
+
+	objects, err := selectObjects(snapshot, spec.Objects)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Restoring %d objects from snapshot %s to %s namespace\n", len(objects), name, namespace)
+
+	documents := make([]string, 0)
+	for _, object := range objects {
+		data, err := yaml.Marshal(object.Object)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, string(data))
+	}
+
+	return deployment.DeployObjectsFromFile(discoveryClient, dynamicClient, &deployment.AppDeploymentFromFileSpec{
+		Name:      name,
+		Namespace: namespace,
+		Content:   strings.Join(documents, "---\n"),
+		DryRun:    spec.DryRun,
+	})
+}
+
+// selectObjects returns objects from the snapshot in the order of the snapshot. Returns an error if any selected
+// object is not in the snapshot.
+func selectObjects(snapshot *Snapshot, selected []SnapshotObject) ([]unstructured.Unstructured, error) {
+	if len(selected) == 0 {
+		return snapshot.Objects, nil
+	}
+
+	result := make([]unstructured.Unstructured, 0)
+	for _, object := range snapshot.Objects {
+		for _, ref := range selected {
+			if matchesObject(&object, ref) {
+				result = append(result, object)
+				break
+			}
+		}
+	}
+
+	for _, ref := range selected {
+		found := false
+		for i := range result {
+			found = found || matchesObject(&result[i], ref)
+		}
+		if !found {
+			return nil, errors.NewBadRequest(fmt.Sprintf("Object %s %s not found in snapshot %s", ref.Kind, ref.Name,
+				snapshot.Name))
+		}
+	}
+
+	return result, nil
+}
+
+// matchesObject returns true if the object is identified by the reference. Only groups of API versions are
+// compared.
+func matchesObject(object *unstructured.Unstructured, ref SnapshotObject) bool {
+	if object.GetKind() != ref.Kind || object.GetName() != ref.Name {
+		return false
+	}
+	if len(ref.APIVersion) == 0 {
+		return true
+	}
+
+	groupVersion, err := schema.ParseGroupVersion(ref.APIVersion)
+	return err == nil && groupVersion.Group == object.GroupVersionKind().Group
+}
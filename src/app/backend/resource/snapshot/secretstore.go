@@ -0,0 +1,251 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// SnapshotSecretType is a type of secrets that store snapshot chunks.
+	SnapshotSecretType v1.SecretType = "dashboard.kubernetes.io/snapshot"
+
+	namespaceLabel              = "snapshot.dashboard.kubernetes.io/namespace"
+	nameLabel                   = "snapshot.dashboard.kubernetes.io/name"
+	chunkAnnotation             = "snapshot.dashboard.kubernetes.io/chunk"
+	chunksAnnotation            = "snapshot.dashboard.kubernetes.io/chunks"
+	creationTimestampAnnotation = "snapshot.dashboard.kubernetes.io/creation-timestamp"
+	objectCountAnnotation       = "snapshot.dashboard.kubernetes.io/object-count"
+	chunkDataKey                = "chunk"
+
+	// maxChunkSize keeps snapshot secrets well below the size limit of objects stored in etcd.
+	maxChunkSize = 768 * 1024
+)
+
+// secretStore splits every serialized snapshot into chunks stored in secrets of a single namespace. Secrets are used
+// instead of config maps, because snapshots contain secrets of the snapshotted namespace.
+type secretStore struct {
+	client    kubernetes.Interface
+	namespace string
+	chunkSize int
+}
+
+// NewSecretStore creates a store that keeps snapshots in secrets of the given namespace.
+func NewSecretStore(client kubernetes.Interface, namespace string) Store {
+	return &secretStore{client: client, namespace: namespace, chunkSize: maxChunkSize}
+}
+
+// List implements Store. Snapshots with missing chunks, e.g. the ones that are being saved, are not listed.
+func (store *secretStore) List(namespace string) ([]SnapshotInfo, error) {
+	if err := validateNames(namespace); err != nil {
+		return nil, err
+	}
+
+	secrets, err := store.listChunks(labels.Set{namespaceLabel: namespace})
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(map[string][]v1.Secret)
+	for _, secret := range secrets {
+		name := secret.Labels[nameLabel]
+		chunks[name] = append(chunks[name], secret)
+	}
+
+	result := make([]SnapshotInfo, 0)
+	for name, secrets := range chunks {
+		if _, err := sortChunks(secrets); err != nil {
+			continue
+		}
+		result = append(result, getSnapshotInfo(namespace, name, &secrets[0]))
+	}
+
+	return result, nil
+}
+
+// Get implements Store.
+func (store *secretStore) Get(namespace, name string) (*Snapshot, error) {
+	if err := validateNames(namespace, name); err != nil {
+		return nil, err
+	}
+
+	secrets, err := store.listChunks(labels.Set{namespaceLabel: namespace, nameLabel: name})
+	if err != nil {
+		return nil, err
+	}
+	if len(secrets) == 0 {
+		return nil, newNotFound(namespace, name)
+	}
+
+	data, err := sortChunks(secrets)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s of namespace %s: %s", name, namespace, err.Error())
+	}
+
+	snapshot, err := decodeSnapshot(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s of namespace %s: %s", name, namespace, err.Error())
+	}
+	return snapshot, nil
+}
+
+// Save implements Store. If any chunk cannot be created, already created chunks are removed.
+func (store *secretStore) Save(snapshot *Snapshot) error {
+	if err := validateNames(snapshot.Namespace, snapshot.Name); err != nil {
+		return err
+	}
+
+	existing, err := store.listChunks(labels.Set{namespaceLabel: snapshot.Namespace, nameLabel: snapshot.Name})
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return newAlreadyExists(snapshot.Namespace, snapshot.Name)
+	}
+
+	data, err := encodeSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+
+	chunks := make([][]byte, 0)
+	for len(data) > store.chunkSize {
+		chunks = append(chunks, data[:store.chunkSize])
+		data = data[store.chunkSize:]
+	}
+	chunks = append(chunks, data)
+
+	created := make([]string, 0)
+	for i, chunk := range chunks {
+		secret := &v1.Secret{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      fmt.Sprintf("snapshot-%s-%s-%d", snapshot.Namespace, snapshot.Name, i),
+				Namespace: store.namespace,
+				Labels: map[string]string{
+					namespaceLabel: snapshot.Namespace,
+					nameLabel:      snapshot.Name,
+				},
+				Annotations: map[string]string{
+					chunkAnnotation:             strconv.Itoa(i),
+					chunksAnnotation:            strconv.Itoa(len(chunks)),
+					creationTimestampAnnotation: snapshot.CreationTimestamp.UTC().Format(time.RFC3339),
+					objectCountAnnotation:       strconv.Itoa(snapshot.ObjectCount),
+				},
+			},
+			Type: SnapshotSecretType,
+			Data: map[string][]byte{chunkDataKey: chunk},
+		}
+
+		if _, err := store.client.CoreV1().Secrets(store.namespace).Create(secret); err != nil {
+			for _, name := range created {
+				store.client.CoreV1().Secrets(store.namespace).Delete(name, &metaV1.DeleteOptions{})
+			}
+			return err
+		}
+		created = append(created, secret.Name)
+	}
+
+	return nil
+}
+
+// Delete implements Store.
+func (store *secretStore) Delete(namespace, name string) error {
+	if err := validateNames(namespace, name); err != nil {
+		return err
+	}
+
+	secrets, err := store.listChunks(labels.Set{namespaceLabel: namespace, nameLabel: name})
+	if err != nil {
+		return err
+	}
+	if len(secrets) == 0 {
+		return newNotFound(namespace, name)
+	}
+
+	for _, secret := range secrets {
+		if err := store.client.CoreV1().Secrets(store.namespace).Delete(secret.Name,
+			&metaV1.DeleteOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listChunks returns snapshot secrets matching the given labels.
+func (store *secretStore) listChunks(selector labels.Set) ([]v1.Secret, error) {
+	list, err := store.client.CoreV1().Secrets(store.namespace).List(metaV1.ListOptions{
+		LabelSelector: selector.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]v1.Secret, 0)
+	for _, secret := range list.Items {
+		if secret.Type == SnapshotSecretType {
+			result = append(result, secret)
+		}
+	}
+	return result, nil
+}
+
+// sortChunks sorts chunks of a single snapshot by their index and returns joined data. Returns an error if any
+// chunk is missing.
+func sortChunks(secrets []v1.Secret) ([]byte, error) {
+	sort.SliceStable(secrets, func(i, j int) bool {
+		return getAnnotationInt(&secrets[i], chunkAnnotation) < getAnnotationInt(&secrets[j], chunkAnnotation)
+	})
+
+	data := make([]byte, 0)
+	for i, secret := range secrets {
+		if getAnnotationInt(&secret, chunkAnnotation) != i || getAnnotationInt(&secret, chunksAnnotation) !=
+			len(secrets) {
+			return nil, fmt.Errorf("chunk %d of %d is missing", i, len(secrets))
+		}
+		data = append(data, secret.Data[chunkDataKey]...)
+	}
+	return data, nil
+}
+
+func getSnapshotInfo(namespace, name string, secret *v1.Secret) SnapshotInfo {
+	info := SnapshotInfo{
+		Name:        name,
+		Namespace:   namespace,
+		ObjectCount: getAnnotationInt(secret, objectCountAnnotation),
+	}
+
+	creationTimestamp, err := time.Parse(time.RFC3339, secret.Annotations[creationTimestampAnnotation])
+	if err == nil {
+		info.CreationTimestamp = metaV1.NewTime(creationTimestamp)
+	}
+	return info
+}
+
+// getAnnotationInt returns annotation value as integer, or -1 if it is missing or invalid.
+func getAnnotationInt(secret *v1.Secret, annotation string) int {
+	value, err := strconv.Atoi(secret.Annotations[annotation])
+	if err != nil {
+		return -1
+	}
+	return value
+}
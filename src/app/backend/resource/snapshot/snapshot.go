@@ -0,0 +1,211 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"log"
+	"sort"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/export"
+)
+
+// nameFormat is a time format used to generate snapshot names. Names have to be valid DNS labels.
+const nameFormat = "20060102-150405"
+
+// SnapshotInfo describes a snapshot without its objects.
+type SnapshotInfo struct {
+	// Name of the snapshot, unique within the namespace.
+	Name string `json:"name"`
+
+	// Namespace the snapshot was taken from.
+	Namespace string `json:"namespace"`
+
+	// Time when the snapshot was taken.
+	CreationTimestamp metaV1.Time `json:"creationTimestamp"`
+
+	// Number of objects in the snapshot.
+	ObjectCount int `json:"objectCount"`
+}
+
+// Snapshot is a copy of all namespaced objects of a namespace, cleaned the same way as exported objects. Volume
+// data is not part of a snapshot.
+type Snapshot struct {
+	SnapshotInfo
+
+	Objects []unstructured.Unstructured `json:"objects"`
+}
+
+// SnapshotList contains a list of snapshots of a namespace, newest first.
+type SnapshotList struct {
+	ListMeta  api.ListMeta   `json:"listMeta"`
+	Snapshots []SnapshotInfo `json:"snapshots"`
+}
+
+// SnapshotObject identifies an object in a snapshot.
+type SnapshotObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+// SnapshotDetail describes a snapshot and lists its objects.
+type SnapshotDetail struct {
+	SnapshotInfo
+
+	Objects []SnapshotObject `json:"objects"`
+
+	// List of non-critical errors, that occurred while the snapshot was taken. Objects of resources that could not
+	// be listed are missing from the snapshot.
+	Errors []error `json:"errors"`
+}
+
+// CreateSnapshot takes a snapshot of the given namespace and saves it in the store.
+func CreateSnapshot(cfg *rest.Config, store Store, namespace string) (*SnapshotDetail, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return createSnapshot(discoveryClient, dynamicClient, store, namespace, time.Now())
+}
+
+func createSnapshot(discoveryClient discovery.DiscoveryInterface, dynamicClient dynamic.Interface, store Store,
+	namespace string, now time.Time) (*SnapshotDetail, error) {
+	log.Printf("Taking snapshot of %s namespace\n", namespace)
+	query, err := export.NewExportQuery("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	objects, err := export.GetObjects(discoveryClient, dynamicClient, namespace, query)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		SnapshotInfo: SnapshotInfo{
+			Name:              now.UTC().Format(nameFormat),
+			Namespace:         namespace,
+			CreationTimestamp: metaV1.NewTime(now.UTC().Truncate(time.Second)),
+			ObjectCount:       len(objects.Objects),
+		},
+		Objects: objects.Objects,
+	}
+
+	if err := store.Save(snapshot); err != nil {
+		return nil, err
+	}
+
+	detail := toSnapshotDetail(snapshot)
+	detail.Errors = objects.Errors
+	return detail, nil
+}
+
+// GetSnapshotList returns snapshots of the given namespace from the store, newest first.
+func GetSnapshotList(store Store, namespace string) (*SnapshotList, error) {
+	snapshots, err := store.List(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[j].CreationTimestamp.Before(&snapshots[i].CreationTimestamp)
+	})
+
+	return &SnapshotList{ListMeta: api.ListMeta{TotalItems: len(snapshots)}, Snapshots: snapshots}, nil
+}
+
+// GetSnapshotDetail returns the snapshot with the given name from the store. Returns forbidden error unless the user
+// is allowed to list objects of the snapshot in its namespace.
+func GetSnapshotDetail(cfg *rest.Config, canI AccessChecker, store Store, namespace, name string) (*SnapshotDetail,
+	error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return getSnapshotDetail(discoveryClient, canI, store, namespace, name)
+}
+
+func getSnapshotDetail(discoveryClient discovery.DiscoveryInterface, canI AccessChecker, store Store,
+	namespace, name string) (*SnapshotDetail, error) {
+	snapshot, err := store.Get(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAccess(discoveryClient, canI, snapshot, namespace, "list"); err != nil {
+		return nil, err
+	}
+
+	return toSnapshotDetail(snapshot), nil
+}
+
+// DeleteSnapshot removes the snapshot with the given name from the store. Returns forbidden error unless the user
+// is allowed to delete objects of the snapshot in its namespace.
+func DeleteSnapshot(cfg *rest.Config, canI AccessChecker, store Store, namespace, name string) error {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	return deleteSnapshot(discoveryClient, canI, store, namespace, name)
+}
+
+func deleteSnapshot(discoveryClient discovery.DiscoveryInterface, canI AccessChecker, store Store,
+	namespace, name string) error {
+	snapshot, err := store.Get(namespace, name)
+	if err != nil {
+		return err
+	}
+
+	if err := checkAccess(discoveryClient, canI, snapshot, namespace, "delete"); err != nil {
+		return err
+	}
+
+	log.Printf("Deleting snapshot %s of %s namespace\n", name, namespace)
+	return store.Delete(namespace, name)
+}
+
+func toSnapshotDetail(snapshot *Snapshot) *SnapshotDetail {
+	detail := &SnapshotDetail{
+		SnapshotInfo: snapshot.SnapshotInfo,
+		Objects:      make([]SnapshotObject, 0),
+		Errors:       make([]error, 0),
+	}
+
+	for _, object := range snapshot.Objects {
+		detail.Objects = append(detail.Objects, SnapshotObject{
+			APIVersion: object.GetAPIVersion(),
+			Kind:       object.GetKind(),
+			Name:       object.GetName(),
+		})
+	}
+
+	return detail
+}
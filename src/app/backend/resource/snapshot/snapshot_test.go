@@ -0,0 +1,219 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+
+	"github.com/kubernetes/dashboard/src/app/backend/testutil"
+)
+
+var configMapResource = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+func newDiscoveryClient() discovery.DiscoveryInterface {
+	return testutil.NewDiscoveryClient(&metaV1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metaV1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: metaV1.Verbs{"list", "create", "get",
+				"patch"}},
+			{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: metaV1.Verbs{"list", "create", "get",
+				"patch"}},
+		},
+	})
+}
+
+func allowAll(ssar *authorizationv1.SelfSubjectAccessReview) bool {
+	return true
+}
+
+func TestSnapshot(t *testing.T) {
+	discoveryClient := newDiscoveryClient()
+	dynamicClient := testutil.NewApplyClient(newConfigMap("a", "value"), newConfigMap("b", "value"))
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+	store := NewDirectoryStore(dir)
+
+	first := time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)
+	detail, err := createSnapshot(discoveryClient, dynamicClient, store, "ns", first)
+	if err != nil {
+		t.Fatalf("createSnapshot(): unexpected error: %s", err.Error())
+	}
+
+	expected := []SnapshotObject{{"v1", "ConfigMap", "a"}, {"v1", "ConfigMap", "b"}}
+	if detail.Name != "20191001-100000" || !reflect.DeepEqual(detail.Objects, expected) {
+		t.Errorf("createSnapshot() == %s %v, expected 20191001-100000 %v", detail.Name, detail.Objects, expected)
+	}
+
+	if _, err := createSnapshot(discoveryClient, dynamicClient, store, "ns", first.Add(time.Hour)); err != nil {
+		t.Fatalf("createSnapshot(): unexpected error: %s", err.Error())
+	}
+
+	list, err := GetSnapshotList(store, "ns")
+	if err != nil {
+		t.Fatalf("GetSnapshotList(): unexpected error: %s", err.Error())
+	}
+	if list.ListMeta.TotalItems != 2 || list.Snapshots[0].Name != "20191001-110000" ||
+		list.Snapshots[1].Name != "20191001-100000" {
+		t.Errorf("GetSnapshotList() == %v, expected newest snapshot first", list)
+	}
+
+	if err := deleteSnapshot(discoveryClient, allowAll, store, "ns", "20191001-110000"); err != nil {
+		t.Fatalf("deleteSnapshot(): unexpected error: %s", err.Error())
+	}
+	if _, err := getSnapshotDetail(discoveryClient, allowAll, store, "ns", "20191001-110000"); err == nil {
+		t.Error("getSnapshotDetail() of deleted snapshot: expected error")
+	}
+}
+
+func TestSnapshotDiffAndRestore(t *testing.T) {
+	discoveryClient := newDiscoveryClient()
+	dynamicClient := testutil.NewApplyClient(newConfigMap("a", "value"), newConfigMap("b", "value"))
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+	store := NewDirectoryStore(dir)
+
+	if _, err := createSnapshot(discoveryClient, dynamicClient, store, "ns", time.Now()); err != nil {
+		t.Fatalf("createSnapshot(): unexpected error: %s", err.Error())
+	}
+	list, _ := GetSnapshotList(store, "ns")
+	name := list.Snapshots[0].Name
+
+	client := dynamicClient.Resource(configMapResource).Namespace("ns")
+	if _, err := client.Update(newConfigMap("a", "changed"), metaV1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Delete("b", &metaV1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Create(newConfigMap("c", "value"), metaV1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	snapshotDiff, err := getSnapshotDiff(discoveryClient, dynamicClient, allowAll, store, "ns", name)
+	if err != nil {
+		t.Fatalf("getSnapshotDiff(): unexpected error: %s", err.Error())
+	}
+
+	statuses := make(map[string]ObjectStatus)
+	for _, object := range snapshotDiff.Objects {
+		statuses[object.Name] = object.Status
+	}
+	expectedStatuses := map[string]ObjectStatus{
+		"a": ObjectStatusModified,
+		"b": ObjectStatusDeleted,
+		"c": ObjectStatusCreated,
+	}
+	if !reflect.DeepEqual(statuses, expectedStatuses) {
+		t.Errorf("getSnapshotDiff() returned statuses %v, expected %v", statuses, expectedStatuses)
+	}
+	expectedDiff := "--- live\n+++ snapshot\n@@ -1,6 +1,6 @@\n apiVersion: v1\n data:\n-  key: changed\n+  key: value\n" +
+		" kind: ConfigMap\n metadata:\n   name: a\n"
+	if snapshotDiff.Objects[0].Diff != expectedDiff {
+		t.Errorf("getSnapshotDiff() returned diff:\n%s\nexpected:\n%s", snapshotDiff.Objects[0].Diff, expectedDiff)
+	}
+
+	spec := &RestoreSpec{Objects: []SnapshotObject{{Kind: "ConfigMap", Name: "b"}}}
+	result, err := restoreSnapshot(discoveryClient, dynamicClient, allowAll, store, "ns", name, spec)
+	if err != nil {
+		t.Fatalf("restoreSnapshot(): unexpected error: %s", err.Error())
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Name != "b" || len(result.Error) > 0 {
+		t.Errorf("restoreSnapshot() == %v, expected only b to be restored", result)
+	}
+	if _, err := client.Get("b", metaV1.GetOptions{}); err != nil {
+		t.Errorf("Expected b to be restored: %s", err.Error())
+	}
+	if live, _ := client.Get("a", metaV1.GetOptions{}); live.Object["data"].(map[string]interface{})["key"] != "changed" {
+		t.Error("Expected a not to be restored")
+	}
+
+	spec = &RestoreSpec{Objects: []SnapshotObject{{APIVersion: "apps/v1", Kind: "ConfigMap", Name: "a"}}}
+	if _, err := restoreSnapshot(discoveryClient, dynamicClient, allowAll, store, "ns", name, spec); err == nil {
+		t.Error("restoreSnapshot() of object not in snapshot: expected error")
+	}
+}
+
+func TestSnapshotAccess(t *testing.T) {
+	secret := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "s", "namespace": "ns"},
+		"data":       map[string]interface{}{"password": "c2VjcmV0"},
+	}}
+	discoveryClient := newDiscoveryClient()
+	dynamicClient := testutil.NewApplyClient(newConfigMap("a", "value"), secret)
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+	store := NewDirectoryStore(dir)
+
+	now := time.Now()
+	configMapsOnly, err := createSnapshot(discoveryClient, testutil.NewApplyClient(newConfigMap("a", "value")), store,
+		"ns", now)
+	if err != nil {
+		t.Fatalf("createSnapshot(): unexpected error: %s", err.Error())
+	}
+	detail, err := createSnapshot(discoveryClient, dynamicClient, store, "ns", now.Add(time.Second))
+	if err != nil {
+		t.Fatalf("createSnapshot(): unexpected error: %s", err.Error())
+	}
+
+	checked := make([]string, 0)
+	canListConfigMaps := func(ssar *authorizationv1.SelfSubjectAccessReview) bool {
+		attributes := ssar.Spec.ResourceAttributes
+		checked = append(checked, attributes.Namespace+"/"+attributes.Resource+"/"+attributes.Verb)
+		return attributes.Resource == "configmaps" && attributes.Verb == "list"
+	}
+
+	if _, err = getSnapshotDetail(discoveryClient, canListConfigMaps, store, "ns", configMapsOnly.Name); err != nil {
+		t.Errorf("getSnapshotDetail() of snapshot without secrets: unexpected error: %s", err.Error())
+	}
+	expected := []string{"ns/configmaps/list"}
+	if !reflect.DeepEqual(checked, expected) {
+		t.Errorf("getSnapshotDetail() checked access %v, expected %v", checked, expected)
+	}
+
+	checked = make([]string, 0)
+	_, err = getSnapshotDetail(discoveryClient, canListConfigMaps, store, "ns", detail.Name)
+	if !k8serrors.IsForbidden(err) {
+		t.Errorf("getSnapshotDetail() without access to secrets: expected forbidden error, got %v", err)
+	}
+	expected = []string{"ns/configmaps/list", "ns/secrets/list"}
+	if !reflect.DeepEqual(checked, expected) {
+		t.Errorf("getSnapshotDetail() checked access %v, expected %v", checked, expected)
+	}
+
+	if _, err = getSnapshotDiff(discoveryClient, dynamicClient, canListConfigMaps, store, "ns",
+		detail.Name); !k8serrors.IsForbidden(err) {
+		t.Errorf("getSnapshotDiff() without access to secrets: expected forbidden error, got %v", err)
+	}
+
+	if err = deleteSnapshot(discoveryClient, canListConfigMaps, store, "ns",
+		detail.Name); !k8serrors.IsForbidden(err) {
+		t.Errorf("deleteSnapshot() without delete access: expected forbidden error, got %v", err)
+	}
+	if _, err = store.Get("ns", detail.Name); err != nil {
+		t.Errorf("Expected snapshot not to be deleted: %s", err.Error())
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// Store persists snapshots. Snapshots are immutable, once saved they can only be read or deleted.
+type Store interface {
+	// List returns snapshots of the given namespace without their objects.
+	List(namespace string) ([]SnapshotInfo, error)
+
+	// Get returns the snapshot with the given name. Returns not found error if it does not exist.
+	Get(namespace, name string) (*Snapshot, error)
+
+	// Save stores a new snapshot. Returns already exists error if the snapshot with the same name exists.
+	Save(snapshot *Snapshot) error
+
+	// Delete removes the snapshot with the given name. Returns not found error if it does not exist.
+	Delete(namespace, name string) error
+}
+
+// validateNames checks that snapshot namespace and name are valid DNS labels, so that they can be safely used in
+// file and object names.
+func validateNames(values ...string) error {
+	for _, value := range values {
+		if messages := validation.IsDNS1123Label(value); len(messages) > 0 {
+			return errors.NewBadRequest(fmt.Sprintf("Invalid snapshot name or namespace %q: %s", value,
+				strings.Join(messages, ", ")))
+		}
+	}
+	return nil
+}
+
+// newNotFound returns not found error for the snapshot with the given name.
+func newNotFound(namespace, name string) error {
+	return errors.NewNotFound(fmt.Sprintf("Snapshot %s of namespace %s not found", name, namespace))
+}
+
+// newAlreadyExists returns already exists error for the snapshot with the given name.
+func newAlreadyExists(namespace, name string) error {
+	return errors.NewAlreadyExists(fmt.Sprintf("Snapshot %s of namespace %s already exists", name, namespace))
+}
+
+// encodeSnapshot serializes the snapshot to gzipped JSON.
+func encodeSnapshot(snapshot *Snapshot) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	if err := json.NewEncoder(writer).Encode(snapshot); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// decodeSnapshot deserializes the snapshot from gzipped JSON.
+func decodeSnapshot(data []byte) (*Snapshot, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(content, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// decodeSnapshotInfo deserializes only the snapshot info from gzipped JSON. Info fields are encoded before objects,
+// so reading stops at the objects, which are neither decompressed nor decoded.
+func decodeSnapshotInfo(reader io.Reader) (*SnapshotInfo, error) {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	defer gzipReader.Close()
+
+	decoder := json.NewDecoder(gzipReader)
+	if token, err := decoder.Token(); err != nil {
+		return nil, err
+	} else if token != json.Delim('{') {
+		return nil, fmt.Errorf("expected snapshot object, got %v", token)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if token == "objects" {
+			break
+		}
+
+		value := json.RawMessage{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		fields[fmt.Sprint(token)] = value
+	}
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &SnapshotInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
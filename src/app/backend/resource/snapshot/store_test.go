@@ -0,0 +1,183 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snapshot
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+func newConfigMap(name, value string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "ns"},
+		"data":       map[string]interface{}{"key": value},
+	}}
+}
+
+func newSnapshot(namespace, name string, objects ...*unstructured.Unstructured) *Snapshot {
+	snapshot := &Snapshot{
+		SnapshotInfo: SnapshotInfo{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metaV1.NewTime(time.Date(2019, 10, 1, 10, 0, 0, 0, time.UTC)),
+			ObjectCount:       len(objects),
+		},
+		Objects: make([]unstructured.Unstructured, 0),
+	}
+	for _, object := range objects {
+		snapshot.Objects = append(snapshot.Objects, *object)
+	}
+	return snapshot
+}
+
+// equalJSON compares values by their JSON representation, as decoded timestamps are in local time zone.
+func equalJSON(a, b interface{}) bool {
+	aJSON, _ := json.Marshal(a)
+	bJSON, _ := json.Marshal(b)
+	return string(aJSON) == string(bJSON)
+}
+
+func testStore(t *testing.T, store Store) {
+	snapshot := newSnapshot("ns", "20191001-100000", newConfigMap("a", "value"), newConfigMap("b", "value"))
+	if err := store.Save(snapshot); err != nil {
+		t.Fatalf("Save(): unexpected error: %s", err.Error())
+	}
+	if err := store.Save(snapshot); !errors.IsAlreadyExists(err) {
+		t.Errorf("Save() of existing snapshot: expected already exists error, got %v", err)
+	}
+	if err := store.Save(newSnapshot("other", "20191001-100000")); err != nil {
+		t.Fatalf("Save(): unexpected error: %s", err.Error())
+	}
+
+	list, err := store.List("ns")
+	if err != nil {
+		t.Fatalf("List(): unexpected error: %s", err.Error())
+	}
+	if !equalJSON(list, []SnapshotInfo{snapshot.SnapshotInfo}) {
+		t.Errorf("List() == %v, expected %v", list, []SnapshotInfo{snapshot.SnapshotInfo})
+	}
+
+	actual, err := store.Get("ns", "20191001-100000")
+	if err != nil {
+		t.Fatalf("Get(): unexpected error: %s", err.Error())
+	}
+	if !equalJSON(actual, snapshot) {
+		t.Errorf("Get() == %v, expected %v", actual, snapshot)
+	}
+
+	if _, err := store.Get("ns", "../other"); err == nil {
+		t.Error("Get() with invalid name: expected error")
+	}
+
+	if err := store.Delete("ns", "20191001-100000"); err != nil {
+		t.Fatalf("Delete(): unexpected error: %s", err.Error())
+	}
+	if _, err := store.Get("ns", "20191001-100000"); !errors.IsNotFoundError(err) {
+		t.Errorf("Get() of deleted snapshot: expected not found error, got %v", err)
+	}
+	if err := store.Delete("ns", "20191001-100000"); !errors.IsNotFoundError(err) {
+		t.Errorf("Delete() of deleted snapshot: expected not found error, got %v", err)
+	}
+
+	if list, _ := store.List("other"); len(list) != 1 {
+		t.Errorf("List() of other namespace returned %d snapshots, expected 1", len(list))
+	}
+}
+
+func newTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "snapshots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestDirectoryStore(t *testing.T) {
+	dir := newTempDir(t)
+	defer os.RemoveAll(dir)
+
+	testStore(t, NewDirectoryStore(dir))
+}
+
+func TestDecodeSnapshotInfo(t *testing.T) {
+	snapshot := newSnapshot("ns", "20191001-100000", newConfigMap("a", "value"))
+	data, err := encodeSnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("encodeSnapshot(): unexpected error: %s", err.Error())
+	}
+
+	// Objects are not read, so the info can be decoded from a truncated file.
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	writer.Write([]byte(`{"name":"20191001-100000","namespace":"ns","objectCount":1,"objects":[{"kind":`))
+	writer.Close()
+
+	for _, data := range [][]byte{data, buffer.Bytes()} {
+		info, err := decodeSnapshotInfo(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("decodeSnapshotInfo(): unexpected error: %s", err.Error())
+		}
+		if info.Name != snapshot.Name || info.Namespace != snapshot.Namespace || info.ObjectCount != 1 {
+			t.Errorf("decodeSnapshotInfo() == %v, expected %v", info, snapshot.SnapshotInfo)
+		}
+	}
+}
+
+func TestSecretStore(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	store := &secretStore{client: client, namespace: "kube-system", chunkSize: 64}
+	testStore(t, store)
+
+	secrets, err := client.CoreV1().Secrets("kube-system").List(metaV1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secrets.Items) < 2 {
+		t.Errorf("Expected snapshot to be split into multiple secrets, got %d", len(secrets.Items))
+	}
+}
+
+func TestSecretStoreIncompleteSnapshot(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	store := &secretStore{client: client, namespace: "kube-system", chunkSize: 64}
+	if err := store.Save(newSnapshot("ns", "20191001-100000", newConfigMap("a", "value"))); err != nil {
+		t.Fatalf("Save(): unexpected error: %s", err.Error())
+	}
+
+	if err := client.CoreV1().Secrets("kube-system").Delete("snapshot-ns-20191001-100000-1",
+		&metaV1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if list, _ := store.List("ns"); len(list) != 0 {
+		t.Errorf("List() == %v, expected incomplete snapshot to be skipped", list)
+	}
+	if _, err := store.Get("ns", "20191001-100000"); err == nil {
+		t.Error("Get() of incomplete snapshot: expected error")
+	}
+}
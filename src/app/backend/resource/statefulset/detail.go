@@ -20,6 +20,7 @@ import (
 	"github.com/kubernetes/dashboard/src/app/backend/errors"
 	metricapi "github.com/kubernetes/dashboard/src/app/backend/integration/metric/api"
 	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+	pdb "github.com/kubernetes/dashboard/src/app/backend/resource/poddisruptionbudget"
 	apps "k8s.io/api/apps/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -30,6 +31,9 @@ type StatefulSetDetail struct {
 	// Extends list item structure.
 	StatefulSet `json:",inline"`
 
+	// Pod disruption budgets that cover pods of this Stateful Set.
+	PodDisruptionBudgets []pdb.PodDisruptionBudget `json:"podDisruptionBudgets"`
+
 	// List of non-critical errors, that occurred during resource retrieval.
 	Errors []error `json:"errors"`
 }
@@ -50,13 +54,21 @@ func GetStatefulSetDetail(client kubernetes.Interface, metricClient metricapi.Me
 		return nil, criticalError
 	}
 
-	ssDetail := getStatefulSetDetail(ss, podInfo, nonCriticalErrors)
+	pdbs, err := pdb.GetMatchingPodDisruptionBudgets(client, namespace, ss.Spec.Template.Labels)
+	nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+	if criticalError != nil {
+		return nil, criticalError
+	}
+
+	ssDetail := getStatefulSetDetail(ss, podInfo, pdbs, nonCriticalErrors)
 	return &ssDetail, nil
 }
 
-func getStatefulSetDetail(statefulSet *apps.StatefulSet, podInfo *common.PodInfo, nonCriticalErrors []error) StatefulSetDetail {
+func getStatefulSetDetail(statefulSet *apps.StatefulSet, podInfo *common.PodInfo, pdbs []pdb.PodDisruptionBudget,
+	nonCriticalErrors []error) StatefulSetDetail {
 	return StatefulSetDetail{
-		StatefulSet: toStatefulSet(statefulSet, podInfo),
-		Errors:      nonCriticalErrors,
+		StatefulSet:          toStatefulSet(statefulSet, podInfo),
+		PodDisruptionBudgets: pdbs,
+		Errors:               nonCriticalErrors,
 	}
 }
@@ -0,0 +1,126 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"fmt"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+)
+
+// Kinds of resources read by GetTopology. References to other kinds can not be checked.
+var listedKinds = []api.ResourceKind{
+	api.ResourceKindIngress,
+	api.ResourceKindService,
+	api.ResourceKindPod,
+	api.ResourceKindReplicaSet,
+	api.ResourceKindReplicationController,
+	api.ResourceKindDeployment,
+	api.ResourceKindStatefulSet,
+	api.ResourceKindDaemonSet,
+	api.ResourceKindJob,
+	api.ResourceKindCronJob,
+	api.ResourceKindHorizontalPodAutoscaler,
+	api.ResourceKindConfigMap,
+	api.ResourceKindSecret,
+	api.ResourceKindPersistentVolumeClaim,
+}
+
+// graph keeps nodes in insertion order and ignores duplicate edges.
+type graph struct {
+	nodes   map[string]*TopologyNode
+	order   []string
+	edges   []TopologyEdge
+	known   map[api.ResourceKind]bool
+	edgeSet map[TopologyEdge]bool
+}
+
+func newGraph(unknown map[api.ResourceKind]bool) *graph {
+	known := make(map[api.ResourceKind]bool)
+	for _, kind := range listedKinds {
+		known[kind] = !unknown[kind]
+	}
+
+	return &graph{
+		nodes:   make(map[string]*TopologyNode),
+		order:   make([]string, 0),
+		edges:   make([]TopologyEdge, 0),
+		known:   known,
+		edgeSet: make(map[TopologyEdge]bool),
+	}
+}
+
+func nodeID(kind api.ResourceKind, name string) string {
+	return fmt.Sprintf("%s/%s", kind, name)
+}
+
+func (g *graph) addNode(kind api.ResourceKind, meta metaV1.ObjectMeta, health Health, message string) string {
+	id := nodeID(kind, meta.Name)
+	if _, ok := g.nodes[id]; !ok {
+		g.order = append(g.order, id)
+	}
+
+	g.nodes[id] = &TopologyNode{
+		ID:         id,
+		ObjectMeta: api.NewObjectMeta(meta),
+		TypeMeta:   api.NewTypeMeta(kind),
+		Health:     health,
+		Message:    message,
+	}
+	return id
+}
+
+func (g *graph) addEdge(source, target string, edgeType EdgeType) {
+	edge := TopologyEdge{Source: source, Target: target, Type: edgeType}
+	if !g.edgeSet[edge] {
+		g.edgeSet[edge] = true
+		g.edges = append(g.edges, edge)
+	}
+}
+
+// Adds edge to referenced resource. Resource that is not part of the graph yet is added as missing, or with unknown
+// health if its kind could not be listed.
+func (g *graph) addEdgeToReference(source string, kind api.ResourceKind, namespace, name string,
+	edgeType EdgeType) {
+	target := nodeID(kind, name)
+	if _, ok := g.nodes[target]; !ok {
+		health, message := HealthUnhealthy, "Resource does not exist."
+		if !g.known[kind] {
+			health, message = HealthUnknown, ""
+		}
+		g.addNode(kind, metaV1.ObjectMeta{Name: name, Namespace: namespace}, health, message)
+	}
+
+	g.addEdge(source, target, edgeType)
+}
+
+// Updates node added as a reference with the existing resource. Resources that are not referenced are ignored.
+func (g *graph) setReferencedNodeHealth(kind api.ResourceKind, meta metaV1.ObjectMeta, health Health,
+	message string) {
+	if _, ok := g.nodes[nodeID(kind, meta.Name)]; ok {
+		g.addNode(kind, meta, health, message)
+	}
+}
+
+func (g *graph) getNodes() []TopologyNode {
+	result := make([]TopologyNode, 0, len(g.order))
+	for _, id := range g.order {
+		result = append(result, *g.nodes[id])
+	}
+
+	return result
+}
@@ -0,0 +1,164 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"fmt"
+
+	autoscaling "k8s.io/api/autoscaling/v1"
+	batch "k8s.io/api/batch/v1"
+	batch2 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Health is a simplified status of a resource in the topology graph.
+type Health string
+
+// List of health states of topology nodes.
+const (
+	HealthHealthy   Health = "Healthy"
+	HealthWarning   Health = "Warning"
+	HealthUnhealthy Health = "Unhealthy"
+	HealthUnknown   Health = "Unknown"
+)
+
+func getPodHealth(pod v1.Pod) (Health, string) {
+	for _, status := range append(append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+		pod.Status.ContainerStatuses...) {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff" {
+			return HealthUnhealthy, fmt.Sprintf("Container %s is crash looping.", status.Name)
+		}
+	}
+
+	switch pod.Status.Phase {
+	case v1.PodSucceeded:
+		return HealthHealthy, ""
+	case v1.PodFailed:
+		return HealthUnhealthy, pod.Status.Message
+	case v1.PodPending:
+		return HealthWarning, "Pod is pending."
+	case v1.PodRunning:
+		if isPodReady(pod) {
+			return HealthHealthy, ""
+		}
+		return HealthWarning, "Pod is not ready."
+	default:
+		return HealthUnknown, ""
+	}
+}
+
+func isPodReady(pod v1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// Returns health of a controller based on its number of ready and desired replicas.
+func getReplicasHealth(desired *int32, ready int32) (Health, string) {
+	desiredReplicas := int32(1)
+	if desired != nil {
+		desiredReplicas = *desired
+	}
+
+	switch {
+	case ready >= desiredReplicas:
+		return HealthHealthy, ""
+	case ready == 0:
+		return HealthUnhealthy, fmt.Sprintf("0 of %d replicas are ready.", desiredReplicas)
+	default:
+		return HealthWarning, fmt.Sprintf("%d of %d replicas are ready.", ready, desiredReplicas)
+	}
+}
+
+func getJobHealth(job batch.Job) (Health, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batch.JobFailed && condition.Status == v1.ConditionTrue {
+			return HealthUnhealthy, condition.Message
+		}
+	}
+
+	return HealthHealthy, ""
+}
+
+func getCronJobHealth(cronJob batch2.CronJob) (Health, string) {
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		return HealthWarning, "Cron job is suspended."
+	}
+
+	return HealthHealthy, ""
+}
+
+// Service without selector has manually managed endpoints, so its health can not be derived from pods.
+func getServiceHealth(service v1.Service, pods []v1.Pod) (Health, string) {
+	if len(service.Spec.Selector) == 0 {
+		return HealthUnknown, ""
+	}
+
+	selector := labels.SelectorFromSet(service.Spec.Selector)
+	selected := 0
+	for _, pod := range pods {
+		if selector.Matches(labels.Set(pod.Labels)) {
+			selected++
+			if isPodReady(pod) {
+				return HealthHealthy, ""
+			}
+		}
+	}
+
+	if selected == 0 {
+		return HealthUnhealthy, "Service does not select any pods."
+	}
+	return HealthUnhealthy, "None of selected pods is ready."
+}
+
+func getIngressHealth(ingress extensions.Ingress, services []v1.Service) (Health, string) {
+	existing := make(map[string]bool)
+	for _, service := range services {
+		existing[service.Name] = true
+	}
+
+	for _, name := range getIngressServiceNames(ingress) {
+		if !existing[name] {
+			return HealthUnhealthy, fmt.Sprintf("Backend service %s does not exist.", name)
+		}
+	}
+
+	return HealthHealthy, ""
+}
+
+func getHorizontalPodAutoscalerHealth(hpa autoscaling.HorizontalPodAutoscaler) (Health, string) {
+	if hpa.Status.CurrentReplicas >= hpa.Spec.MaxReplicas {
+		return HealthWarning, "Target is scaled to the maximum number of replicas."
+	}
+
+	return HealthHealthy, ""
+}
+
+func getPersistentVolumeClaimHealth(pvc v1.PersistentVolumeClaim) (Health, string) {
+	switch pvc.Status.Phase {
+	case v1.ClaimBound:
+		return HealthHealthy, ""
+	case v1.ClaimPending:
+		return HealthWarning, "Claim is not bound to a volume."
+	default:
+		return HealthUnhealthy, fmt.Sprintf("Claim is %s.", pvc.Status.Phase)
+	}
+}
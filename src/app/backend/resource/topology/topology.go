@@ -0,0 +1,464 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"log"
+	"strings"
+
+	apps "k8s.io/api/apps/v1"
+	autoscaling "k8s.io/api/autoscaling/v1"
+	batch "k8s.io/api/batch/v1"
+	batch2 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/common"
+)
+
+// EdgeType describes the relation between two nodes of the topology graph.
+type EdgeType string
+
+// List of relations between topology nodes.
+const (
+	// Ingress routes traffic to the service.
+	EdgeTypeRoutes EdgeType = "routes"
+	// Service selects the pod.
+	EdgeTypeSelects EdgeType = "selects"
+	// Object is controlled by the owner.
+	EdgeTypeOwnedBy EdgeType = "ownedBy"
+	// Cron job created the job.
+	EdgeTypeOwns EdgeType = "owns"
+	// Pod mounts the config map, secret or persistent volume claim as a volume.
+	EdgeTypeMounts EdgeType = "mounts"
+	// Pod references the config map or secret from environment variables or image pull secrets.
+	EdgeTypeReferences EdgeType = "references"
+	// Horizontal pod autoscaler scales the target.
+	EdgeTypeScales EdgeType = "scales"
+)
+
+// Topology is a graph of resources of a namespace and relations between them.
+type Topology struct {
+	Nodes []TopologyNode `json:"nodes"`
+	Edges []TopologyEdge `json:"edges"`
+
+	// List of non-critical errors, that occurred during resource retrieval.
+	Errors []error `json:"errors"`
+}
+
+// TopologyNode is a single resource of the topology graph.
+type TopologyNode struct {
+	// ID of the node unique within the graph, i.e. kind and name of the resource.
+	ID         string         `json:"id"`
+	ObjectMeta api.ObjectMeta `json:"objectMeta"`
+	TypeMeta   api.TypeMeta   `json:"typeMeta"`
+	Health     Health         `json:"health"`
+
+	// Reason of the health status, if the resource is not healthy.
+	Message string `json:"message,omitempty"`
+}
+
+// TopologyEdge is a directed relation between two nodes of the topology graph.
+type TopologyEdge struct {
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Type   EdgeType `json:"type"`
+}
+
+// GetTopology returns topology graph of resources in given namespace.
+func GetTopology(client client.Interface, namespace string) (*Topology, error) {
+	log.Printf("Getting topology of %s namespace", namespace)
+
+	nsQuery := common.NewSameNamespaceQuery(namespace)
+	channels := &common.ResourceChannels{
+		IngressList:                 common.GetIngressListChannel(client, nsQuery, 1),
+		ServiceList:                 common.GetServiceListChannel(client, nsQuery, 1),
+		PodList:                     common.GetPodListChannel(client, nsQuery, 1),
+		ReplicaSetList:              common.GetReplicaSetListChannel(client, nsQuery, 1),
+		ReplicationControllerList:   common.GetReplicationControllerListChannel(client, nsQuery, 1),
+		DeploymentList:              common.GetDeploymentListChannel(client, nsQuery, 1),
+		StatefulSetList:             common.GetStatefulSetListChannel(client, nsQuery, 1),
+		DaemonSetList:               common.GetDaemonSetListChannel(client, nsQuery, 1),
+		JobList:                     common.GetJobListChannel(client, nsQuery, 1),
+		CronJobList:                 common.GetCronJobListChannel(client, nsQuery, 1),
+		HorizontalPodAutoscalerList: common.GetHorizontalPodAutoscalerListChannel(client, nsQuery, 1),
+		ConfigMapList:               common.GetConfigMapListChannel(client, nsQuery, 1),
+		SecretList:                  common.GetSecretListChannel(client, nsQuery, 1),
+		PersistentVolumeClaimList:   common.GetPersistentVolumeClaimListChannel(client, nsQuery, 1),
+	}
+
+	return GetTopologyFromChannels(channels)
+}
+
+// resources holds all resources read from the channels. Kinds that could not be listed are marked in the unknown
+// map, so references to them are not reported as missing.
+type resources struct {
+	ingresses    []extensions.Ingress
+	services     []v1.Service
+	pods         []v1.Pod
+	replicaSets  []apps.ReplicaSet
+	rcs          []v1.ReplicationController
+	deployments  []apps.Deployment
+	statefulSets []apps.StatefulSet
+	daemonSets   []apps.DaemonSet
+	jobs         []batch.Job
+	cronJobs     []batch2.CronJob
+	hpas         []autoscaling.HorizontalPodAutoscaler
+	configMaps   []v1.ConfigMap
+	secrets      []v1.Secret
+	pvcs         []v1.PersistentVolumeClaim
+	unknown      map[api.ResourceKind]bool
+}
+
+// GetTopologyFromChannels returns topology graph of resources read from given channels.
+func GetTopologyFromChannels(channels *common.ResourceChannels) (*Topology, error) {
+	res, nonCriticalErrors, err := readResources(channels)
+	if err != nil {
+		return nil, err
+	}
+
+	g := newGraph(res.unknown)
+	addNodes(g, res)
+	addEdges(g, res)
+
+	return &Topology{Nodes: g.getNodes(), Edges: g.edges, Errors: nonCriticalErrors}, nil
+}
+
+func readResources(channels *common.ResourceChannels) (*resources, []error, error) {
+	res := &resources{unknown: make(map[api.ResourceKind]bool)}
+	nonCriticalErrors := make([]error, 0)
+
+	// Lists that could not be read because of non-critical errors are empty and their kind is marked as unknown.
+	handle := func(kind api.ResourceKind, err error) error {
+		if err == nil {
+			return nil
+		}
+
+		var criticalError error
+		nonCriticalErrors, criticalError = errors.AppendError(err, nonCriticalErrors)
+		res.unknown[kind] = true
+		return criticalError
+	}
+
+	ingresses, err := <-channels.IngressList.List, <-channels.IngressList.Error
+	if err := handle(api.ResourceKindIngress, err); err != nil {
+		return nil, nil, err
+	}
+	if ingresses != nil {
+		res.ingresses = ingresses.Items
+	}
+
+	services, err := <-channels.ServiceList.List, <-channels.ServiceList.Error
+	if err := handle(api.ResourceKindService, err); err != nil {
+		return nil, nil, err
+	}
+	if services != nil {
+		res.services = services.Items
+	}
+
+	pods, err := <-channels.PodList.List, <-channels.PodList.Error
+	if err := handle(api.ResourceKindPod, err); err != nil {
+		return nil, nil, err
+	}
+	if pods != nil {
+		res.pods = pods.Items
+	}
+
+	replicaSets, err := <-channels.ReplicaSetList.List, <-channels.ReplicaSetList.Error
+	if err := handle(api.ResourceKindReplicaSet, err); err != nil {
+		return nil, nil, err
+	}
+	if replicaSets != nil {
+		res.replicaSets = replicaSets.Items
+	}
+
+	rcs, err := <-channels.ReplicationControllerList.List, <-channels.ReplicationControllerList.Error
+	if err := handle(api.ResourceKindReplicationController, err); err != nil {
+		return nil, nil, err
+	}
+	if rcs != nil {
+		res.rcs = rcs.Items
+	}
+
+	deployments, err := <-channels.DeploymentList.List, <-channels.DeploymentList.Error
+	if err := handle(api.ResourceKindDeployment, err); err != nil {
+		return nil, nil, err
+	}
+	if deployments != nil {
+		res.deployments = deployments.Items
+	}
+
+	statefulSets, err := <-channels.StatefulSetList.List, <-channels.StatefulSetList.Error
+	if err := handle(api.ResourceKindStatefulSet, err); err != nil {
+		return nil, nil, err
+	}
+	if statefulSets != nil {
+		res.statefulSets = statefulSets.Items
+	}
+
+	daemonSets, err := <-channels.DaemonSetList.List, <-channels.DaemonSetList.Error
+	if err := handle(api.ResourceKindDaemonSet, err); err != nil {
+		return nil, nil, err
+	}
+	if daemonSets != nil {
+		res.daemonSets = daemonSets.Items
+	}
+
+	jobs, err := <-channels.JobList.List, <-channels.JobList.Error
+	if err := handle(api.ResourceKindJob, err); err != nil {
+		return nil, nil, err
+	}
+	if jobs != nil {
+		res.jobs = jobs.Items
+	}
+
+	cronJobs, err := <-channels.CronJobList.List, <-channels.CronJobList.Error
+	if err := handle(api.ResourceKindCronJob, err); err != nil {
+		return nil, nil, err
+	}
+	if cronJobs != nil {
+		res.cronJobs = cronJobs.Items
+	}
+
+	hpas, err := <-channels.HorizontalPodAutoscalerList.List, <-channels.HorizontalPodAutoscalerList.Error
+	if err := handle(api.ResourceKindHorizontalPodAutoscaler, err); err != nil {
+		return nil, nil, err
+	}
+	if hpas != nil {
+		res.hpas = hpas.Items
+	}
+
+	configMaps, err := <-channels.ConfigMapList.List, <-channels.ConfigMapList.Error
+	if err := handle(api.ResourceKindConfigMap, err); err != nil {
+		return nil, nil, err
+	}
+	if configMaps != nil {
+		res.configMaps = configMaps.Items
+	}
+
+	secrets, err := <-channels.SecretList.List, <-channels.SecretList.Error
+	if err := handle(api.ResourceKindSecret, err); err != nil {
+		return nil, nil, err
+	}
+	if secrets != nil {
+		res.secrets = secrets.Items
+	}
+
+	pvcs, err := <-channels.PersistentVolumeClaimList.List, <-channels.PersistentVolumeClaimList.Error
+	if err := handle(api.ResourceKindPersistentVolumeClaim, err); err != nil {
+		return nil, nil, err
+	}
+	if pvcs != nil {
+		res.pvcs = pvcs.Items
+	}
+
+	return res, nonCriticalErrors, nil
+}
+
+func addNodes(g *graph, res *resources) {
+	for _, item := range res.ingresses {
+		health, message := getIngressHealth(item, res.services)
+		g.addNode(api.ResourceKindIngress, item.ObjectMeta, health, message)
+	}
+	for _, item := range res.services {
+		health, message := getServiceHealth(item, res.pods)
+		g.addNode(api.ResourceKindService, item.ObjectMeta, health, message)
+	}
+	for _, item := range res.pods {
+		health, message := getPodHealth(item)
+		g.addNode(api.ResourceKindPod, item.ObjectMeta, health, message)
+	}
+	for _, item := range res.replicaSets {
+		health, message := getReplicasHealth(item.Spec.Replicas, item.Status.ReadyReplicas)
+		g.addNode(api.ResourceKindReplicaSet, item.ObjectMeta, health, message)
+	}
+	for _, item := range res.rcs {
+		health, message := getReplicasHealth(item.Spec.Replicas, item.Status.ReadyReplicas)
+		g.addNode(api.ResourceKindReplicationController, item.ObjectMeta, health, message)
+	}
+	for _, item := range res.deployments {
+		health, message := getReplicasHealth(item.Spec.Replicas, item.Status.ReadyReplicas)
+		g.addNode(api.ResourceKindDeployment, item.ObjectMeta, health, message)
+	}
+	for _, item := range res.statefulSets {
+		health, message := getReplicasHealth(item.Spec.Replicas, item.Status.ReadyReplicas)
+		g.addNode(api.ResourceKindStatefulSet, item.ObjectMeta, health, message)
+	}
+	for _, item := range res.daemonSets {
+		desired := item.Status.DesiredNumberScheduled
+		health, message := getReplicasHealth(&desired, item.Status.NumberReady)
+		g.addNode(api.ResourceKindDaemonSet, item.ObjectMeta, health, message)
+	}
+	for _, item := range res.jobs {
+		health, message := getJobHealth(item)
+		g.addNode(api.ResourceKindJob, item.ObjectMeta, health, message)
+	}
+	for _, item := range res.cronJobs {
+		health, message := getCronJobHealth(item)
+		g.addNode(api.ResourceKindCronJob, item.ObjectMeta, health, message)
+	}
+	for _, item := range res.hpas {
+		health, message := getHorizontalPodAutoscalerHealth(item)
+		g.addNode(api.ResourceKindHorizontalPodAutoscaler, item.ObjectMeta, health, message)
+	}
+}
+
+func addEdges(g *graph, res *resources) {
+	for _, ingress := range res.ingresses {
+		source := nodeID(api.ResourceKindIngress, ingress.Name)
+		for _, serviceName := range getIngressServiceNames(ingress) {
+			g.addEdgeToReference(source, api.ResourceKindService, ingress.Namespace, serviceName, EdgeTypeRoutes)
+		}
+	}
+
+	for _, service := range res.services {
+		if len(service.Spec.Selector) == 0 {
+			continue
+		}
+
+		source := nodeID(api.ResourceKindService, service.Name)
+		selector := labels.SelectorFromSet(service.Spec.Selector)
+		for _, pod := range res.pods {
+			if selector.Matches(labels.Set(pod.Labels)) {
+				g.addEdge(source, nodeID(api.ResourceKindPod, pod.Name), EdgeTypeSelects)
+			}
+		}
+	}
+
+	for _, pod := range res.pods {
+		addOwnerEdge(g, api.ResourceKindPod, pod.ObjectMeta)
+		addPodReferenceEdges(g, pod)
+	}
+	for _, item := range res.replicaSets {
+		addOwnerEdge(g, api.ResourceKindReplicaSet, item.ObjectMeta)
+	}
+
+	for _, job := range res.jobs {
+		controller := metaV1.GetControllerOf(&job)
+		if controller != nil && controller.Kind == "CronJob" {
+			g.addEdge(nodeID(api.ResourceKindCronJob, controller.Name), nodeID(api.ResourceKindJob, job.Name),
+				EdgeTypeOwns)
+		}
+	}
+
+	for _, hpa := range res.hpas {
+		kind := api.ResourceKind(strings.ToLower(hpa.Spec.ScaleTargetRef.Kind))
+		g.addEdgeToReference(nodeID(api.ResourceKindHorizontalPodAutoscaler, hpa.Name), kind, hpa.Namespace,
+			hpa.Spec.ScaleTargetRef.Name, EdgeTypeScales)
+	}
+
+	for _, item := range res.configMaps {
+		g.setReferencedNodeHealth(api.ResourceKindConfigMap, item.ObjectMeta, HealthHealthy, "")
+	}
+	for _, item := range res.secrets {
+		g.setReferencedNodeHealth(api.ResourceKindSecret, item.ObjectMeta, HealthHealthy, "")
+	}
+	for _, item := range res.pvcs {
+		health, message := getPersistentVolumeClaimHealth(item)
+		g.setReferencedNodeHealth(api.ResourceKindPersistentVolumeClaim, item.ObjectMeta, health, message)
+	}
+}
+
+// Adds edge from the object to its controller, if the controller is part of the graph.
+func addOwnerEdge(g *graph, kind api.ResourceKind, meta metaV1.ObjectMeta) {
+	controller := metaV1.GetControllerOf(&meta)
+	if controller == nil {
+		return
+	}
+
+	target := nodeID(api.ResourceKind(strings.ToLower(controller.Kind)), controller.Name)
+	if _, ok := g.nodes[target]; ok {
+		g.addEdge(nodeID(kind, meta.Name), target, EdgeTypeOwnedBy)
+	}
+}
+
+// Adds edges from the pod to config maps, secrets and persistent volume claims it uses.
+func addPodReferenceEdges(g *graph, pod v1.Pod) {
+	source := nodeID(api.ResourceKindPod, pod.Name)
+	reference := func(kind api.ResourceKind, name string, edgeType EdgeType) {
+		g.addEdgeToReference(source, kind, pod.Namespace, name, edgeType)
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		switch {
+		case volume.ConfigMap != nil:
+			reference(api.ResourceKindConfigMap, volume.ConfigMap.Name, EdgeTypeMounts)
+		case volume.Secret != nil:
+			reference(api.ResourceKindSecret, volume.Secret.SecretName, EdgeTypeMounts)
+		case volume.PersistentVolumeClaim != nil:
+			reference(api.ResourceKindPersistentVolumeClaim, volume.PersistentVolumeClaim.ClaimName, EdgeTypeMounts)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					reference(api.ResourceKindConfigMap, source.ConfigMap.Name, EdgeTypeMounts)
+				}
+				if source.Secret != nil {
+					reference(api.ResourceKindSecret, source.Secret.Name, EdgeTypeMounts)
+				}
+			}
+		}
+	}
+
+	containers := append(append([]v1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				reference(api.ResourceKindConfigMap, envFrom.ConfigMapRef.Name, EdgeTypeReferences)
+			}
+			if envFrom.SecretRef != nil {
+				reference(api.ResourceKindSecret, envFrom.SecretRef.Name, EdgeTypeReferences)
+			}
+		}
+
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				reference(api.ResourceKindConfigMap, env.ValueFrom.ConfigMapKeyRef.Name, EdgeTypeReferences)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				reference(api.ResourceKindSecret, env.ValueFrom.SecretKeyRef.Name, EdgeTypeReferences)
+			}
+		}
+	}
+
+	for _, secret := range pod.Spec.ImagePullSecrets {
+		reference(api.ResourceKindSecret, secret.Name, EdgeTypeReferences)
+	}
+}
+
+func getIngressServiceNames(ingress extensions.Ingress) []string {
+	names := make([]string, 0)
+	if ingress.Spec.Backend != nil {
+		names = append(names, ingress.Spec.Backend.ServiceName)
+	}
+
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			names = append(names, path.Backend.ServiceName)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,135 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"reflect"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	autoscaling "k8s.io/api/autoscaling/v1"
+	batch "k8s.io/api/batch/v1"
+	batch2 "k8s.io/api/batch/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetTopology(t *testing.T) {
+	replicas := int32(2)
+	isController := true
+	labels := map[string]string{"app": "web"}
+	owner := func(kind, name string) []metaV1.OwnerReference {
+		return []metaV1.OwnerReference{{Kind: kind, Name: name, Controller: &isController}}
+	}
+	readyPod := v1.PodStatus{
+		Phase:      v1.PodRunning,
+		Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+	}
+
+	client := fake.NewSimpleClientset(
+		&extensions.Ingress{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "ns"},
+			Spec:       extensions.IngressSpec{Backend: &extensions.IngressBackend{ServiceName: "web"}},
+		},
+		&v1.Service{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "ns"},
+			Spec:       v1.ServiceSpec{Selector: labels},
+		},
+		&apps.Deployment{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "ns"},
+			Spec:       apps.DeploymentSpec{Replicas: &replicas},
+			Status:     apps.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&apps.ReplicaSet{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web-1", Namespace: "ns", OwnerReferences: owner("Deployment", "web")},
+			Spec:       apps.ReplicaSetSpec{Replicas: &replicas},
+			Status:     apps.ReplicaSetStatus{ReadyReplicas: 2},
+		},
+		&v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web-1-a", Namespace: "ns", Labels: labels,
+				OwnerReferences: owner("ReplicaSet", "web-1")},
+			Spec: v1.PodSpec{
+				Volumes: []v1.Volume{
+					{Name: "config", VolumeSource: v1.VolumeSource{
+						ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "config"}}}},
+					{Name: "data", VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"}}},
+				},
+				ImagePullSecrets: []v1.LocalObjectReference{{Name: "missing"}},
+			},
+			Status: readyPod,
+		},
+		&v1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "config", Namespace: "ns"}},
+		&v1.ConfigMap{ObjectMeta: metaV1.ObjectMeta{Name: "unused", Namespace: "ns"}},
+		&v1.PersistentVolumeClaim{
+			ObjectMeta: metaV1.ObjectMeta{Name: "data", Namespace: "ns"},
+			Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimPending},
+		},
+		&autoscaling.HorizontalPodAutoscaler{
+			ObjectMeta: metaV1.ObjectMeta{Name: "web", Namespace: "ns"},
+			Spec: autoscaling.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscaling.CrossVersionObjectReference{Kind: "Deployment", Name: "web"},
+				MaxReplicas:    5,
+			},
+		},
+		&batch2.CronJob{ObjectMeta: metaV1.ObjectMeta{Name: "backup", Namespace: "ns"}},
+		&batch.Job{
+			ObjectMeta: metaV1.ObjectMeta{Name: "backup-1", Namespace: "ns", OwnerReferences: owner("CronJob", "backup")},
+		},
+	)
+
+	actual, err := GetTopology(client, "ns")
+	if err != nil {
+		t.Fatalf("GetTopology(): unexpected error: %s", err.Error())
+	}
+
+	health := make(map[string]Health)
+	for _, node := range actual.Nodes {
+		health[node.ID] = node.Health
+	}
+	expectedHealth := map[string]Health{
+		"ingress/web":                 HealthHealthy,
+		"service/web":                 HealthHealthy,
+		"pod/web-1-a":                 HealthHealthy,
+		"replicaset/web-1":            HealthHealthy,
+		"deployment/web":              HealthWarning,
+		"job/backup-1":                HealthHealthy,
+		"cronjob/backup":              HealthHealthy,
+		"horizontalpodautoscaler/web": HealthHealthy,
+		"configmap/config":            HealthHealthy,
+		"persistentvolumeclaim/data":  HealthWarning,
+		"secret/missing":              HealthUnhealthy,
+	}
+	if !reflect.DeepEqual(health, expectedHealth) {
+		t.Errorf("GetTopology() nodes == %v, expected %v", health, expectedHealth)
+	}
+
+	expectedEdges := []TopologyEdge{
+		{Source: "ingress/web", Target: "service/web", Type: EdgeTypeRoutes},
+		{Source: "service/web", Target: "pod/web-1-a", Type: EdgeTypeSelects},
+		{Source: "pod/web-1-a", Target: "replicaset/web-1", Type: EdgeTypeOwnedBy},
+		{Source: "pod/web-1-a", Target: "configmap/config", Type: EdgeTypeMounts},
+		{Source: "pod/web-1-a", Target: "persistentvolumeclaim/data", Type: EdgeTypeMounts},
+		{Source: "pod/web-1-a", Target: "secret/missing", Type: EdgeTypeReferences},
+		{Source: "replicaset/web-1", Target: "deployment/web", Type: EdgeTypeOwnedBy},
+		{Source: "cronjob/backup", Target: "job/backup-1", Type: EdgeTypeOwns},
+		{Source: "horizontalpodautoscaler/web", Target: "deployment/web", Type: EdgeTypeScales},
+	}
+	if !reflect.DeepEqual(actual.Edges, expectedEdges) {
+		t.Errorf("GetTopology() edges == %v, expected %v", actual.Edges, expectedEdges)
+	}
+}
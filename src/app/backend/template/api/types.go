@@ -0,0 +1,143 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+const (
+	// TemplatesConfigMapName contains a name of config map, that stores app templates.
+	TemplatesConfigMapName = "kubernetes-dashboard-templates"
+
+	// ConfigMapKindName is a name of config map kind.
+	ConfigMapKindName = "ConfigMap"
+
+	// ConfigMapAPIVersion is a API version of config map.
+	ConfigMapAPIVersion = "v1"
+)
+
+// TemplateKind describes what the template renders to.
+type TemplateKind string
+
+const (
+	// TemplateKindAppDeployment templates render to app deployment spec, the same as used by the deploy form.
+	TemplateKindAppDeployment TemplateKind = "appDeployment"
+
+	// TemplateKindManifest templates render to YAML or JSON manifests, the same as used by deploy from file.
+	TemplateKindManifest TemplateKind = "manifest"
+)
+
+// ParameterType is a type of the template parameter value.
+type ParameterType string
+
+// Supported parameter types. Values of integer parameters are passed to the template as int64.
+const (
+	ParameterTypeString  ParameterType = "string"
+	ParameterTypeInteger ParameterType = "integer"
+	ParameterTypeBoolean ParameterType = "boolean"
+)
+
+// TemplateManager is used for management of app templates.
+type TemplateManager interface {
+	// GetTemplates gets all templates from config map.
+	GetTemplates(client kubernetes.Interface) ([]Template, error)
+	// GetTemplate gets template with given name from config map.
+	GetTemplate(client kubernetes.Interface, name string) (*Template, error)
+	// SaveTemplate creates or replaces template in config map.
+	SaveTemplate(client kubernetes.Interface, t *Template) error
+	// DeleteTemplate removes template with given name from config map.
+	DeleteTemplate(client kubernetes.Interface, name string) error
+}
+
+// Template is a parameterized app stored in the cluster.
+type Template struct {
+	// Name of the template. It is also the key of the template in config map.
+	Name string `json:"name"`
+
+	Description string `json:"description"`
+
+	Kind TemplateKind `json:"kind"`
+
+	Parameters []Parameter `json:"parameters"`
+
+	// Go template rendered with parameter values, e.g. "replicas: {{ .replicas }}". Parameters are available by
+	// their names, namespace of the deployment as "Namespace".
+	Content string `json:"content"`
+}
+
+// Parameter is a typed parameter of the template.
+type Parameter struct {
+	// Name of the parameter used in the template content. Must be a valid Go identifier.
+	Name string `json:"name"`
+
+	Description string `json:"description"`
+
+	Type ParameterType `json:"type"`
+
+	// Value used if the parameter is not given. Parameters without default value are required.
+	Default interface{} `json:"default,omitempty"`
+}
+
+// TemplateList is a list of templates.
+type TemplateList struct {
+	Templates []Template `json:"templates"`
+}
+
+// DeploySpec is a specification of deployment of the template.
+type DeploySpec struct {
+	// Target namespace of the app.
+	Namespace string `json:"namespace"`
+
+	// Values of template parameters by parameter names.
+	Parameters map[string]interface{} `json:"parameters"`
+
+	// Whether to only render and validate the template without creating any objects.
+	DryRun bool `json:"dryRun"`
+}
+
+// DeployResult is a result of deployment of the template.
+type DeployResult struct {
+	Name string       `json:"name"`
+	Kind TemplateKind `json:"kind"`
+
+	// Rendered content of the template.
+	Content string `json:"content"`
+
+	// Deployed app spec. Set for app deployment templates.
+	AppDeployment *deployment.AppDeploymentSpec `json:"appDeployment,omitempty"`
+
+	// Results of deployment of objects from the rendered manifest. Set for manifest templates.
+	Manifest *deployment.AppDeploymentFromFileResponse `json:"manifest,omitempty"`
+}
+
+// GetEmptyTemplatesConfigMap returns config map without any templates.
+func GetEmptyTemplatesConfigMap(namespace string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TemplatesConfigMapName,
+			Namespace: namespace,
+		},
+		TypeMeta: metav1.TypeMeta{
+			Kind:       ConfigMapKindName,
+			APIVersion: ConfigMapAPIVersion,
+		},
+		Data: map[string]string{},
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"fmt"
+	"log"
+
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/template/api"
+	"github.com/kubernetes/dashboard/src/app/backend/validation"
+)
+
+// DeployTemplate renders the template with given parameter values and deploys the result into the namespace. App
+// deployment templates are deployed the same way as apps from the deploy form, manifest templates the same way as
+// deploy from file.
+func DeployTemplate(client kubernetes.Interface, cfg *rest.Config, t *api.Template,
+	spec *api.DeploySpec) (*api.DeployResult, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return deployTemplate(client, discoveryClient, dynamicClient, t, spec)
+}
+
+func deployTemplate(client kubernetes.Interface, discoveryClient discovery.DiscoveryInterface,
+	dynamicClient dynamic.Interface, t *api.Template, spec *api.DeploySpec) (*api.DeployResult, error) {
+	log.Printf("Deploying %s template into %s namespace", t.Name, spec.Namespace)
+
+	if len(spec.Namespace) == 0 {
+		return nil, errors.NewBadRequest("namespace is required")
+	}
+
+	content, err := Render(t, spec.Namespace, spec.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.DeployResult{Name: t.Name, Kind: t.Kind, Content: content}
+	switch t.Kind {
+	case api.TemplateKindAppDeployment:
+		appDeploymentSpec := new(deployment.AppDeploymentSpec)
+		if err := yaml.UnmarshalStrict([]byte(content), appDeploymentSpec); err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("Rendered template %s is not a valid app deployment: %s",
+				t.Name, err.Error()))
+		}
+		appDeploymentSpec.Namespace = spec.Namespace
+
+		if err := validation.ValidateAppDeploymentSpec(appDeploymentSpec); err != nil {
+			return nil, err
+		}
+		if !spec.DryRun {
+			if err := deployment.DeployApp(appDeploymentSpec, client); err != nil {
+				return nil, err
+			}
+		}
+		result.AppDeployment = appDeploymentSpec
+	case api.TemplateKindManifest:
+		response, err := deployment.DeployObjectsFromFile(discoveryClient, dynamicClient,
+			&deployment.AppDeploymentFromFileSpec{
+				Name:      t.Name,
+				Namespace: spec.Namespace,
+				Content:   content,
+				DryRun:    spec.DryRun,
+			})
+		if err != nil {
+			return nil, err
+		}
+		result.Manifest = response
+	default:
+		return nil, errors.NewBadRequest(fmt.Sprintf("Invalid kind %q of template %s", t.Kind, t.Name))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"strings"
+	"testing"
+
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+	"github.com/kubernetes/dashboard/src/app/backend/template/api"
+	"github.com/kubernetes/dashboard/src/app/backend/testutil"
+)
+
+func newDiscoveryClient() discovery.DiscoveryInterface {
+	return testutil.NewDiscoveryClient(&metaV1.APIResourceList{
+		GroupVersion: "v1",
+		APIResources: []metaV1.APIResource{
+			{Name: "configmaps", Kind: "ConfigMap", Namespaced: true, Verbs: []string{"get", "list", "patch"}},
+		},
+	})
+}
+
+func newAppDeploymentTemplate() *api.Template {
+	return &api.Template{
+		Name: "service",
+		Kind: api.TemplateKindAppDeployment,
+		Parameters: []api.Parameter{
+			{Name: "name", Type: api.ParameterTypeString},
+			{Name: "image", Type: api.ParameterTypeString, Default: "nginx:1.17"},
+			{Name: "replicas", Type: api.ParameterTypeInteger, Default: float64(1)},
+		},
+		Content: `name: {{ .name }}
+containerImage: {{ .image | quote }}
+replicas: {{ .replicas }}
+labels:
+- key: app
+  value: {{ .name }}
+`,
+	}
+}
+
+func TestDeployAppDeploymentTemplate(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	spec := &api.DeploySpec{Namespace: "team-a", Parameters: map[string]interface{}{"name": "web",
+		"replicas": float64(3)}}
+
+	result, err := deployTemplate(client, newDiscoveryClient(), testutil.NewApplyClient(), newAppDeploymentTemplate(),
+		spec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.AppDeployment == nil || result.AppDeployment.Namespace != "team-a" ||
+		result.AppDeployment.ContainerImage != "nginx:1.17" {
+		t.Errorf("Unexpected app deployment spec %#v", result.AppDeployment)
+	}
+
+	created, err := client.AppsV1().Deployments("team-a").Get("web", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected deployment to be created, but got %v", err)
+	}
+	if *created.Spec.Replicas != 3 || created.Labels["app"] != "web" {
+		t.Errorf("Unexpected deployment %#v", created)
+	}
+}
+
+func TestDeployAppDeploymentTemplateDryRun(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	spec := &api.DeploySpec{Namespace: "team-a", Parameters: map[string]interface{}{"name": "web"}, DryRun: true}
+
+	result, err := deployTemplate(client, newDiscoveryClient(), testutil.NewApplyClient(), newAppDeploymentTemplate(),
+		spec)
+	if err != nil || result.AppDeployment == nil {
+		t.Fatalf("Expected rendered app deployment, but got %v, %v", result, err)
+	}
+	if len(client.Actions()) != 0 {
+		t.Errorf("Expected no actions in dry run, but got %v", client.Actions())
+	}
+}
+
+func TestDeployAppDeploymentTemplateInvalid(t *testing.T) {
+	cases := []struct {
+		info       string
+		parameters map[string]interface{}
+		expected   string
+	}{
+		{"invalid app name", map[string]interface{}{"name": "Web"}, "name: Invalid value"},
+		{"unknown field", map[string]interface{}{"name": "web\nunknown: value"}, "is not a valid app deployment"},
+	}
+
+	for _, c := range cases {
+		client := fake.NewSimpleClientset()
+		spec := &api.DeploySpec{Namespace: "team-a", Parameters: c.parameters}
+		_, err := deployTemplate(client, newDiscoveryClient(), testutil.NewApplyClient(), newAppDeploymentTemplate(),
+			spec)
+		if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("%s: expected error containing %q, but got %v", c.info, c.expected, err)
+		}
+		if len(client.Actions()) != 0 {
+			t.Errorf("%s: expected no actions, but got %v", c.info, client.Actions())
+		}
+	}
+}
+
+func TestDeployManifestTemplate(t *testing.T) {
+	template := &api.Template{
+		Name:       "config",
+		Kind:       api.TemplateKindManifest,
+		Parameters: []api.Parameter{{Name: "level", Type: api.ParameterTypeString, Default: "info"}},
+		Content: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: logging
+data:
+  level: {{ .level }}
+`,
+	}
+	dynamicClient := testutil.NewApplyClient()
+	spec := &api.DeploySpec{Namespace: "team-a", Parameters: map[string]interface{}{"level": "debug"}}
+
+	result, err := deployTemplate(fake.NewSimpleClientset(), newDiscoveryClient(), dynamicClient, template, spec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result.Manifest == nil || len(result.Manifest.Objects) != 1 ||
+		result.Manifest.Objects[0].Result != deployment.DeployResultCreated {
+		t.Fatalf("Unexpected result %#v", result.Manifest)
+	}
+
+	configMap, err := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}).
+		Namespace("team-a").Get("logging", metaV1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected config map to be created, but got %v", err)
+	}
+	if level, _, _ := unstructured.NestedString(configMap.Object, "data", "level"); level != "debug" {
+		t.Errorf("Expected level debug, but got %s", level)
+	}
+}
+
+func TestDeployTemplateWithoutNamespace(t *testing.T) {
+	_, err := deployTemplate(fake.NewSimpleClientset(), newDiscoveryClient(), testutil.NewApplyClient(),
+		newAppDeploymentTemplate(), &api.DeploySpec{Parameters: map[string]interface{}{"name": "web"}})
+	if err == nil {
+		t.Errorf("Expected error for missing namespace")
+	}
+}
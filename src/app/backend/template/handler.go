@@ -0,0 +1,212 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"net/http"
+
+	restful "github.com/emicklei/go-restful"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/template/api"
+)
+
+// TemplateHandler manages all endpoints related to app templates. Templates are stored in the cluster of clientManager
+// and read with Dashboard's own client, which is allowed to get the templates config map. Changes are made with the
+// client of the user. Templates are deployed to the cluster of deployClientManager.
+type TemplateHandler struct {
+	manager             api.TemplateManager
+	clientManager       clientapi.ClientManager
+	deployClientManager clientapi.ClientManager
+}
+
+// Install creates new endpoints for managing app templates.
+func (self *TemplateHandler) Install(ws *restful.WebService) {
+	ws.Route(
+		ws.GET("/template").
+			To(self.handleGetTemplates).
+			Writes(api.TemplateList{}))
+	ws.Route(
+		ws.GET("/template/cani").
+			To(self.handleTemplatesCanI).
+			Writes(clientapi.CanIResponse{}))
+	ws.Route(
+		ws.GET("/template/{name}").
+			To(self.handleGetTemplate).
+			Writes(api.Template{}))
+	ws.Route(
+		ws.PUT("/template/{name}").
+			To(self.handleSaveTemplate).
+			Reads(api.Template{}).
+			Writes(api.Template{}))
+	ws.Route(
+		ws.DELETE("/template/{name}").
+			To(self.handleDeleteTemplate))
+}
+
+// InstallDeploy creates new endpoint for deploying app templates to the cluster of given client manager.
+func (self *TemplateHandler) InstallDeploy(ws *restful.WebService, clientManager clientapi.ClientManager) {
+	handler := &TemplateHandler{
+		manager:             self.manager,
+		clientManager:       self.clientManager,
+		deployClientManager: clientManager,
+	}
+	ws.Route(
+		ws.POST("/template/{name}/deploy").
+			To(handler.handleDeployTemplate).
+			Reads(api.DeploySpec{}).
+			Writes(api.DeployResult{}))
+}
+
+func (self *TemplateHandler) handleTemplatesCanI(request *restful.Request, response *restful.Response) {
+	verb := request.QueryParameter("verb")
+	if len(verb) == 0 {
+		verb = http.MethodGet
+	}
+
+	canI := self.clientManager.CanI(request, clientapi.ToSelfSubjectAccessReview(
+		args.Holder.GetNamespace(),
+		api.TemplatesConfigMapName,
+		api.ConfigMapKindName,
+		verb,
+	))
+
+	response.WriteHeaderAndEntity(http.StatusOK, clientapi.CanIResponse{Allowed: canI})
+}
+
+func (self *TemplateHandler) handleGetTemplates(request *restful.Request, response *restful.Response) {
+	templates, err := self.manager.GetTemplates(self.clientManager.InsecureClient())
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, api.TemplateList{Templates: templates})
+}
+
+func (self *TemplateHandler) handleGetTemplate(request *restful.Request, response *restful.Response) {
+	result, err := self.manager.GetTemplate(self.clientManager.InsecureClient(), request.PathParameter("name"))
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusOK, result)
+}
+
+func (self *TemplateHandler) handleSaveTemplate(request *restful.Request, response *restful.Response) {
+	t := new(api.Template)
+	if err := request.ReadEntity(t); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	t.Name = request.PathParameter("name")
+
+	if err := self.checkCanUpdate(request); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	client, err := self.clientManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	if err := self.manager.SaveTemplate(client, t); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeaderAndEntity(http.StatusCreated, t)
+}
+
+func (self *TemplateHandler) handleDeleteTemplate(request *restful.Request, response *restful.Response) {
+	if err := self.checkCanUpdate(request); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	client, err := self.clientManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	if err := self.manager.DeleteTemplate(client, request.PathParameter("name")); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+	response.WriteHeader(http.StatusNoContent)
+}
+
+func (self *TemplateHandler) handleDeployTemplate(request *restful.Request, response *restful.Response) {
+	spec := new(api.DeploySpec)
+	if err := request.ReadEntity(spec); err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	t, err := self.manager.GetTemplate(self.clientManager.InsecureClient(), request.PathParameter("name"))
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	client, err := self.deployClientManager.Client(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	config, err := self.deployClientManager.Config(request)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	result, err := DeployTemplate(client, config, t, spec)
+	if err != nil {
+		errors.HandleInternalError(response, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if spec.DryRun {
+		status = http.StatusOK
+	}
+	if result.Manifest != nil {
+		status = result.Manifest.Status(status)
+	}
+	response.WriteHeaderAndEntity(status, result)
+}
+
+// checkCanUpdate returns forbidden error if the user is not allowed to update the templates config map.
+func (self *TemplateHandler) checkCanUpdate(request *restful.Request) error {
+	canI := self.clientManager.CanI(request, clientapi.ToSelfSubjectAccessReview(
+		args.Holder.GetNamespace(),
+		api.TemplatesConfigMapName,
+		"configmaps",
+		"update",
+	))
+	if !canI {
+		return errors.NewForbidden("User is not allowed to change templates")
+	}
+	return nil
+}
+
+// NewTemplateHandler creates TemplateHandler that deploys templates to the cluster they are stored in.
+func NewTemplateHandler(manager api.TemplateManager, clientManager clientapi.ClientManager) TemplateHandler {
+	return TemplateHandler{manager: manager, clientManager: clientManager, deployClientManager: clientManager}
+}
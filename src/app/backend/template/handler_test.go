@@ -0,0 +1,104 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	clientapi "github.com/kubernetes/dashboard/src/app/backend/client/api"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/template/api"
+)
+
+// fakeClientManager serves Dashboard's own client, while the client of the user is not allowed to do anything.
+type fakeClientManager struct {
+	clientapi.ClientManager
+	insecureClient kubernetes.Interface
+}
+
+func (self *fakeClientManager) InsecureClient() kubernetes.Interface {
+	return self.insecureClient
+}
+
+func (self *fakeClientManager) Client(request *restful.Request) (kubernetes.Interface, error) {
+	return nil, errors.NewUnauthorized("MSG_LOGIN_UNAUTHORIZED_ERROR")
+}
+
+func (self *fakeClientManager) CanI(request *restful.Request, ssar *authorizationv1.SelfSubjectAccessReview) bool {
+	return false
+}
+
+func TestTemplateHandler_Install(t *testing.T) {
+	tHandler := NewTemplateHandler(NewTemplateManager(), nil)
+	ws := new(restful.WebService)
+	tHandler.Install(ws)
+
+	if len(ws.Routes()) == 0 {
+		t.Error("Failed to install routes.")
+	}
+	for _, route := range ws.Routes() {
+		if route.Method == http.MethodPost {
+			t.Errorf("Install() installed deploy route %s", route.Path)
+		}
+	}
+
+	deployWs := new(restful.WebService)
+	tHandler.InstallDeploy(deployWs, nil)
+	if len(deployWs.Routes()) != 1 || deployWs.Routes()[0].Path != "/template/{name}/deploy" {
+		t.Errorf("InstallDeploy() installed routes %v", deployWs.Routes())
+	}
+}
+
+func TestTemplateHandler_ReadWithDashboardClient(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: api.TemplatesConfigMapName, Namespace: args.Holder.GetNamespace()},
+		Data:       map[string]string{"nginx": `{"description":"Web server"}`},
+	})
+	tHandler := NewTemplateHandler(NewTemplateManager(), &fakeClientManager{insecureClient: client})
+	ws := new(restful.WebService).Consumes(restful.MIME_JSON).Produces(restful.MIME_JSON)
+	tHandler.Install(ws)
+	container := restful.NewContainer()
+	container.Add(ws)
+
+	recorder := httptest.NewRecorder()
+	container.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/template", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /template returned %d: %s", recorder.Code, recorder.Body.String())
+	}
+	list := new(api.TemplateList)
+	if err := json.Unmarshal(recorder.Body.Bytes(), list); err != nil {
+		t.Fatalf("Cannot unmarshal template list: %s", err.Error())
+	}
+	if len(list.Templates) != 1 || list.Templates[0].Name != "nginx" {
+		t.Errorf("GET /template returned %v", list.Templates)
+	}
+
+	recorder = httptest.NewRecorder()
+	container.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/template/nginx", nil))
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("DELETE /template/nginx returned %d, expected %d", recorder.Code, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/args"
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/template/api"
+)
+
+// TemplateManager is a structure containing all template manager members. Templates are stored as JSON in the
+// templates config map in the namespace of Dashboard, one key per template. Config map is read on every request, so
+// that changes made by other replicas or directly in the cluster are visible immediately.
+type TemplateManager struct{}
+
+// NewTemplateManager creates new template manager.
+func NewTemplateManager() api.TemplateManager {
+	return &TemplateManager{}
+}
+
+// load returns the templates config map. Nil is returned if it does not exist yet.
+func (tm *TemplateManager) load(client kubernetes.Interface) (*v1.ConfigMap, error) {
+	configMap, err := client.CoreV1().ConfigMaps(args.Holder.GetNamespace()).
+		Get(api.TemplatesConfigMapName, metav1.GetOptions{})
+	if errors.IsNotFoundError(err) {
+		return nil, nil
+	}
+	return configMap, err
+}
+
+// GetTemplates implements TemplateManager interface. Check it for more information.
+func (tm *TemplateManager) GetTemplates(client kubernetes.Interface) ([]api.Template, error) {
+	configMap, err := tm.load(client)
+	if err != nil || configMap == nil {
+		return []api.Template{}, err
+	}
+
+	templates := make([]api.Template, 0, len(configMap.Data))
+	for key, value := range configMap.Data {
+		t, err := unmarshalTemplate(key, value)
+		if err != nil {
+			log.Printf("Cannot unmarshal template %s: %s", key, err.Error())
+			continue
+		}
+		templates = append(templates, *t)
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// GetTemplate implements TemplateManager interface. Check it for more information.
+func (tm *TemplateManager) GetTemplate(client kubernetes.Interface, name string) (*api.Template, error) {
+	configMap, err := tm.load(client)
+	if err != nil {
+		return nil, err
+	}
+
+	if configMap == nil || len(configMap.Data[name]) == 0 {
+		return nil, newTemplateNotFound(name)
+	}
+	return unmarshalTemplate(name, configMap.Data[name])
+}
+
+// SaveTemplate implements TemplateManager interface. Check it for more information.
+func (tm *TemplateManager) SaveTemplate(client kubernetes.Interface, t *api.Template) error {
+	if err := ValidateTemplate(t); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	configMap, err := tm.load(client)
+	if err != nil {
+		return err
+	}
+
+	configMaps := client.CoreV1().ConfigMaps(args.Holder.GetNamespace())
+	if configMap == nil {
+		configMap = api.GetEmptyTemplatesConfigMap(args.Holder.GetNamespace())
+		configMap.Data[t.Name] = string(data)
+		_, err = configMaps.Create(configMap)
+		return err
+	}
+
+	// Data can be nil if the configMap exists but does not have any data
+	if configMap.Data == nil {
+		configMap.Data = make(map[string]string)
+	}
+
+	// Resource version of the loaded config map makes concurrent changes fail with conflict.
+	configMap.Data[t.Name] = string(data)
+	_, err = configMaps.Update(configMap)
+	return err
+}
+
+// DeleteTemplate implements TemplateManager interface. Check it for more information.
+func (tm *TemplateManager) DeleteTemplate(client kubernetes.Interface, name string) error {
+	configMap, err := tm.load(client)
+	if err != nil {
+		return err
+	}
+
+	if configMap == nil {
+		return newTemplateNotFound(name)
+	}
+	if _, ok := configMap.Data[name]; !ok {
+		return newTemplateNotFound(name)
+	}
+
+	delete(configMap.Data, name)
+	_, err = client.CoreV1().ConfigMaps(args.Holder.GetNamespace()).Update(configMap)
+	return err
+}
+
+// unmarshalTemplate decodes template stored under given key. Key is the name of the template.
+func unmarshalTemplate(key, value string) (*api.Template, error) {
+	t := new(api.Template)
+	if err := json.Unmarshal([]byte(value), t); err != nil {
+		return nil, err
+	}
+	t.Name = key
+	return t, nil
+}
+
+func newTemplateNotFound(name string) error {
+	return errors.NewNotFound(fmt.Sprintf("template %s not found", name))
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/template/api"
+)
+
+func TestTemplateManager(t *testing.T) {
+	tm := NewTemplateManager()
+	client := fake.NewSimpleClientset()
+
+	templates, err := tm.GetTemplates(client)
+	if err != nil || len(templates) != 0 {
+		t.Fatalf("Expected no templates without config map, but got %v, %v", templates, err)
+	}
+
+	web := newTestTemplate()
+	worker := newTestTemplate()
+	worker.Name = "worker"
+	for _, template := range []*api.Template{worker, web} {
+		if err := tm.SaveTemplate(client, template); err != nil {
+			t.Fatalf("Cannot save template %s: %v", template.Name, err)
+		}
+	}
+
+	configMap, err := client.CoreV1().ConfigMaps("").Get(api.TemplatesConfigMapName, metav1.GetOptions{})
+	if err != nil || len(configMap.Data) != 2 {
+		t.Fatalf("Expected config map with 2 templates, but got %v, %v", configMap, err)
+	}
+
+	templates, err = tm.GetTemplates(client)
+	if err != nil || len(templates) != 2 || templates[0].Name != "web" || templates[1].Name != "worker" {
+		t.Fatalf("Expected web and worker templates, but got %v, %v", templates, err)
+	}
+
+	actual, err := tm.GetTemplate(client, "web")
+	if err != nil || !reflect.DeepEqual(actual, web) {
+		t.Errorf("Expected template %#v, but got %#v, %v", web, actual, err)
+	}
+
+	if err := tm.DeleteTemplate(client, "web"); err != nil {
+		t.Fatalf("Cannot delete template: %v", err)
+	}
+	if _, err := tm.GetTemplate(client, "web"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected not found error for deleted template, but got %v", err)
+	}
+	if err := tm.DeleteTemplate(client, "web"); !errors.IsNotFoundError(err) {
+		t.Errorf("Expected not found error for repeated delete, but got %v", err)
+	}
+
+	invalid := newTestTemplate()
+	invalid.Kind = "chart"
+	if err := tm.SaveTemplate(client, invalid); err == nil {
+		t.Errorf("Expected invalid template to be rejected")
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	textTemplate "text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/template/api"
+)
+
+// namespaceParameter is the name under which the namespace of the deployment is available in templates.
+const namespaceParameter = "Namespace"
+
+// parameterNameRegexp matches names usable as fields in Go templates, e.g. {{ .replicas }}.
+var parameterNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// parameterTypes are supported types of template parameters.
+var parameterTypes = map[api.ParameterType]bool{
+	api.ParameterTypeString:  true,
+	api.ParameterTypeInteger: true,
+	api.ParameterTypeBoolean: true,
+}
+
+// templateFuncs are functions available in template content in addition to Go template builtins.
+var templateFuncs = textTemplate.FuncMap{
+	// quote returns the value as double-quoted string, which is valid both in YAML and JSON.
+	"quote": func(value interface{}) (string, error) {
+		data, err := json.Marshal(fmt.Sprint(value))
+		return string(data), err
+	},
+}
+
+// ValidateTemplate checks the name, kind, parameters and syntax of the template content.
+func ValidateTemplate(t *api.Template) error {
+	if errs := validation.IsDNS1123Subdomain(t.Name); len(errs) > 0 {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid template name %q: %s", t.Name, strings.Join(errs, "; ")))
+	}
+
+	if t.Kind != api.TemplateKindAppDeployment && t.Kind != api.TemplateKindManifest {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid kind %q of template %s, expected %s or %s", t.Kind,
+			t.Name, api.TemplateKindAppDeployment, api.TemplateKindManifest))
+	}
+
+	names := map[string]bool{namespaceParameter: true}
+	for i, parameter := range t.Parameters {
+		if !parameterNameRegexp.MatchString(parameter.Name) {
+			return errors.NewBadRequest(fmt.Sprintf("Invalid name %q of parameter %d, it must be a valid identifier",
+				parameter.Name, i))
+		}
+		if names[parameter.Name] {
+			return errors.NewBadRequest(fmt.Sprintf("Duplicate parameter %s", parameter.Name))
+		}
+		names[parameter.Name] = true
+
+		if !parameterTypes[parameter.Type] {
+			return errors.NewBadRequest(fmt.Sprintf("Unsupported type %q of parameter %s, expected %s, %s or %s",
+				parameter.Type, parameter.Name, api.ParameterTypeString, api.ParameterTypeInteger,
+				api.ParameterTypeBoolean))
+		}
+
+		if parameter.Default != nil {
+			if _, err := convertValue(parameter, parameter.Default); err != nil {
+				return errors.NewBadRequest(fmt.Sprintf("Invalid default value of parameter %s: %s",
+					parameter.Name, err.Error()))
+			}
+		}
+	}
+
+	if _, err := parseContent(t); err != nil {
+		return errors.NewBadRequest(fmt.Sprintf("Invalid content of template %s: %s", t.Name, err.Error()))
+	}
+
+	return nil
+}
+
+// Render renders content of the template with given parameter values. Default values are used for missing
+// parameters. Unknown parameters, missing required parameters and values of wrong type are rejected.
+func Render(t *api.Template, namespace string, values map[string]interface{}) (string, error) {
+	data, err := resolveParameters(t, values)
+	if err != nil {
+		return "", err
+	}
+	data[namespaceParameter] = namespace
+
+	content, err := parseContent(t)
+	if err != nil {
+		return "", errors.NewBadRequest(fmt.Sprintf("Invalid content of template %s: %s", t.Name, err.Error()))
+	}
+
+	result := &bytes.Buffer{}
+	if err := content.Execute(result, data); err != nil {
+		return "", errors.NewBadRequest(fmt.Sprintf("Cannot render template %s: %s", t.Name, err.Error()))
+	}
+	return result.String(), nil
+}
+
+func parseContent(t *api.Template) (*textTemplate.Template, error) {
+	return textTemplate.New(t.Name).Funcs(templateFuncs).Option("missingkey=error").Parse(t.Content)
+}
+
+func resolveParameters(t *api.Template, values map[string]interface{}) (map[string]interface{}, error) {
+	parameters := map[string]api.Parameter{}
+	for _, parameter := range t.Parameters {
+		parameters[parameter.Name] = parameter
+	}
+
+	for name := range values {
+		if _, ok := parameters[name]; !ok {
+			return nil, errors.NewBadRequest(fmt.Sprintf("Unknown parameter %s of template %s", name, t.Name))
+		}
+	}
+
+	result := map[string]interface{}{}
+	for _, parameter := range t.Parameters {
+		value, ok := values[parameter.Name]
+		if !ok || value == nil {
+			value = parameter.Default
+		}
+		if value == nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("Missing value of required parameter %s", parameter.Name))
+		}
+
+		converted, err := convertValue(parameter, value)
+		if err != nil {
+			return nil, errors.NewBadRequest(fmt.Sprintf("Invalid value of parameter %s: %s", parameter.Name,
+				err.Error()))
+		}
+		result[parameter.Name] = converted
+	}
+
+	return result, nil
+}
+
+// convertValue checks the type of the value decoded from JSON and converts integers to int64.
+func convertValue(parameter api.Parameter, value interface{}) (interface{}, error) {
+	switch parameter.Type {
+	case api.ParameterTypeString:
+		if typed, ok := value.(string); ok {
+			return typed, nil
+		}
+	case api.ParameterTypeBoolean:
+		if typed, ok := value.(bool); ok {
+			return typed, nil
+		}
+	case api.ParameterTypeInteger:
+		switch typed := value.(type) {
+		case int:
+			return int64(typed), nil
+		case int64:
+			return typed, nil
+		case float64:
+			if typed == math.Trunc(typed) && math.Abs(typed) < math.MaxInt64 {
+				return int64(typed), nil
+			}
+		case json.Number:
+			return typed.Int64()
+		}
+	}
+
+	return nil, fmt.Errorf("expected %s, but got %v", parameter.Type, value)
+}
@@ -0,0 +1,127 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kubernetes/dashboard/src/app/backend/template/api"
+)
+
+func newTestTemplate() *api.Template {
+	return &api.Template{
+		Name: "web",
+		Kind: api.TemplateKindManifest,
+		Parameters: []api.Parameter{
+			{Name: "name", Type: api.ParameterTypeString},
+			{Name: "replicas", Type: api.ParameterTypeInteger, Default: float64(2)},
+			{Name: "debug", Type: api.ParameterTypeBoolean, Default: false},
+		},
+		Content: `name: {{ .name | quote }}
+namespace: {{ .Namespace }}
+replicas: {{ .replicas }}
+{{- if .debug }}
+debug: true
+{{- end }}
+`,
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	if err := ValidateTemplate(newTestTemplate()); err != nil {
+		t.Fatalf("Expected valid template, but got %v", err)
+	}
+
+	cases := []struct {
+		info     string
+		modify   func(t *api.Template)
+		expected string
+	}{
+		{"invalid name", func(t *api.Template) { t.Name = "Web" }, "Invalid template name"},
+		{"invalid kind", func(t *api.Template) { t.Kind = "chart" }, "Invalid kind"},
+		{"invalid parameter name", func(t *api.Template) { t.Parameters[0].Name = "app-name" }, "Invalid name"},
+		{"reserved parameter name", func(t *api.Template) { t.Parameters[0].Name = "Namespace" },
+			"Duplicate parameter"},
+		{"invalid parameter type", func(t *api.Template) { t.Parameters[0].Type = "float" }, "Unsupported type"},
+		{"invalid default value", func(t *api.Template) { t.Parameters[1].Default = 1.5 }, "Invalid default value"},
+		{"invalid content", func(t *api.Template) { t.Content = "{{ .name" }, "Invalid content"},
+	}
+
+	for _, c := range cases {
+		template := newTestTemplate()
+		c.modify(template)
+		err := ValidateTemplate(template)
+		if err == nil || !strings.Contains(err.Error(), c.expected) {
+			t.Errorf("%s: expected error containing %q, but got %v", c.info, c.expected, err)
+		}
+	}
+}
+
+func TestRender(t *testing.T) {
+	cases := []struct {
+		info     string
+		values   map[string]interface{}
+		expected string
+		err      string
+	}{
+		{
+			"default values",
+			map[string]interface{}{"name": "web: v1"},
+			"name: \"web: v1\"\nnamespace: team-a\nreplicas: 2\n",
+			"",
+		},
+		{
+			"given values",
+			map[string]interface{}{"name": "web", "replicas": float64(3), "debug": true},
+			"name: \"web\"\nnamespace: team-a\nreplicas: 3\ndebug: true\n",
+			"",
+		},
+		{
+			"missing required parameter",
+			map[string]interface{}{"replicas": float64(3)},
+			"",
+			"Missing value of required parameter name",
+		},
+		{
+			"unknown parameter",
+			map[string]interface{}{"name": "web", "image": "nginx"},
+			"",
+			"Unknown parameter image",
+		},
+		{
+			"invalid type",
+			map[string]interface{}{"name": "web", "replicas": "3"},
+			"",
+			"Invalid value of parameter replicas",
+		},
+	}
+
+	for _, c := range cases {
+		actual, err := Render(newTestTemplate(), "team-a", c.values)
+		if len(c.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("%s: expected error containing %q, but got %v", c.info, c.err, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+		} else if actual != c.expected {
+			t.Errorf("%s: expected:\n%s\nbut got:\n%s", c.info, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testutil contains fake clients shared by tests of several packages.
+package testutil
+
+import (
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+)
+
+// NewDiscoveryClient returns fake discovery client serving the given resources.
+func NewDiscoveryClient(resources ...*metaV1.APIResourceList) discovery.DiscoveryInterface {
+	discoveryClient := fake.NewSimpleClientset().Discovery()
+	discoveryClient.(*fakediscovery.FakeDiscovery).Resources = resources
+	return discoveryClient
+}
+
+// NewApplyClient returns fake dynamic client containing given objects, which approximates server-side apply by
+// creating or replacing the object.
+func NewApplyClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	scheme := runtime.NewScheme()
+	tracker := core.NewObjectTracker(scheme, serializer.NewCodecFactory(scheme).UniversalDecoder())
+	for _, object := range objects {
+		if err := tracker.Add(object); err != nil {
+			panic(err)
+		}
+	}
+
+	client := dynamicfake.NewSimpleDynamicClient(scheme)
+	client.PrependReactor("*", "*", core.ObjectReaction(tracker))
+	client.PrependReactor("patch", "*", func(action core.Action) (bool, runtime.Object, error) {
+		patch := action.(core.PatchAction)
+		if patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+
+		object := &unstructured.Unstructured{}
+		if err := object.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+
+		_, err := tracker.Get(patch.GetResource(), patch.GetNamespace(), patch.GetName())
+		if k8serrors.IsNotFound(err) {
+			return true, object, tracker.Create(patch.GetResource(), object, patch.GetNamespace())
+		}
+		return true, object, tracker.Update(patch.GetResource(), object, patch.GetNamespace())
+	})
+
+	return client
+}
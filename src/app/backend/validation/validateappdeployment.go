@@ -0,0 +1,385 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"log"
+	"path"
+
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+// supportedAccessModes are access modes of persistent volume claims created with the app.
+var supportedAccessModes = map[api.PersistentVolumeAccessMode]bool{
+	api.ReadWriteOnce: true,
+	api.ReadOnlyMany:  true,
+	api.ReadWriteMany: true,
+}
+
+// ValidateAppDeploymentSpec validates the whole app deployment specification before any object is created, so that
+// an invalid specification does not leave a partially deployed app behind. All problems are reported in a single
+// bad request error.
+func ValidateAppDeploymentSpec(spec *deployment.AppDeploymentSpec) error {
+	log.Printf("Validating specification of %s application in %s namespace", spec.Name, spec.Namespace)
+
+	errs := validateAppDeploymentSpec(spec)
+	if len(errs) > 0 {
+		return errors.NewBadRequest(errs.ToAggregate().Error())
+	}
+	return nil
+}
+
+func validateAppDeploymentSpec(spec *deployment.AppDeploymentSpec) field.ErrorList {
+	errs := field.ErrorList{}
+	errs = append(errs, validateDNSLabel(field.NewPath("name"), spec.Name)...)
+	errs = append(errs, validateDNSLabel(field.NewPath("namespace"), spec.Namespace)...)
+	errs = append(errs, validateImageReference(field.NewPath("containerImage"), spec.ContainerImage)...)
+
+	if spec.Replicas < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("replicas"), spec.Replicas, "must not be negative"))
+	}
+
+	for i, label := range spec.Labels {
+		labelPath := field.NewPath("labels").Index(i)
+		errs = append(errs, validateQualifiedName(labelPath.Child("key"), label.Key)...)
+		errs = append(errs, validateLabelValue(labelPath.Child("value"), label.Value)...)
+	}
+
+	errs = append(errs, validatePortMappings(spec)...)
+	errs = append(errs, validateVariables(field.NewPath("variables"), spec.Variables)...)
+	errs = append(errs, validateResources(nil, spec.CpuRequirement, spec.MemoryRequirement, spec.ContainerOptions)...)
+	errs = append(errs, validateContainerOptions(nil, spec.ContainerOptions)...)
+
+	// Main container is named after the app.
+	names := map[string]bool{spec.Name: true}
+	errs = append(errs, validateContainers(field.NewPath("initContainers"), spec.InitContainers, names)...)
+	errs = append(errs, validateInitContainerProbes(field.NewPath("initContainers"), spec.InitContainers)...)
+	errs = append(errs, validateContainers(field.NewPath("containers"), spec.Containers, names)...)
+
+	errs = append(errs, validatePersistentVolumeClaims(field.NewPath("persistentVolumeClaims"),
+		spec.PersistentVolumeClaims)...)
+	errs = append(errs, validateIngress(spec)...)
+
+	for key, value := range spec.NodeSelector {
+		selectorPath := field.NewPath("nodeSelector").Key(key)
+		errs = append(errs, validateQualifiedName(selectorPath, key)...)
+		errs = append(errs, validateLabelValue(selectorPath, value)...)
+	}
+
+	return errs
+}
+
+func validatePortMappings(spec *deployment.AppDeploymentSpec) field.ErrorList {
+	errs := field.ErrorList{}
+	for i, mapping := range spec.PortMappings {
+		mappingPath := field.NewPath("portMappings").Index(i)
+		errs = append(errs, validatePort(mappingPath.Child("port"), mapping.Port)...)
+		errs = append(errs, validatePort(mappingPath.Child("targetPort"), mapping.TargetPort)...)
+
+		protocolPath := mappingPath.Child("protocol")
+		supported := deployment.GetAvailableProtocols().Protocols
+		if !containsProtocol(supported, mapping.Protocol) {
+			errs = append(errs, field.NotSupported(protocolPath, mapping.Protocol, protocolNames(supported)))
+		} else if !ValidateProtocol(&ProtocolValiditySpec{Protocol: mapping.Protocol,
+			IsExternal: spec.IsExternal}).Valid {
+			errs = append(errs, field.Invalid(protocolPath, mapping.Protocol, "not supported by external services"))
+		}
+	}
+	return errs
+}
+
+func validateContainers(fieldPath *field.Path, containers []deployment.ContainerSpec,
+	names map[string]bool) field.ErrorList {
+	errs := field.ErrorList{}
+	for i, container := range containers {
+		containerPath := fieldPath.Index(i)
+		errs = append(errs, validateDNSLabel(containerPath.Child("name"), container.Name)...)
+		if names[container.Name] {
+			errs = append(errs, field.Duplicate(containerPath.Child("name"), container.Name))
+		}
+		names[container.Name] = true
+
+		errs = append(errs, validateImageReference(containerPath.Child("containerImage"), container.ContainerImage)...)
+		errs = append(errs, validateVariables(containerPath.Child("variables"), container.Variables)...)
+		errs = append(errs, validateResources(containerPath, container.CpuRequirement, container.MemoryRequirement,
+			container.ContainerOptions)...)
+		errs = append(errs, validateContainerOptions(containerPath, container.ContainerOptions)...)
+	}
+	return errs
+}
+
+// validateInitContainerProbes forbids probes of init containers, which are rejected by the API server, because init
+// containers have to run to completion before the app starts.
+func validateInitContainerProbes(fieldPath *field.Path, containers []deployment.ContainerSpec) field.ErrorList {
+	errs := field.ErrorList{}
+	for i, container := range containers {
+		containerPath := fieldPath.Index(i)
+		if container.LivenessProbe != nil {
+			errs = append(errs, field.Forbidden(containerPath.Child("livenessProbe"),
+				"may not be set for init containers"))
+		}
+		if container.ReadinessProbe != nil {
+			errs = append(errs, field.Forbidden(containerPath.Child("readinessProbe"),
+				"may not be set for init containers"))
+		}
+	}
+	return errs
+}
+
+// validateResources checks that requests and limits are not negative and that limits are not lower than requests.
+// Path is nil for the main container, whose fields are at the top level of the specification.
+func validateResources(fieldPath *field.Path, cpuRequirement, memoryRequirement *resource.Quantity,
+	options deployment.ContainerOptions) field.ErrorList {
+	errs := field.ErrorList{}
+	errs = append(errs, validateQuantity(fieldPath.Child("cpuRequirement"), cpuRequirement)...)
+	errs = append(errs, validateQuantity(fieldPath.Child("memoryRequirement"), memoryRequirement)...)
+	errs = append(errs, validateQuantity(fieldPath.Child("cpuLimit"), options.CpuLimit)...)
+	errs = append(errs, validateQuantity(fieldPath.Child("memoryLimit"), options.MemoryLimit)...)
+
+	if cpuRequirement != nil && options.CpuLimit != nil && options.CpuLimit.Cmp(*cpuRequirement) < 0 {
+		errs = append(errs, field.Invalid(fieldPath.Child("cpuLimit"), options.CpuLimit.String(),
+			"must be greater than or equal to cpuRequirement"))
+	}
+	if memoryRequirement != nil && options.MemoryLimit != nil && options.MemoryLimit.Cmp(*memoryRequirement) < 0 {
+		errs = append(errs, field.Invalid(fieldPath.Child("memoryLimit"), options.MemoryLimit.String(),
+			"must be greater than or equal to memoryRequirement"))
+	}
+	return errs
+}
+
+func validateContainerOptions(fieldPath *field.Path, options deployment.ContainerOptions) field.ErrorList {
+	errs := field.ErrorList{}
+	errs = append(errs, validateProbe(fieldPath.Child("livenessProbe"), options.LivenessProbe)...)
+	errs = append(errs, validateProbe(fieldPath.Child("readinessProbe"), options.ReadinessProbe)...)
+
+	for i, source := range options.EnvFrom {
+		sourcePath := fieldPath.Child("envFrom").Index(i)
+		if source.Kind != deployment.EnvFromConfigMap && source.Kind != deployment.EnvFromSecret {
+			errs = append(errs, field.NotSupported(sourcePath.Child("kind"), source.Kind,
+				[]string{deployment.EnvFromConfigMap, deployment.EnvFromSecret}))
+		}
+		errs = append(errs, validateDNSSubdomain(sourcePath.Child("name"), source.Name)...)
+		if len(source.Prefix) > 0 {
+			for _, msg := range validation.IsEnvVarName(source.Prefix) {
+				errs = append(errs, field.Invalid(sourcePath.Child("prefix"), source.Prefix, msg))
+			}
+		}
+	}
+
+	mountPaths := map[string]bool{}
+	for i, mount := range options.VolumeMounts {
+		mountPath := fieldPath.Child("volumeMounts").Index(i)
+		errs = append(errs, validateDNSSubdomain(mountPath.Child("claimName"), mount.ClaimName)...)
+		if !path.IsAbs(mount.MountPath) {
+			errs = append(errs, field.Invalid(mountPath.Child("mountPath"), mount.MountPath, "must be an absolute path"))
+		} else if mountPaths[mount.MountPath] {
+			errs = append(errs, field.Duplicate(mountPath.Child("mountPath"), mount.MountPath))
+		}
+		mountPaths[mount.MountPath] = true
+		if path.IsAbs(mount.SubPath) {
+			errs = append(errs, field.Invalid(mountPath.Child("subPath"), mount.SubPath, "must be a relative path"))
+		}
+	}
+
+	return errs
+}
+
+func validateProbe(fieldPath *field.Path, probe *deployment.ProbeSpec) field.ErrorList {
+	errs := field.ErrorList{}
+	if probe == nil {
+		return errs
+	}
+
+	switch probe.Type {
+	case deployment.ProbeTypeHTTP:
+		if len(probe.Path) > 0 && !path.IsAbs(probe.Path) {
+			errs = append(errs, field.Invalid(fieldPath.Child("path"), probe.Path, "must start with /"))
+		}
+		errs = append(errs, validatePort(fieldPath.Child("port"), probe.Port)...)
+	case deployment.ProbeTypeTCP:
+		errs = append(errs, validatePort(fieldPath.Child("port"), probe.Port)...)
+	case deployment.ProbeTypeExec:
+		if len(probe.Command) == 0 {
+			errs = append(errs, field.Required(fieldPath.Child("command"), "command of exec probe is required"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(fieldPath.Child("type"), probe.Type, []string{
+			string(deployment.ProbeTypeHTTP), string(deployment.ProbeTypeTCP), string(deployment.ProbeTypeExec)}))
+	}
+
+	timings := []struct {
+		name  string
+		value int32
+	}{
+		{"initialDelaySeconds", probe.InitialDelaySeconds},
+		{"periodSeconds", probe.PeriodSeconds},
+		{"timeoutSeconds", probe.TimeoutSeconds},
+		{"failureThreshold", probe.FailureThreshold},
+	}
+	for _, timing := range timings {
+		if timing.value < 0 {
+			errs = append(errs, field.Invalid(fieldPath.Child(timing.name), timing.value, "must not be negative"))
+		}
+	}
+
+	return errs
+}
+
+func validatePersistentVolumeClaims(fieldPath *field.Path,
+	claims []deployment.PersistentVolumeClaimSpec) field.ErrorList {
+	errs := field.ErrorList{}
+	names := map[string]bool{}
+	for i, claim := range claims {
+		claimPath := fieldPath.Index(i)
+		errs = append(errs, validateDNSSubdomain(claimPath.Child("name"), claim.Name)...)
+		if names[claim.Name] {
+			errs = append(errs, field.Duplicate(claimPath.Child("name"), claim.Name))
+		}
+		names[claim.Name] = true
+
+		if claim.Size.Sign() <= 0 {
+			errs = append(errs, field.Invalid(claimPath.Child("size"), claim.Size.String(), "must be positive"))
+		}
+		if len(claim.AccessMode) > 0 && !supportedAccessModes[claim.AccessMode] {
+			errs = append(errs, field.NotSupported(claimPath.Child("accessMode"), claim.AccessMode, []string{
+				string(api.ReadWriteOnce), string(api.ReadOnlyMany), string(api.ReadWriteMany)}))
+		}
+		if claim.StorageClassName != nil {
+			errs = append(errs, validateDNSSubdomain(claimPath.Child("storageClassName"), *claim.StorageClassName)...)
+		}
+	}
+	return errs
+}
+
+func validateIngress(spec *deployment.AppDeploymentSpec) field.ErrorList {
+	errs := field.ErrorList{}
+	ingress := spec.Ingress
+	if ingress == nil {
+		return errs
+	}
+
+	ingressPath := field.NewPath("ingress")
+	if !spec.IsExternal || len(spec.PortMappings) == 0 {
+		errs = append(errs, field.Forbidden(ingressPath, "ingress can be created only for an external service"))
+	}
+
+	if len(ingress.Host) > 0 {
+		errs = append(errs, validateDNSSubdomain(ingressPath.Child("host"), ingress.Host)...)
+	}
+	if len(ingress.Path) > 0 && !path.IsAbs(ingress.Path) {
+		errs = append(errs, field.Invalid(ingressPath.Child("path"), ingress.Path, "must start with /"))
+	}
+
+	if ingress.ServicePort != 0 {
+		found := false
+		for _, mapping := range spec.PortMappings {
+			found = found || mapping.Port == ingress.ServicePort
+		}
+		if !found {
+			errs = append(errs, field.Invalid(ingressPath.Child("servicePort"), ingress.ServicePort,
+				"must be one of ports of port mappings"))
+		}
+	}
+
+	if ingress.TLSSecret != nil {
+		errs = append(errs, validateDNSSubdomain(ingressPath.Child("tlsSecret"), *ingress.TLSSecret)...)
+	}
+
+	return errs
+}
+
+func validateVariables(fieldPath *field.Path, variables []deployment.EnvironmentVariable) field.ErrorList {
+	errs := field.ErrorList{}
+	for i, variable := range variables {
+		for _, msg := range validation.IsEnvVarName(variable.Name) {
+			errs = append(errs, field.Invalid(fieldPath.Index(i).Child("name"), variable.Name, msg))
+		}
+	}
+	return errs
+}
+
+func validateImageReference(fieldPath *field.Path, image string) field.ErrorList {
+	if len(image) == 0 {
+		return field.ErrorList{field.Required(fieldPath, "image is required")}
+	}
+	// Parsing never fails with an error, invalid reference is reported in the validity.
+	validity, _ := ValidateImageReference(&ImageReferenceValiditySpec{Reference: image})
+	if !validity.Valid {
+		return field.ErrorList{field.Invalid(fieldPath, image, validity.Reason)}
+	}
+	return nil
+}
+
+func validateQuantity(fieldPath *field.Path, quantity *resource.Quantity) field.ErrorList {
+	if quantity != nil && quantity.Sign() < 0 {
+		return field.ErrorList{field.Invalid(fieldPath, quantity.String(), "must not be negative")}
+	}
+	return nil
+}
+
+func validatePort(fieldPath *field.Path, port int32) field.ErrorList {
+	errs := field.ErrorList{}
+	for _, msg := range validation.IsValidPortNum(int(port)) {
+		errs = append(errs, field.Invalid(fieldPath, port, msg))
+	}
+	return errs
+}
+
+func validateDNSLabel(fieldPath *field.Path, value string) field.ErrorList {
+	return toFieldErrors(fieldPath, value, validation.IsDNS1123Label(value))
+}
+
+func validateDNSSubdomain(fieldPath *field.Path, value string) field.ErrorList {
+	return toFieldErrors(fieldPath, value, validation.IsDNS1123Subdomain(value))
+}
+
+func validateQualifiedName(fieldPath *field.Path, value string) field.ErrorList {
+	return toFieldErrors(fieldPath, value, validation.IsQualifiedName(value))
+}
+
+func validateLabelValue(fieldPath *field.Path, value string) field.ErrorList {
+	return toFieldErrors(fieldPath, value, validation.IsValidLabelValue(value))
+}
+
+func toFieldErrors(fieldPath *field.Path, value string, messages []string) field.ErrorList {
+	errs := field.ErrorList{}
+	for _, msg := range messages {
+		errs = append(errs, field.Invalid(fieldPath, value, msg))
+	}
+	return errs
+}
+
+func containsProtocol(protocols []api.Protocol, protocol api.Protocol) bool {
+	for _, supported := range protocols {
+		if supported == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+func protocolNames(protocols []api.Protocol) []string {
+	var result []string
+	for _, protocol := range protocols {
+		result = append(result, string(protocol))
+	}
+	return result
+}
@@ -0,0 +1,197 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	api "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/kubernetes/dashboard/src/app/backend/resource/deployment"
+)
+
+func newValidAppDeploymentSpec() *deployment.AppDeploymentSpec {
+	cpuRequirement := resource.MustParse("100m")
+	cpuLimit := resource.MustParse("500m")
+	tlsSecret := "web-tls"
+	return &deployment.AppDeploymentSpec{
+		Name:           "web",
+		Namespace:      "default",
+		ContainerImage: "nginx:1.17",
+		Replicas:       2,
+		PortMappings:   []deployment.PortMapping{{Port: 80, TargetPort: 8080, Protocol: api.ProtocolTCP}},
+		Variables:      []deployment.EnvironmentVariable{{Name: "MODE", Value: "production"}},
+		IsExternal:     true,
+		CpuRequirement: &cpuRequirement,
+		Labels:         []deployment.Label{{Key: "app", Value: "web"}},
+		ContainerOptions: deployment.ContainerOptions{
+			CpuLimit:       &cpuLimit,
+			LivenessProbe:  &deployment.ProbeSpec{Type: deployment.ProbeTypeHTTP, Path: "/healthz", Port: 8080},
+			ReadinessProbe: &deployment.ProbeSpec{Type: deployment.ProbeTypeTCP, Port: 8080, PeriodSeconds: 5},
+			EnvFrom:        []deployment.EnvFromSource{{Kind: deployment.EnvFromConfigMap, Name: "web-config"}},
+			VolumeMounts:   []deployment.VolumeMountSpec{{ClaimName: "data", MountPath: "/data"}},
+		},
+		Containers: []deployment.ContainerSpec{{
+			Name:           "proxy",
+			ContainerImage: "envoyproxy/envoy:v1.11.0",
+			ContainerOptions: deployment.ContainerOptions{
+				ReadinessProbe: &deployment.ProbeSpec{Type: deployment.ProbeTypeExec, Command: []string{"true"}},
+			},
+		}},
+		InitContainers: []deployment.ContainerSpec{{
+			Name:           "migrate",
+			ContainerImage: "migrate:1",
+			ContainerOptions: deployment.ContainerOptions{
+				EnvFrom: []deployment.EnvFromSource{{Kind: deployment.EnvFromSecret, Name: "db"}},
+			},
+		}},
+		PersistentVolumeClaims: []deployment.PersistentVolumeClaimSpec{
+			{Name: "data", Size: resource.MustParse("1Gi"), AccessMode: api.ReadWriteOnce},
+		},
+		Ingress:      &deployment.IngressSpec{Host: "web.example.com", Path: "/", TLSSecret: &tlsSecret},
+		NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+	}
+}
+
+func TestValidateAppDeploymentSpec(t *testing.T) {
+	if err := ValidateAppDeploymentSpec(newValidAppDeploymentSpec()); err != nil {
+		t.Fatalf("Expected valid spec, but got %v", err)
+	}
+
+	cases := []struct {
+		info     string
+		modify   func(spec *deployment.AppDeploymentSpec)
+		expected []string
+	}{
+		{
+			"invalid name and image",
+			func(spec *deployment.AppDeploymentSpec) {
+				spec.Name = "Web"
+				spec.ContainerImage = ""
+			},
+			[]string{"name: Invalid value", "containerImage: Required value"},
+		},
+		{
+			"negative replicas",
+			func(spec *deployment.AppDeploymentSpec) { spec.Replicas = -1 },
+			[]string{"replicas: Invalid value"},
+		},
+		{
+			"UDP port of external service",
+			func(spec *deployment.AppDeploymentSpec) { spec.PortMappings[0].Protocol = api.ProtocolUDP },
+			[]string{"portMappings[0].protocol: Invalid value"},
+		},
+		{
+			"invalid port",
+			func(spec *deployment.AppDeploymentSpec) { spec.PortMappings[0].TargetPort = 70000 },
+			[]string{"portMappings[0].targetPort: Invalid value"},
+		},
+		{
+			"limit lower than request",
+			func(spec *deployment.AppDeploymentSpec) {
+				limit := resource.MustParse("50m")
+				spec.CpuLimit = &limit
+			},
+			[]string{"cpuLimit: Invalid value"},
+		},
+		{
+			"invalid probes",
+			func(spec *deployment.AppDeploymentSpec) {
+				spec.LivenessProbe.Path = "healthz"
+				spec.ReadinessProbe.Type = "grpc"
+				spec.Containers[0].ReadinessProbe.Command = nil
+			},
+			[]string{"livenessProbe.path: Invalid value", "readinessProbe.type: Unsupported value",
+				"containers[0].readinessProbe.command: Required value"},
+		},
+		{
+			"probe of init container",
+			func(spec *deployment.AppDeploymentSpec) {
+				spec.InitContainers[0].LivenessProbe = &deployment.ProbeSpec{Type: deployment.ProbeTypeTCP, Port: 80}
+			},
+			[]string{"initContainers[0].livenessProbe: Forbidden"},
+		},
+		{
+			"invalid environment source",
+			func(spec *deployment.AppDeploymentSpec) {
+				spec.InitContainers[0].EnvFrom[0].Kind = "Pod"
+				spec.EnvFrom[0].Prefix = "1_"
+			},
+			[]string{"initContainers[0].envFrom[0].kind: Unsupported value", "envFrom[0].prefix: Invalid value"},
+		},
+		{
+			"duplicate container names",
+			func(spec *deployment.AppDeploymentSpec) { spec.Containers[0].Name = "web" },
+			[]string{"containers[0].name: Duplicate value"},
+		},
+		{
+			"invalid volume mounts",
+			func(spec *deployment.AppDeploymentSpec) {
+				spec.VolumeMounts = append(spec.VolumeMounts,
+					deployment.VolumeMountSpec{ClaimName: "data", MountPath: "/data"},
+					deployment.VolumeMountSpec{ClaimName: "data", MountPath: "logs"})
+			},
+			[]string{"volumeMounts[1].mountPath: Duplicate value", "volumeMounts[2].mountPath: Invalid value"},
+		},
+		{
+			"invalid claim",
+			func(spec *deployment.AppDeploymentSpec) {
+				spec.PersistentVolumeClaims[0].Size = resource.Quantity{}
+				spec.PersistentVolumeClaims[0].AccessMode = "ReadWriteAll"
+			},
+			[]string{"persistentVolumeClaims[0].size: Invalid value",
+				"persistentVolumeClaims[0].accessMode: Unsupported value"},
+		},
+		{
+			"ingress of internal service",
+			func(spec *deployment.AppDeploymentSpec) {
+				spec.IsExternal = false
+				spec.Ingress.ServicePort = 443
+			},
+			[]string{"ingress: Forbidden", "ingress.servicePort: Invalid value"},
+		},
+		{
+			"invalid node selector",
+			func(spec *deployment.AppDeploymentSpec) { spec.NodeSelector["disk"] = "fast ssd" },
+			[]string{"nodeSelector[disk]: Invalid value"},
+		},
+	}
+
+	for _, c := range cases {
+		spec := newValidAppDeploymentSpec()
+		c.modify(spec)
+
+		err := ValidateAppDeploymentSpec(spec)
+		if err == nil {
+			t.Errorf("%s: expected error, but spec is valid", c.info)
+			continue
+		}
+
+		statusError, ok := err.(*k8serrors.StatusError)
+		if !ok || statusError.ErrStatus.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected bad request error, but got %v", c.info, err)
+			continue
+		}
+		for _, expected := range c.expected {
+			if !strings.Contains(statusError.Error(), expected) {
+				t.Errorf("%s: expected error to contain %q, but got %q", c.info, expected, statusError.Error())
+			}
+		}
+	}
+}
@@ -22,6 +22,12 @@ import (
 type ImageReferenceValiditySpec struct {
 	// Reference of the image
 	Reference string `json:"reference"`
+
+	// Namespace of the image pull secret. Used only by the registry check, see CheckImageReference.
+	Namespace string `json:"namespace"`
+
+	// The name of an image pull secret used to authenticate to the registry by the registry check.
+	ImagePullSecret *string `json:"imagePullSecret"`
 }
 
 // ImageReferenceValidity describes validity of the image reference.
@@ -30,6 +36,8 @@ type ImageReferenceValidity struct {
 	Valid bool `json:"valid"`
 	// Error reason when image reference is valid
 	Reason string `json:"reason"`
+	// Image as found in the registry. Set only by the registry check.
+	Registry *RegistryImage `json:"registry,omitempty"`
 }
 
 // ValidateImageReference validates image reference.
@@ -0,0 +1,535 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/reference"
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	client "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes/dashboard/src/app/backend/errors"
+)
+
+// registryTimeout limits the whole registry check including all its requests, so that slow or unreachable
+// registries do not block the deploy form.
+var registryTimeout = 10 * time.Second
+
+const (
+	// maxManifestSize limits the size of manifests and image configs read from the registry.
+	maxManifestSize = 4 << 20
+
+	// dockerHubDomain is the domain of normalized references of Docker Hub images.
+	dockerHubDomain = "docker.io"
+
+	// dockerHubRegistry is the host serving the registry API of Docker Hub.
+	dockerHubRegistry = "registry-1.docker.io"
+)
+
+// manifestMediaTypes are accepted manifest formats, lists first so that all platforms of the image are found.
+var manifestMediaTypes = []string{
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+}
+
+// challengeParamRegexp matches parameters of WWW-Authenticate header, e.g. realm="https://auth.docker.io/token".
+var challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// RegistryImage describes the image as found in its registry.
+type RegistryImage struct {
+	// True when the registry responded. Other fields are set only for reachable registries.
+	Reachable bool `json:"reachable"`
+
+	// True when the image exists in the registry and can be pulled with given credentials.
+	Exists bool `json:"exists"`
+
+	// Digest the tag resolves to.
+	Digest string `json:"digest,omitempty"`
+
+	// Media type of the manifest, e.g. the one of multi-platform manifest list.
+	MediaType string `json:"mediaType,omitempty"`
+
+	Platforms []ImagePlatform `json:"platforms,omitempty"`
+
+	// Compressed size of the image config and layers in bytes. For multi-platform images it is the size of the
+	// linux/amd64 image or of the first platform, if linux/amd64 is not available.
+	Size int64 `json:"size,omitempty"`
+
+	// Reason why the check could not be completed.
+	Error string `json:"error,omitempty"`
+}
+
+// ImagePlatform is a platform the image is built for.
+type ImagePlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant,omitempty"`
+}
+
+// descriptor references content in the registry.
+type descriptor struct {
+	MediaType string         `json:"mediaType"`
+	Digest    string         `json:"digest"`
+	Size      int64          `json:"size"`
+	Platform  *ImagePlatform `json:"platform,omitempty"`
+}
+
+// manifest is a union of image manifest and manifest list in Docker and OCI formats.
+type manifest struct {
+	MediaType string       `json:"mediaType"`
+	Config    descriptor   `json:"config"`
+	Layers    []descriptor `json:"layers"`
+	Manifests []descriptor `json:"manifests"`
+}
+
+// dockerConfigJSON is the content of the .dockerconfigjson key of image pull secrets.
+type dockerConfigJSON struct {
+	Auths map[string]dockerConfigEntry `json:"auths"`
+}
+
+// dockerConfigEntry are credentials of a single registry in docker config.
+type dockerConfigEntry struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Auth     string `json:"auth"`
+}
+
+// registryError is an error response of the registry. Registry which responded with an error is reachable.
+type registryError struct {
+	message string
+}
+
+func (err *registryError) Error() string {
+	return err.message
+}
+
+func newRegistryError(format string, args ...interface{}) error {
+	return &registryError{message: fmt.Sprintf(format, args...)}
+}
+
+// registryCredentials are credentials for the registry taken from the image pull secret.
+type registryCredentials struct {
+	username string
+	password string
+}
+
+// CheckImageReference validates syntax of the image reference and checks that the image exists in its registry. The registry is queried with credentials from the image pull secret of the spec.
+// Unreachable registry is reported in the result, not as an error, as the image can still be valid.
+func CheckImageReference(client client.Interface, spec *ImageReferenceValiditySpec) (*ImageReferenceValidity,
+	error) {
+	validity, err := ValidateImageReference(spec)
+	if err != nil || !validity.Valid {
+		return validity, err
+	}
+
+	named, err := reference.ParseNormalizedNamed(spec.Reference)
+	if err != nil {
+		validity.Registry = &RegistryImage{Error: err.Error()}
+		return validity, nil
+	}
+
+	var credentials *registryCredentials
+	if spec.ImagePullSecret != nil && len(*spec.ImagePullSecret) > 0 {
+		credentials, err = getRegistryCredentials(client, spec.Namespace, *spec.ImagePullSecret,
+			reference.Domain(named))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), registryTimeout)
+	defer cancel()
+	validity.Registry = inspectImage(ctx, http.DefaultClient, named, credentials)
+	return validity, nil
+}
+
+// getRegistryCredentials returns credentials of the registry from the image pull secret. Nil is returned if the
+// secret has no credentials for the registry, in which case the registry is accessed anonymously.
+func getRegistryCredentials(client client.Interface, namespace, name, domain string) (*registryCredentials,
+	error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	auths := map[string]dockerConfigEntry{}
+	switch secret.Type {
+	case v1.SecretTypeDockerConfigJson:
+		config := dockerConfigJSON{Auths: auths}
+		err = json.Unmarshal(secret.Data[v1.DockerConfigJsonKey], &config)
+		auths = config.Auths
+	case v1.SecretTypeDockercfg:
+		err = json.Unmarshal(secret.Data[v1.DockerConfigKey], &auths)
+	default:
+		return nil, errors.NewBadRequest(fmt.Sprintf("Secret %s is not an image pull secret", name))
+	}
+	if err != nil {
+		return nil, errors.NewBadRequest(fmt.Sprintf("Invalid image pull secret %s: %s", name, err.Error()))
+	}
+
+	for server, auth := range auths {
+		if normalizeRegistryHost(server) != normalizeRegistryHost(domain) {
+			continue
+		}
+
+		if len(auth.Username) == 0 && len(auth.Auth) > 0 {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, errors.NewBadRequest(fmt.Sprintf("Invalid auth of %s in image pull secret %s", server,
+					name))
+			}
+			parts := strings.SplitN(string(decoded), ":", 2)
+			auth.Username = parts[0]
+			if len(parts) == 2 {
+				auth.Password = parts[1]
+			}
+		}
+		return &registryCredentials{username: auth.Username, password: auth.Password}, nil
+	}
+
+	log.Printf("Image pull secret %s has no credentials for %s registry", name, domain)
+	return nil, nil
+}
+
+// normalizeRegistryHost returns the host of the registry server given as in docker config, e.g.
+// https://index.docker.io/v1/. All Docker Hub hosts are normalized to docker.io.
+func normalizeRegistryHost(server string) string {
+	host := strings.ToLower(server)
+	if index := strings.Index(host, "://"); index >= 0 {
+		host = host[index+3:]
+	}
+	host = strings.SplitN(host, "/", 2)[0]
+
+	switch host {
+	case "index.docker.io", dockerHubRegistry, "registry.hub.docker.com":
+		return dockerHubDomain
+	}
+	return host
+}
+
+// getRegistryURL returns base URL of the registry API. Registries on the loopback interface are accessed over plain
+// HTTP, as Docker does by default, other registries over HTTPS.
+func getRegistryURL(domain string) string {
+	if domain == dockerHubDomain {
+		domain = dockerHubRegistry
+	}
+
+	host := domain
+	if splitHost, _, err := net.SplitHostPort(domain); err == nil {
+		host = splitHost
+	}
+
+	scheme := "https"
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		scheme = "http"
+	}
+	return scheme + "://" + domain
+}
+
+// repository is a client of a single repository in the registry, which implements Docker Registry HTTP API V2.
+type repository struct {
+	client      *http.Client
+	baseURL     string
+	name        string
+	credentials *registryCredentials
+
+	// Authorization header obtained from the last authentication challenge.
+	authorization string
+}
+
+// inspectImage resolves the reference in its registry and describes the image. All requests are cancelled when the
+// context is done.
+func inspectImage(ctx context.Context, httpClient *http.Client, named reference.Named,
+	credentials *registryCredentials) *RegistryImage {
+	repo := &repository{
+		client:      httpClient,
+		baseURL:     getRegistryURL(reference.Domain(named)),
+		name:        reference.Path(named),
+		credentials: credentials,
+	}
+
+	ref := "latest"
+	if digested, ok := named.(reference.Digested); ok {
+		ref = digested.Digest().String()
+	} else if tagged, ok := named.(reference.Tagged); ok {
+		ref = tagged.Tag()
+	}
+
+	image, err := repo.inspect(ctx, ref)
+	if err != nil {
+		log.Printf("Cannot check image %s in registry: %s", named.String(), err.Error())
+		if image == nil {
+			_, responded := err.(*registryError)
+			image = &RegistryImage{Reachable: responded}
+		}
+		image.Error = err.Error()
+	}
+	return image
+}
+
+// inspect returns description of the image with given tag or digest. Partial result is returned together with the
+// error if the registry responded, but the image could not be fully described.
+func (repo *repository) inspect(ctx context.Context, ref string) (*RegistryImage, error) {
+	manifest, digest, found, err := repo.getManifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return &RegistryImage{Reachable: true}, nil
+	}
+
+	image := &RegistryImage{Reachable: true, Exists: true, Digest: digest, MediaType: manifest.MediaType}
+	if len(manifest.Manifests) > 0 {
+		var selected *descriptor
+		for i, entry := range manifest.Manifests {
+			// Attestations and other non-image manifests have unknown platform.
+			if entry.Platform == nil || entry.Platform.OS == "unknown" {
+				continue
+			}
+			image.Platforms = append(image.Platforms, *entry.Platform)
+			if selected == nil || (entry.Platform.OS == "linux" && entry.Platform.Architecture == "amd64") {
+				selected = &manifest.Manifests[i]
+			}
+		}
+
+		if selected == nil {
+			return image, nil
+		}
+		manifest, _, found, err = repo.getManifest(ctx, selected.Digest)
+		if err != nil {
+			return image, err
+		}
+		if !found {
+			return image, newRegistryError("manifest %s of the image list not found", selected.Digest)
+		}
+		image.Size = getImageSize(manifest)
+		return image, nil
+	}
+
+	image.Size = getImageSize(manifest)
+	if len(manifest.Config.Digest) > 0 {
+		platform := ImagePlatform{}
+		response, err := repo.get(ctx, "/blobs/"+manifest.Config.Digest, nil, &platform)
+		if err != nil {
+			return image, err
+		}
+		if response == nil {
+			return image, newRegistryError("config %s of the image not found", manifest.Config.Digest)
+		}
+		image.Platforms = []ImagePlatform{platform}
+	}
+	return image, nil
+}
+
+// getManifest returns manifest with given tag or digest and its digest. False is returned if it does not exist.
+func (repo *repository) getManifest(ctx context.Context, ref string) (*manifest, string, bool, error) {
+	result := new(manifest)
+	header := http.Header{"Accept": []string{strings.Join(manifestMediaTypes, ", ")}}
+	response, err := repo.get(ctx, "/manifests/"+ref, header, result)
+	if err != nil {
+		return nil, "", false, err
+	}
+	if response == nil {
+		return nil, "", false, nil
+	}
+
+	if len(result.MediaType) == 0 {
+		result.MediaType = response.header.Get("Content-Type")
+	}
+
+	digest := response.header.Get("Docker-Content-Digest")
+	if len(digest) == 0 {
+		sum := sha256.Sum256(response.body)
+		digest = "sha256:" + hex.EncodeToString(sum[:])
+	}
+	return result, digest, true, nil
+}
+
+// registryResponse is a successful response of the registry.
+type registryResponse struct {
+	header http.Header
+	body   []byte
+}
+
+// get sends GET request to the path relative to the repository and decodes JSON response into the result. Nil
+// response is returned if the content is not found. Authentication challenges are answered once.
+func (repo *repository) get(ctx context.Context, path string, header http.Header, result interface{}) (
+	*registryResponse, error) {
+	requestURL := fmt.Sprintf("%s/v2/%s%s", repo.baseURL, repo.name, path)
+	response, err := repo.do(ctx, requestURL, header)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusUnauthorized {
+		if err := repo.authenticate(ctx, response.Header.Get("WWW-Authenticate")); err != nil {
+			return nil, err
+		}
+
+		response.Body.Close()
+		response, err = repo.do(ctx, requestURL, header)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, newRegistryError("access to %s denied by the registry", repo.name)
+	default:
+		return nil, newRegistryError("registry responded with %s", response.Status)
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, maxManifestSize))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, newRegistryError("invalid response of the registry: %v", err)
+	}
+	return &registryResponse{header: response.Header, body: body}, nil
+}
+
+func (repo *repository) do(ctx context.Context, requestURL string, header http.Header) (*http.Response, error) {
+	request, err := http.NewRequest(http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(ctx)
+
+	for key, values := range header {
+		request.Header[key] = values
+	}
+	if len(repo.authorization) > 0 {
+		request.Header.Set("Authorization", repo.authorization)
+	}
+	return repo.client.Do(request)
+}
+
+// authenticate answers the authentication challenge of the registry. Bearer challenges are answered with a token
+// issued by the token service of the registry, basic challenges with the credentials directly.
+func (repo *repository) authenticate(ctx context.Context, challenge string) error {
+	scheme := strings.ToLower(strings.SplitN(challenge, " ", 2)[0])
+	params := map[string]string{}
+	for _, match := range challengeParamRegexp.FindAllStringSubmatch(challenge, -1) {
+		params[strings.ToLower(match[1])] = match[2]
+	}
+
+	switch scheme {
+	case "basic":
+		if repo.credentials == nil {
+			return newRegistryError("registry requires credentials for %s", repo.name)
+		}
+		credentials := repo.credentials.username + ":" + repo.credentials.password
+		repo.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+		return nil
+	case "bearer":
+		token, err := repo.getToken(ctx, params)
+		if err != nil {
+			return err
+		}
+		repo.authorization = "Bearer " + token
+		return nil
+	}
+
+	return newRegistryError("unsupported authentication challenge %q of the registry", challenge)
+}
+
+// getToken requests pull token for the repository from the token service given by the challenge parameters. Token
+// service has to use HTTPS, unless the registry itself is accessed over plain HTTP, as credentials are sent to it.
+func (repo *repository) getToken(ctx context.Context, params map[string]string) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || len(params["realm"]) == 0 {
+		return "", newRegistryError("invalid token realm %q of the registry", params["realm"])
+	}
+	if realm.Scheme != "https" && (realm.Scheme != "http" || !strings.HasPrefix(repo.baseURL, "http://")) {
+		return "", newRegistryError("token realm %q of the registry does not use HTTPS", params["realm"])
+	}
+
+	scope := params["scope"]
+	if len(scope) == 0 {
+		scope = fmt.Sprintf("repository:%s:pull", repo.name)
+	}
+
+	query := realm.Query()
+	query.Set("scope", scope)
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	request = request.WithContext(ctx)
+	if repo.credentials != nil {
+		request.SetBasicAuth(repo.credentials.username, repo.credentials.password)
+	}
+
+	response, err := repo.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", newRegistryError("token service of the registry responded with %s", response.Status)
+	}
+
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(response.Body, maxManifestSize)).Decode(&token); err != nil {
+		return "", newRegistryError("invalid response of the token service: %v", err)
+	}
+	if len(token.Token) == 0 {
+		token.Token = token.AccessToken
+	}
+	return token.Token, nil
+}
+
+// getImageSize returns the sum of sizes of the image config and layers.
+func getImageSize(manifest *manifest) int64 {
+	size := manifest.Config.Size
+	for _, layer := range manifest.Layers {
+		size += layer.Size
+	}
+	return size
+}
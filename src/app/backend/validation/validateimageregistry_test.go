@@ -0,0 +1,317 @@
+// Copyright 2017 The Kubernetes Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testToken    = "test-token"
+	configDigest = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	amd64Digest  = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+	appDigest    = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+)
+
+// multiPlatformIndex is an OCI image index of team/multi image.
+var multiPlatformIndex = fmt.Sprintf(`{
+	"schemaVersion": 2,
+	"manifests": [
+		{"digest": "sha256:arm64", "platform": {"os": "linux", "architecture": "arm64"}},
+		{"digest": %q, "platform": {"os": "linux", "architecture": "amd64"}},
+		{"digest": "sha256:attestation", "platform": {"os": "unknown", "architecture": "unknown"}}
+	]
+}`, amd64Digest)
+
+// newTestRegistry returns a registry stand-in serving private team/app image and public team/multi
+// multi-platform image. Private repository requires token issued for user:password.
+func newTestRegistry() *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "password" ||
+			r.URL.Query().Get("scope") != "repository:team/app:pull" || r.URL.Query().Get("service") != "test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"token": %q}`, testToken)
+	})
+
+	private := func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+testToken {
+				w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+					`Bearer realm="%s/token",service="test",scope="repository:team/app:pull"`, server.URL))
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			handler(w, r)
+		}
+	}
+
+	mux.HandleFunc("/v2/team/app/manifests/1.0", private(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), "application/vnd.docker.distribution.manifest.v2+json") {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Docker-Content-Digest", appDigest)
+		fmt.Fprintf(w, `{
+			"schemaVersion": 2,
+			"mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+			"config": {"digest": %q, "size": 100},
+			"layers": [{"size": 1000}, {"size": 2000}]
+		}`, configDigest)
+	}))
+	mux.HandleFunc("/v2/team/app/blobs/"+configDigest, private(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"os": "linux", "architecture": "arm", "variant": "v7", "rootfs": {}}`)
+	}))
+
+	mux.HandleFunc("/v2/team/multi/manifests/latest", func(w http.ResponseWriter, r *http.Request) {
+		// Digest header is not set, so the digest is computed from the body.
+		w.Header().Set("Content-Type", "application/vnd.oci.image.index.v1+json")
+		fmt.Fprint(w, multiPlatformIndex)
+	})
+	mux.HandleFunc("/v2/team/multi/manifests/"+amd64Digest, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"schemaVersion": 2, "config": {"size": 10}, "layers": [{"size": 500}]}`)
+	})
+
+	return server
+}
+
+func newPullSecret(name string, secretType v1.SecretType, key, data string) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "default"},
+		Type:       secretType,
+		Data:       map[string][]byte{key: []byte(data)},
+	}
+}
+
+func TestCheckImageReference(t *testing.T) {
+	server := newTestRegistry()
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	auth := base64.StdEncoding.EncodeToString([]byte("user:password"))
+	client := fake.NewSimpleClientset(
+		newPullSecret("registry", v1.SecretTypeDockerConfigJson, v1.DockerConfigJsonKey,
+			fmt.Sprintf(`{"auths": {"http://%s/": {"username": "user", "password": "password"}}}`, host)),
+		newPullSecret("legacy", v1.SecretTypeDockercfg, v1.DockerConfigKey,
+			fmt.Sprintf(`{%q: {"auth": %q}}`, host, auth)),
+		newPullSecret("other", v1.SecretTypeDockerConfigJson, v1.DockerConfigJsonKey,
+			`{"auths": {"https://index.docker.io/v1/": {"username": "user", "password": "password"}}}`),
+	)
+
+	cases := []struct {
+		info            string
+		reference       string
+		imagePullSecret string
+		expected        *RegistryImage
+	}{
+		{
+			"private image with token authentication",
+			host + "/team/app:1.0",
+			"registry",
+			&RegistryImage{
+				Reachable: true,
+				Exists:    true,
+				Digest:    appDigest,
+				MediaType: "application/vnd.docker.distribution.manifest.v2+json",
+				Platforms: []ImagePlatform{{OS: "linux", Architecture: "arm", Variant: "v7"}},
+				Size:      3100,
+			},
+		},
+		{
+			"credentials from legacy docker config",
+			host + "/team/app:1.0",
+			"legacy",
+			&RegistryImage{
+				Reachable: true,
+				Exists:    true,
+				Digest:    appDigest,
+				MediaType: "application/vnd.docker.distribution.manifest.v2+json",
+				Platforms: []ImagePlatform{{OS: "linux", Architecture: "arm", Variant: "v7"}},
+				Size:      3100,
+			},
+		},
+		{
+			"private image without credentials for the registry",
+			host + "/team/app:1.0",
+			"other",
+			&RegistryImage{Reachable: true, Error: "token service of the registry responded with 401 Unauthorized"},
+		},
+		{
+			"multi-platform image",
+			host + "/team/multi",
+			"",
+			&RegistryImage{
+				Reachable: true,
+				Exists:    true,
+				Digest:    fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(multiPlatformIndex))),
+				MediaType: "application/vnd.oci.image.index.v1+json",
+				Platforms: []ImagePlatform{{OS: "linux", Architecture: "arm64"}, {OS: "linux", Architecture: "amd64"}},
+				Size:      510,
+			},
+		},
+		{
+			"missing image",
+			host + "/team/missing:1.0",
+			"",
+			&RegistryImage{Reachable: true},
+		},
+	}
+
+	for _, c := range cases {
+		spec := &ImageReferenceValiditySpec{Reference: c.reference, Namespace: "default"}
+		if len(c.imagePullSecret) > 0 {
+			spec.ImagePullSecret = &c.imagePullSecret
+		}
+
+		validity, err := CheckImageReference(client, spec)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", c.info, err)
+			continue
+		}
+		if !validity.Valid {
+			t.Errorf("%s: expected valid reference, but got %#v", c.info, validity)
+		}
+
+		if !reflect.DeepEqual(validity.Registry, c.expected) {
+			t.Errorf("%s: expected %#v, but got %#v", c.info, c.expected, validity.Registry)
+		}
+	}
+}
+
+func TestCheckImageReferenceUnreachableRegistry(t *testing.T) {
+	server := newTestRegistry()
+	host := strings.TrimPrefix(server.URL, "http://")
+	server.Close()
+
+	spec := &ImageReferenceValiditySpec{Reference: host + "/team/app:1.0"}
+	validity, err := CheckImageReference(fake.NewSimpleClientset(), spec)
+	if err != nil {
+		t.Fatalf("Expected unreachable registry not to fail the validation, but got %v", err)
+	}
+	if !validity.Valid || validity.Registry == nil || validity.Registry.Reachable || len(validity.Registry.Error) == 0 {
+		t.Errorf("Expected valid reference with unreachable registry, but got %#v", validity)
+	}
+}
+
+func TestCheckImageReferenceSlowRegistry(t *testing.T) {
+	defer func(timeout time.Duration) { registryTimeout = timeout }(registryTimeout)
+	registryTimeout = 50 * time.Millisecond
+
+	// Every response is fast enough on its own, but the whole check exceeds the timeout.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(30 * time.Millisecond)
+		w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	name := "registry"
+	client := fake.NewSimpleClientset(newPullSecret(name, v1.SecretTypeDockerConfigJson, v1.DockerConfigJsonKey,
+		fmt.Sprintf(`{"auths": {%q: {"username": "user", "password": "password"}}}`, host)))
+	spec := &ImageReferenceValiditySpec{Reference: host + "/team/app:1.0",
+		Namespace: "default", ImagePullSecret: &name}
+
+	validity, err := CheckImageReference(client, spec)
+	if err != nil {
+		t.Fatalf("Expected slow registry not to fail the validation, but got %v", err)
+	}
+	if validity.Registry == nil || !strings.Contains(validity.Registry.Error, "deadline exceeded") {
+		t.Errorf("Expected registry check to time out, but got %#v", validity.Registry)
+	}
+}
+
+func TestGetTokenInsecureRealm(t *testing.T) {
+	repo := &repository{
+		client:      http.DefaultClient,
+		baseURL:     "https://registry.example.com",
+		name:        "team/app",
+		credentials: &registryCredentials{username: "user", password: "password"},
+	}
+
+	_, err := repo.getToken(context.Background(), map[string]string{"realm": "http://auth.example.com/token"})
+	if err == nil || !strings.Contains(err.Error(), "does not use HTTPS") {
+		t.Errorf("Expected error for token realm without HTTPS, but got %v", err)
+	}
+}
+
+func TestCheckImageReferenceInvalidReference(t *testing.T) {
+	spec := &ImageReferenceValiditySpec{Reference: "private.registry:5000/Test:1"}
+	validity, err := CheckImageReference(fake.NewSimpleClientset(), spec)
+	if err != nil || validity.Valid || validity.Registry != nil {
+		t.Errorf("Expected invalid reference without registry check, but got %#v, %v", validity, err)
+	}
+}
+
+func TestCheckImageReferenceInvalidSecret(t *testing.T) {
+	name := "opaque"
+	client := fake.NewSimpleClientset(newPullSecret(name, v1.SecretTypeOpaque, "key", "value"))
+	spec := &ImageReferenceValiditySpec{Reference: "private.registry:5000/test:1",
+		Namespace: "default", ImagePullSecret: &name}
+
+	if _, err := CheckImageReference(client, spec); err == nil {
+		t.Errorf("Expected error for secret which is not an image pull secret")
+	}
+}
+
+func TestNormalizeRegistryHost(t *testing.T) {
+	cases := map[string]string{
+		"https://index.docker.io/v1/":   "docker.io",
+		"registry-1.docker.io":          "docker.io",
+		"docker.io":                     "docker.io",
+		"http://Registry.Example.com/":  "registry.example.com",
+		"registry.example.com:5000":     "registry.example.com:5000",
+		"https://registry.example.com/": "registry.example.com",
+	}
+
+	for server, expected := range cases {
+		if actual := normalizeRegistryHost(server); actual != expected {
+			t.Errorf("Expected %s to be normalized to %s, but got %s", server, expected, actual)
+		}
+	}
+}
+
+func TestGetRegistryURL(t *testing.T) {
+	cases := map[string]string{
+		"docker.io":            "https://registry-1.docker.io",
+		"localhost:5000":       "http://localhost:5000",
+		"127.0.0.1:5000":       "http://127.0.0.1:5000",
+		"registry.example.com": "https://registry.example.com",
+	}
+
+	for domain, expected := range cases {
+		if actual := getRegistryURL(domain); actual != expected {
+			t.Errorf("Expected URL of %s to be %s, but got %s", domain, expected, actual)
+		}
+	}
+}